@@ -4,9 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/goccy/go-yaml"
 	"github.com/imdario/mergo"
 
@@ -49,6 +53,10 @@ var DefaultConf = Config{
 	Faucet: Faucet{
 		Host: "0.0.0.0:4500",
 	},
+	Console: Console{
+		// the console signs txs with the accounts of the chain, it is only served locally by default.
+		Host: "localhost:4501",
+	},
 }
 
 // Config is the user given configuration to do additional setup
@@ -57,6 +65,7 @@ type Config struct {
 	Accounts  []Account              `yaml:"accounts"`
 	Validator Validator              `yaml:"validator"`
 	Faucet    Faucet                 `yaml:"faucet"`
+	Console   Console                `yaml:"console"`
 	Client    Client                 `yaml:"client"`
 	Build     Build                  `yaml:"build"`
 	Init      Init                   `yaml:"init"`
@@ -82,6 +91,13 @@ type Account struct {
 	Address  string   `yaml:"address,omitempty"`
 	CoinType string   `yaml:"cointype,omitempty"`
 
+	// AccountIndex and AddressIndex are the indexes of the HD derivation path of the account.
+	AccountIndex uint32 `yaml:"account_index,omitempty"`
+	AddressIndex uint32 `yaml:"address_index,omitempty"`
+
+	// HDPath is the full HD derivation path of the account, the indexes are ignored when it's set.
+	HDPath string `yaml:"hd_path,omitempty"`
+
 	// The RPCAddress off the chain that account is issued at.
 	RPCAddress string `yaml:"rpc_address,omitempty"`
 }
@@ -108,6 +124,25 @@ type Proto struct {
 	// ThirdPartyPath is the relative path of where the third party proto files are
 	// located that used by the app.
 	ThirdPartyPaths []string `yaml:"third_party_paths"`
+
+	// Dependencies is a list of third party proto dependencies fetched from
+	// Buf modules or git repositories.
+	Dependencies []ProtoDependency `yaml:"dependencies"`
+}
+
+// ProtoDependency is a third party proto dependency, either a Buf module or a git repository.
+type ProtoDependency struct {
+	// Module is a Buf module reference, e.g. buf.build/cosmos/gogo-proto.
+	Module string `yaml:"module,omitempty"`
+
+	// Git is the URL of a git repository hosting proto files.
+	Git string `yaml:"git,omitempty"`
+
+	// Revision is the commit, tag or branch to pin the dependency to.
+	Revision string `yaml:"revision,omitempty"`
+
+	// Path is the dir of the proto files inside the git repository.
+	Path string `yaml:"path,omitempty"`
 }
 
 // Client configures code generation for clients.
@@ -120,6 +155,9 @@ type Client struct {
 
 	// OpenAPI configures OpenAPI spec generation for API.
 	OpenAPI OpenAPI `yaml:"openapi"`
+
+	// Plugins configures code generation with custom protoc plugins.
+	Plugins []Plugin `yaml:"plugins"`
 }
 
 // Vuex configures code generation for Vuex.
@@ -139,6 +177,26 @@ type OpenAPI struct {
 	Path string `yaml:"path"`
 }
 
+// Plugin configures code generation with a custom protoc plugin.
+type Plugin struct {
+	// Name is the name of the plugin, it is used in protoc flags like --name_out.
+	Name string `yaml:"name"`
+
+	// Binary is the path of the plugin's binary relative to the app.
+	// protoc-gen-name is looked up in $PATH when it's empty.
+	Binary string `yaml:"binary,omitempty"`
+
+	// Options are passed to the plugin.
+	Options []string `yaml:"options,omitempty"`
+
+	// Out is the output dir of the generated code relative to the app.
+	Out string `yaml:"out"`
+
+	// IncludeThirdParty enables code generation for the third party modules
+	// used by the app, including the Cosmos SDK.
+	IncludeThirdParty bool `yaml:"include_third_party,omitempty"`
+}
+
 // Faucet configuration.
 type Faucet struct {
 	// Name is faucet account's name.
@@ -159,6 +217,139 @@ type Faucet struct {
 
 	// Port number for faucet server to listen at.
 	Port int `yaml:"port"`
+
+	// RateLimit limits the number of transfer requests.
+	RateLimit FaucetRateLimit `yaml:"rate_limit"`
+
+	// Verifier verifies the transfer requests with a challenge.
+	Verifier FaucetVerifier `yaml:"verifier"`
+
+	// APIKeys authenticate the clients with higher quotas.
+	APIKeys []FaucetAPIKey `yaml:"api_keys"`
+
+	// AllowedAddresses are the only addresses that can receive tokens when it's not empty.
+	AllowedAddresses []string `yaml:"allowed_addresses"`
+
+	// DeniedAddresses are the addresses that cannot receive tokens.
+	DeniedAddresses []string `yaml:"denied_addresses"`
+
+	// RequestLog is the path of a file to log the transfer requests in as JSON lines.
+	RequestLog string `yaml:"request_log"`
+
+	// Pool sends the transfers from a pool of accounts refilled by the faucet account.
+	Pool FaucetPool `yaml:"pool"`
+
+	// DenomAliases maps the names that coins can be requested by to their denoms, e.g. to IBC denoms.
+	DenomAliases map[string]string `yaml:"denom_aliases"`
+
+	// Chains are the other chains served by the faucet.
+	Chains []FaucetChain `yaml:"chains"`
+}
+
+// FaucetChain configures another chain served by the faucet.
+type FaucetChain struct {
+	// ChainID is the id of the chain, it is fetched from the node when it's empty.
+	ChainID string `yaml:"chain_id"`
+
+	// RPC is the address of the chain's node.
+	RPC string `yaml:"rpc"`
+
+	// AddressPrefix is the bech32 prefix of the chain's account addresses.
+	AddressPrefix string `yaml:"address_prefix"`
+
+	// Name is the name of the account to send the tokens from.
+	Name string `yaml:"name"`
+
+	// Mnemonic is the mnemonic of the account, the account must be in the keyring when it's empty.
+	Mnemonic string `yaml:"mnemonic"`
+
+	// CoinType is the coin type number of the account for HD derivation (BIP-0044).
+	CoinType string `yaml:"cointype"`
+
+	// Coins holds the type of coins that can be sent.
+	Coins []string `yaml:"coins"`
+
+	// CoinsMax holds the max amounts of coins that can be sent to a single account.
+	CoinsMax []string `yaml:"coins_max"`
+
+	// DenomAliases maps the names that coins can be requested by to their denoms.
+	DenomAliases map[string]string `yaml:"denom_aliases"`
+}
+
+// FaucetPool configures the pool of accounts that the faucet sends the transfers from.
+type FaucetPool struct {
+	// Size is the number of accounts in the pool.
+	Size int `yaml:"size"`
+
+	// Mnemonic is the mnemonic to derive the accounts from, the mnemonic of the faucet account by default.
+	Mnemonic string `yaml:"mnemonic"`
+
+	// RefillThreshold holds the balances under which the accounts are refilled.
+	RefillThreshold []string `yaml:"refill_threshold"`
+
+	// RefillAmount holds the amounts of coins sent to refill the accounts.
+	RefillAmount []string `yaml:"refill_amount"`
+}
+
+// FaucetRateLimit configures the rate limits of the faucet.
+type FaucetRateLimit struct {
+	// PerIP is the max. number of requests that a single IP can make within the window.
+	PerIP int `yaml:"per_ip"`
+
+	// Global is the max. number of requests that all clients can make within the window.
+	Global int `yaml:"global"`
+
+	// Window is the duration that the request counts are kept for.
+	Window string `yaml:"window"`
+
+	// IPHeader is the header to read the client IPs from when the faucet is behind a proxy.
+	IPHeader string `yaml:"ip_header"`
+
+	// TrustedProxies is the number of proxies in front of the faucet that add the client IPs to IPHeader.
+	TrustedProxies int `yaml:"trusted_proxies"`
+}
+
+// FaucetVerifier configures how the faucet verifies the transfer requests.
+type FaucetVerifier struct {
+	// Type is the type of the verifier: pow, captcha or test.
+	Type string `yaml:"type"`
+
+	// Difficulty is the number of leading zero bits required in the proof of work.
+	Difficulty uint `yaml:"difficulty"`
+
+	// URL is the verification endpoint of the captcha provider.
+	URL string `yaml:"url"`
+
+	// Secret is the secret key of the captcha provider.
+	Secret string `yaml:"secret"`
+
+	// Token is the token accepted by the test verifier.
+	Token string `yaml:"token"`
+}
+
+// FaucetAPIKey is an API key that grants a tier of quotas.
+type FaucetAPIKey struct {
+	// Name identifies the key.
+	Name string `yaml:"name"`
+
+	// Key is the key that is sent by clients in the Authorization header.
+	Key string `yaml:"key"`
+
+	// RateLimit is the max. number of requests that can be made with the key within
+	// the rate limit window. There is no limit when it's 0.
+	RateLimit int `yaml:"rate_limit"`
+
+	// CoinsMax overwrites the max. amounts that can be transferred to a single user.
+	CoinsMax []string `yaml:"coins_max"`
+}
+
+// Console configures the developer console served with the chain.
+type Console struct {
+	// Disabled turns off the console.
+	Disabled bool `yaml:"disabled"`
+
+	// Host is the host of the console server.
+	Host string `yaml:"host"`
 }
 
 // Init overwrites sdk configurations with given values.
@@ -219,6 +410,14 @@ func validate(conf Config) error {
 	if conf.Validator.Name == "" {
 		return &ValidationError{"validator is required"}
 	}
+	for _, account := range conf.Accounts {
+		if account.HDPath == "" {
+			continue
+		}
+		if _, err := hd.NewParamsFromPath(account.HDPath); err != nil {
+			return &ValidationError{fmt.Sprintf("invalid HD path of account %s: %s", account.Name, err)}
+		}
+	}
 	return nil
 }
 
@@ -256,6 +455,68 @@ func FaucetHost(conf Config) string {
 	return host
 }
 
+// Addresses returns the addresses of the servers started to serve a chain with conf.
+func Addresses(conf Config) []string {
+	return []string{
+		conf.Host.RPC,
+		conf.Host.P2P,
+		conf.Host.Prof,
+		conf.Host.GRPC,
+		conf.Host.GRPCWeb,
+		conf.Host.API,
+		FaucetHost(conf),
+		conf.Console.Host,
+	}
+}
+
+// OffsetPorts returns conf with the ports of the addresses of all its servers increased by offset.
+func OffsetPorts(conf Config, offset int) (Config, error) {
+	addresses := []*string{
+		&conf.Host.RPC,
+		&conf.Host.P2P,
+		&conf.Host.Prof,
+		&conf.Host.GRPC,
+		&conf.Host.GRPCWeb,
+		&conf.Host.API,
+		&conf.Faucet.Host,
+		&conf.Console.Host,
+	}
+
+	// the legacy port option of the faucet is replaced by its host.
+	conf.Faucet.Host = FaucetHost(conf)
+	conf.Faucet.Port = 0
+
+	for _, address := range addresses {
+		offsetAddress, err := offsetPort(*address, offset)
+		if err != nil {
+			return Config{}, err
+		}
+		*address = offsetAddress
+	}
+
+	return conf, nil
+}
+
+// offsetPort returns address with its port increased by offset, a scheme prefix is kept.
+func offsetPort(address string, offset int) (string, error) {
+	var scheme string
+	if i := strings.Index(address, "://"); i != -1 {
+		scheme, address = address[:i+3], address[i+3:]
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	p, err := strconv.Atoi(port)
+	if err != nil {
+		return "", fmt.Errorf("invalid port of address %s: %w", address, err)
+	}
+
+	return scheme + net.JoinHostPort(host, strconv.Itoa(p+offset)), nil
+}
+
 // CreateConfigDir creates config directory if it is not created yet.
 func CreateConfigDir() error {
 	confPath, err := ConfigDirPath()
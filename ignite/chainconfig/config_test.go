@@ -75,6 +75,42 @@ validator:
 	}, conf.Validator)
 }
 
+func TestHDPathParse(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token"]
+    account_index: 1
+    address_index: 2
+  - name: you
+    coins: ["5000token"]
+    hd_path: m/44'/118'/0'/0/3
+validator:
+  name: user1
+  staked: "100000000stake"
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+
+	require.NoError(t, err)
+	require.Equal(t, []Account{
+		{
+			Name:         "me",
+			Coins:        []string{"1000token"},
+			AccountIndex: 1,
+			AddressIndex: 2,
+		},
+		{
+			Name:   "you",
+			Coins:  []string{"5000token"},
+			HDPath: "m/44'/118'/0'/0/3",
+		},
+	}, conf.Accounts)
+
+	_, err = Parse(strings.NewReader(strings.Replace(confyml, "m/44'/118'/0'/0/3", "44/118", 1)))
+	require.Error(t, err)
+}
+
 func TestParseInvalid(t *testing.T) {
 	confyml := `
 accounts:
@@ -139,3 +175,63 @@ faucet:
 	require.NoError(t, err)
 	require.Equal(t, ":4700", FaucetHost(conf))
 }
+
+func TestParseClientPlugins(t *testing.T) {
+	confyml := `
+accounts:
+  - name: me
+    coins: ["1000token", "100000000stake"]
+validator:
+  name: user1
+  staked: "100000000stake"
+client:
+  plugins:
+    - name: go-grpc
+      options: ["paths=source_relative"]
+      out: "gen/go"
+      include_third_party: true
+    - name: custom
+      binary: "bin/protoc-gen-custom"
+      out: "gen/custom"
+`
+
+	conf, err := Parse(strings.NewReader(confyml))
+
+	require.NoError(t, err)
+	require.Equal(t, []Plugin{
+		{
+			Name:              "go-grpc",
+			Options:           []string{"paths=source_relative"},
+			Out:               "gen/go",
+			IncludeThirdParty: true,
+		},
+		{
+			Name:   "custom",
+			Binary: "bin/protoc-gen-custom",
+			Out:    "gen/custom",
+		},
+	}, conf.Client.Plugins)
+}
+
+func TestOffsetPorts(t *testing.T) {
+	conf := DefaultConf
+	conf.Host.RPC = "tcp://0.0.0.0:26657"
+	conf.Faucet.Port = 4600
+
+	conf, err := OffsetPorts(conf, 10)
+	require.NoError(t, err)
+	require.Equal(t, Host{
+		RPC:     "tcp://0.0.0.0:26667",
+		P2P:     "0.0.0.0:26666",
+		Prof:    "0.0.0.0:6070",
+		GRPC:    "0.0.0.0:9100",
+		GRPCWeb: "0.0.0.0:9101",
+		API:     "0.0.0.0:1327",
+	}, conf.Host)
+	require.Equal(t, ":4610", FaucetHost(conf))
+	require.Equal(t, "localhost:4511", conf.Console.Host)
+
+	conf.Host.API = "localhost"
+	_, err = OffsetPorts(conf, 10)
+	require.Error(t, err)
+}
@@ -1,14 +1,19 @@
 package ignitecmd
 
 import (
+	"fmt"
 	"os"
 
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+
 	"github.com/spf13/cobra"
 	flag "github.com/spf13/pflag"
 
 	"github.com/ignite/cli/ignite/pkg/cliui/cliquiz"
 	"github.com/ignite/cli/ignite/pkg/cliui/entrywriter"
 	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/xurl"
 )
 
 const (
@@ -17,6 +22,12 @@ const (
 	flagNonInteractive = "non-interactive"
 	flagKeyringBackend = "keyring-backend"
 	flagFrom           = "from"
+	flagMultisig       = "multisig"
+	flagAccountNumber  = "account-number"
+	flagSequence       = "sequence"
+	flagAccountIndex   = "account-index"
+	flagAddressIndex   = "address-index"
+	flagHDPath         = "hd-path"
 )
 
 func NewAccount() *cobra.Command {
@@ -35,6 +46,13 @@ Ignite CLI uses accounts to interact with the Ignite blockchain, use an IBC rela
 	c.AddCommand(NewAccountList())
 	c.AddCommand(NewAccountImport())
 	c.AddCommand(NewAccountExport())
+	c.AddCommand(NewAccountCreateMultisig())
+	c.AddCommand(NewAccountSignTx())
+	c.AddCommand(NewAccountCombineSignatures())
+	c.AddCommand(NewAccountDerive())
+	c.AddCommand(NewAccountBackup())
+	c.AddCommand(NewAccountRestore())
+	c.AddCommand(NewAccountTx())
 
 	return c
 }
@@ -74,6 +92,28 @@ func getFrom(cmd *cobra.Command) string {
 	return prefix
 }
 
+func flagSetHDDerivation() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Uint32(flagAccountIndex, 0, "Account index of the HD derivation path")
+	fs.Uint32(flagAddressIndex, 0, "Address index of the HD derivation path")
+	fs.String(flagHDPath, "", "Full HD derivation path, e.g. m/44'/118'/0'/0/0, overrides the indexes")
+	return fs
+}
+
+func getDerivationOptions(cmd *cobra.Command) []cosmosaccount.DerivationOption {
+	var (
+		accountIndex, _ = cmd.Flags().GetUint32(flagAccountIndex)
+		addressIndex, _ = cmd.Flags().GetUint32(flagAddressIndex)
+		hdPath, _       = cmd.Flags().GetString(flagHDPath)
+	)
+
+	return []cosmosaccount.DerivationOption{
+		cosmosaccount.AccountIndex(accountIndex),
+		cosmosaccount.AddressIndex(addressIndex),
+		cosmosaccount.HDPath(hdPath),
+	}
+}
+
 func flagSetAccountImportExport() *flag.FlagSet {
 	fs := flag.NewFlagSet("", flag.ContinueOnError)
 	fs.Bool(flagNonInteractive, false, "Do not enter into interactive mode")
@@ -102,3 +142,61 @@ func getPassphrase(cmd *cobra.Command) (string, error) {
 
 	return pass, nil
 }
+
+func flagSetMultisigSigning() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(flagMultisig, "", "Multisig account that signs the tx")
+	fs.String(flagNode, "http://localhost:26657", "RPC address of the blockchain's node")
+	fs.String(flagChainID, "", "Chain ID of the blockchain, fetched from the node by default")
+	fs.Uint64(flagAccountNumber, 0, "Account number of the multisig account, fetched from the node by default")
+	fs.Uint64(flagSequence, 0, "Sequence of the multisig account, fetched from the node by default")
+	return fs
+}
+
+// getSigningClient returns a client to sign txs with the accounts of the Ignite keyring.
+// the node isn't queried when the chain id is set.
+func getSigningClient(cmd *cobra.Command, options ...cosmosclient.Option) (cosmosclient.Client, error) {
+	var (
+		node, _    = cmd.Flags().GetString(flagNode)
+		chainID, _ = cmd.Flags().GetString(flagChainID)
+	)
+
+	nodeAddress, err := xurl.HTTP(node)
+	if err != nil {
+		return cosmosclient.Client{}, fmt.Errorf("invalid node address format: %w", err)
+	}
+
+	options = append([]cosmosclient.Option{
+		cosmosclient.WithNodeAddress(nodeAddress),
+		cosmosclient.WithChainID(chainID),
+		cosmosclient.WithAddressPrefix(getAddressPrefix(cmd)),
+		cosmosclient.WithHome(cosmosaccount.KeyringHome),
+		cosmosclient.WithKeyringServiceName(sdktypes.KeyringServiceName()),
+		cosmosclient.WithKeyringBackend(getKeyringBackend(cmd)),
+	}, options...)
+
+	return cosmosclient.New(cmd.Context(), options...)
+}
+
+// getSignerData returns the account number and sequence to sign offline with,
+// nil is returned when they must be fetched from the node.
+func getSignerData(cmd *cobra.Command) (*cosmosclient.SignerData, error) {
+	var (
+		accountNumber, _ = cmd.Flags().GetUint64(flagAccountNumber)
+		sequence, _      = cmd.Flags().GetUint64(flagSequence)
+		offline          = cmd.Flags().Changed(flagAccountNumber)
+	)
+
+	if offline != cmd.Flags().Changed(flagSequence) {
+		return nil, fmt.Errorf("both --%s and --%s are required to sign offline", flagAccountNumber, flagSequence)
+	}
+
+	if !offline {
+		return nil, nil
+	}
+
+	return &cosmosclient.SignerData{
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+	}, nil
+}
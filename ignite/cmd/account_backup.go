@@ -0,0 +1,70 @@
+package ignitecmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+func NewAccountBackup() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "backup [name]...",
+		Short: "Back up accounts to a passphrase encrypted file",
+		Long: `Back up the accounts with the names, or all accounts, to a file encrypted with a passphrase.
+
+The accounts are restored by "ignite account restore" to any keyring backend. Ledger accounts
+cannot be backed up, they are skipped when all accounts are backed up.
+
+The backup keeps the name, algorithm and coin type of the accounts. The keyring doesn't record
+the derivation paths of the local accounts, their coin type is written as "unknown" and their
+private keys are backed up instead.`,
+		Example: "  ignite account backup --out accounts.backup",
+		RunE:    accountBackupHandler,
+	}
+
+	c.Flags().String(flagOut, "", "File to write the backup to")
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetAccountImportExport())
+
+	return c
+}
+
+func accountBackupHandler(cmd *cobra.Command, args []string) error {
+	out, _ := cmd.Flags().GetString(flagOut)
+	if out == "" {
+		return fmt.Errorf("--%s is required", flagOut)
+	}
+
+	passphrase, err := getPassphrase(cmd)
+	if err != nil {
+		return err
+	}
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	archive, skipped, err := ca.Backup(passphrase, args...)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(out, archive, 0o600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Accounts backed up to file: %s\n", out)
+
+	if len(skipped) > 0 {
+		fmt.Printf("Ledger accounts are not backed up: %s\n", strings.Join(skipped, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package ignitecmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const flagBroadcast = "broadcast"
+
+func NewAccountCombineSignatures() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "combine-signatures [tx-file] [signature-file]...",
+		Short: "Combine the signatures of the keys of a multisig account into its tx",
+		Long: `Combine the signatures made by "ignite account sign-tx" with the keys of a multisig account
+into the tx of the account. The signatures are verified, and at least the threshold of the multisig
+is required.
+
+The signed tx is written as JSON, or broadcasted to --node with --broadcast.`,
+		Example: `  ignite account combine-signatures tx.json alice.json bob.json --multisig treasury --broadcast`,
+		Args:    cobra.MinimumNArgs(2),
+		RunE:    accountCombineSignaturesHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetMultisigSigning())
+	c.Flags().StringP(flagOutput, "o", "", "File to write the signed tx to, printed by default")
+	c.Flags().Bool(flagBroadcast, false, "Broadcast the signed tx")
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+
+	return c
+}
+
+func accountCombineSignaturesHandler(cmd *cobra.Command, args []string) error {
+	var (
+		multisig, _  = cmd.Flags().GetString(flagMultisig)
+		output, _    = cmd.Flags().GetString(flagOutput)
+		broadcast, _ = cmd.Flags().GetBool(flagBroadcast)
+	)
+
+	if multisig == "" {
+		return fmt.Errorf("--%s is required", flagMultisig)
+	}
+
+	txJSON, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	var signatures [][]byte
+	for _, path := range args[1:] {
+		signature, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		signatures = append(signatures, signature)
+	}
+
+	signer, err := getSignerData(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := getSigningClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	signedJSON, err := client.CombineSignatures(txJSON, multisig, signatures, signer)
+	if err != nil {
+		return err
+	}
+
+	if !broadcast {
+		return writeOutput(output, signedJSON)
+	}
+
+	resp, err := client.BroadcastSignedTx(signedJSON)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Tx broadcasted: %s\n", resp.TxHash)
+	return nil
+}
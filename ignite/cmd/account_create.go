@@ -17,6 +17,7 @@ func NewAccountCreate() *cobra.Command {
 	}
 
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetHDDerivation())
 
 	return c
 }
@@ -31,7 +32,7 @@ func accountCreateHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	_, mnemonic, err := ca.Create(name)
+	_, mnemonic, err := ca.Create(name, getDerivationOptions(cmd)...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,55 @@
+package ignitecmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+const (
+	flagKeys      = "keys"
+	flagThreshold = "threshold"
+)
+
+func NewAccountCreateMultisig() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "create-multisig [name]",
+		Short: "Create a multisig account from the keys of existing accounts",
+		Long: `Create a multisig account from the public keys of existing accounts.
+
+A number of the keys equal to the threshold must sign the txs of the multisig account,
+see "ignite account sign-tx" and "ignite account combine-signatures".`,
+		Example: "  ignite account create-multisig treasury --keys alice,bob,carol --threshold 2",
+		Args:    cobra.ExactArgs(1),
+		RunE:    accountCreateMultisigHandler,
+	}
+
+	c.Flags().StringSlice(flagKeys, nil, "Accounts whose keys make up the multisig")
+	c.Flags().Int(flagThreshold, 1, "Number of keys required to sign a tx")
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+
+	return c
+}
+
+func accountCreateMultisigHandler(cmd *cobra.Command, args []string) error {
+	var (
+		name         = args[0]
+		keys, _      = cmd.Flags().GetStringSlice(flagKeys)
+		threshold, _ = cmd.Flags().GetInt(flagThreshold)
+	)
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	acc, err := ca.CreateMultisig(name, keys, threshold)
+	if err != nil {
+		return err
+	}
+
+	return printAccounts(cmd, acc)
+}
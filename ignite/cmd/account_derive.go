@@ -0,0 +1,95 @@
+package ignitecmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui/cliquiz"
+	"github.com/ignite/cli/ignite/pkg/cliui/entrywriter"
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+const (
+	flagCount  = "count"
+	flagImport = "import"
+)
+
+func NewAccountDerive() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "derive [name]",
+		Short: "List the accounts derived from a mnemonic",
+		Long: `List the accounts derived from a mnemonic at consecutive address indexes, starting from the
+HD derivation path set by the flags.
+
+The accounts are named <name>-<address index>, and are imported in the keyring with --import.`,
+		Example: "  ignite account derive wallet --count 5 --account-index 1",
+		Args:    cobra.ExactArgs(1),
+		RunE:    accountDeriveHandler,
+	}
+
+	c.Flags().String(flagSecret, "", "Your mnemonic (use interactive mode instead to securely pass your mnemonic)")
+	c.Flags().Int(flagCount, 1, "Number of accounts to derive")
+	c.Flags().Bool(flagImport, false, "Import the derived accounts in the keyring")
+	c.Flags().AddFlagSet(flagSetHDDerivation())
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+
+	return c
+}
+
+func accountDeriveHandler(cmd *cobra.Command, args []string) error {
+	var (
+		name         = args[0]
+		mnemonic, _  = cmd.Flags().GetString(flagSecret)
+		count, _     = cmd.Flags().GetInt(flagCount)
+		doImport, _  = cmd.Flags().GetBool(flagImport)
+		derivOptions = getDerivationOptions(cmd)
+	)
+
+	if count < 1 {
+		return fmt.Errorf("--%s must be at least 1", flagCount)
+	}
+
+	if mnemonic == "" {
+		if err := cliquiz.Ask(
+			cliquiz.NewQuestion("Your mnemonic", &mnemonic, cliquiz.HideAnswer(), cliquiz.Required())); err != nil {
+			return err
+		}
+	}
+
+	if !bip39.IsMnemonicValid(mnemonic) {
+		return errors.New("mnemonic is not valid")
+	}
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	derived, err := ca.Derive(mnemonic, count, derivOptions...)
+	if err != nil {
+		return err
+	}
+
+	var entries [][]string
+
+	for _, acc := range derived {
+		accName := fmt.Sprintf("%s-%d", name, acc.AddressIndex)
+
+		if doImport {
+			if _, err := ca.Import(accName, mnemonic, "", cosmosaccount.HDPath(acc.HDPath)); err != nil {
+				return fmt.Errorf("cannot import %s: %w", accName, err)
+			}
+		}
+
+		entries = append(entries, []string{accName, acc.HDPath, acc.Address(getAddressPrefix(cmd))})
+	}
+
+	return entrywriter.MustWrite(os.Stdout, []string{"name", "hd path", "address"}, entries...)
+}
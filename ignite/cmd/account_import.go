@@ -25,6 +25,7 @@ func NewAccountImport() *cobra.Command {
 	c.Flags().String(flagSecret, "", "Your mnemonic or path to your private key (use interactive mode instead to securely pass your mnemonic)")
 	c.Flags().AddFlagSet(flagSetKeyringBackend())
 	c.Flags().AddFlagSet(flagSetAccountImportExport())
+	c.Flags().AddFlagSet(flagSetHDDerivation())
 
 	return c
 }
@@ -65,7 +66,7 @@ func accountImportHandler(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	if _, err := ca.Import(name, secret, passphrase); err != nil {
+	if _, err := ca.Import(name, secret, passphrase, getDerivationOptions(cmd)...); err != nil {
 		return err
 	}
 
@@ -0,0 +1,71 @@
+package ignitecmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+func NewAccountRestore() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "restore [file] [name]...",
+		Short: "Restore accounts from a backup",
+		Long: `Restore the accounts with the names, or all accounts, from a backup made by "ignite account backup".
+
+Existing accounts are never overwritten, the accounts whose name or address already exists are
+reported as conflicts instead.`,
+		Example: "  ignite account restore accounts.backup --keyring-backend os",
+		Args:    cobra.MinimumNArgs(1),
+		RunE:    accountRestoreHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetAccountImportExport())
+	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+
+	return c
+}
+
+func accountRestoreHandler(cmd *cobra.Command, args []string) error {
+	archive, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := getPassphrase(cmd)
+	if err != nil {
+		return err
+	}
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	report, err := ca.Restore(archive, passphrase, args[1:]...)
+	if err != nil {
+		return err
+	}
+
+	if len(report.Restored) > 0 {
+		fmt.Printf("Restored %d account(s):\n\n", len(report.Restored))
+		if err := printAccounts(cmd, report.Restored...); err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
+	if len(report.Conflicts) > 0 {
+		fmt.Printf("%d account(s) not restored:\n\n", len(report.Conflicts))
+		for _, conflict := range report.Conflicts {
+			fmt.Printf("  %s: %s\n", conflict.Name, conflict.Reason)
+		}
+	}
+
+	return nil
+}
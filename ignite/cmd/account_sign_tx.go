@@ -0,0 +1,82 @@
+package ignitecmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func NewAccountSignTx() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "sign-tx [tx-file]",
+		Short: "Sign a tx of a multisig account with one of its keys",
+		Long: `Sign a tx of a multisig account with the key of an account of the multisig.
+
+The tx is read as JSON, e.g. from a tx generated by the blockchain's binary with --generate-only.
+The signature is written as JSON to be combined with the signatures of the other keys by
+"ignite account combine-signatures".
+
+The tx is signed offline when --chain-id, --account-number and --sequence are set,
+otherwise they are fetched from --node.`,
+		Example: `  ignite account sign-tx tx.json --from alice --multisig treasury --output alice.json`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    accountSignTxHandler,
+	}
+
+	c.Flags().String(flagFrom, "", "Account of the multisig that signs the tx")
+	c.Flags().AddFlagSet(flagSetMultisigSigning())
+	c.Flags().StringP(flagOutput, "o", "", "File to write the signature to, printed by default")
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+
+	return c
+}
+
+func accountSignTxHandler(cmd *cobra.Command, args []string) error {
+	var (
+		from        = getFrom(cmd)
+		multisig, _ = cmd.Flags().GetString(flagMultisig)
+		output, _   = cmd.Flags().GetString(flagOutput)
+	)
+
+	if from == "" || multisig == "" {
+		return fmt.Errorf("both --%s and --%s are required", flagFrom, flagMultisig)
+	}
+
+	txJSON, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	signer, err := getSignerData(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := getSigningClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	signature, err := client.SignMultisigTx(txJSON, from, multisig, signer)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(output, signature)
+}
+
+// writeOutput writes the JSON content to the output file, or prints it when there is no output.
+func writeOutput(output string, content []byte) error {
+	if output == "" {
+		fmt.Println(string(content))
+		return nil
+	}
+
+	if err := os.WriteFile(output, content, 0o644); err != nil {
+		return fmt.Errorf("cannot write the output: %w", err)
+	}
+
+	return nil
+}
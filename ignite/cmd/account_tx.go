@@ -0,0 +1,67 @@
+package ignitecmd
+
+import (
+	"github.com/spf13/cobra"
+	flag "github.com/spf13/pflag"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+)
+
+const flagGas = "gas"
+
+func NewAccountTx() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "tx [command]",
+		Short: "Build, sign and broadcast txs in separate steps",
+		Long: `Build, sign and broadcast txs in separate steps, e.g. to sign txs on an offline machine.
+
+The txs are written as JSON in the same format as the txs generated by the blockchain's binary
+with --generate-only, so the txs of both tools can be used interchangeably.`,
+		Example: `  ignite account tx build msg.json --from alice --gas 200000 --fees 1000stake -o tx.json
+  ignite account tx sign tx.json --from alice --chain-id mars --account-number 3 --sequence 12 -o signed.json
+  ignite account tx broadcast signed.json --node http://localhost:26657`,
+	}
+
+	c.AddCommand(NewAccountTxBuild())
+	c.AddCommand(NewAccountTxSign())
+	c.AddCommand(NewAccountTxBroadcast())
+
+	return c
+}
+
+func flagSetTxFees() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.Uint64(flagGas, 0, "Gas limit of the tx, simulated by the node by default")
+	fs.Float64(flagGasAdjustment, 1, "Factor the simulated gas is multiplied by")
+	fs.String(flagGasPrices, "", "Gas prices to compute the fees of the tx from, e.g. 0.025stake")
+	fs.String(flagFees, "", "Fees of the tx, e.g. 1000stake")
+	fs.String(flagFeeGranter, "", "Address of the account that pays the fees of the tx with a fee grant")
+	return fs
+}
+
+func getTxFeeOptions(cmd *cobra.Command) []cosmosclient.Option {
+	var (
+		gas, _           = cmd.Flags().GetUint64(flagGas)
+		gasAdjustment, _ = cmd.Flags().GetFloat64(flagGasAdjustment)
+		gasPrices, _     = cmd.Flags().GetString(flagGasPrices)
+		fees, _          = cmd.Flags().GetString(flagFees)
+		feeGranter, _    = cmd.Flags().GetString(flagFeeGranter)
+	)
+
+	return []cosmosclient.Option{
+		cosmosclient.WithGas(gas),
+		cosmosclient.WithGasAdjustment(gasAdjustment),
+		cosmosclient.WithGasPrices(gasPrices),
+		cosmosclient.WithFees(fees),
+		cosmosclient.WithFeeGranter(feeGranter),
+	}
+}
+
+func flagSetTxSigning() *flag.FlagSet {
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	fs.String(flagNode, "http://localhost:26657", "RPC address of the blockchain's node")
+	fs.String(flagChainID, "", "Chain ID of the blockchain, fetched from the node by default")
+	fs.Uint64(flagAccountNumber, 0, "Account number of the signer, fetched from the node by default")
+	fs.Uint64(flagSequence, 0, "Sequence of the signer, fetched from the node by default")
+	return fs
+}
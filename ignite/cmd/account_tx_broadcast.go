@@ -0,0 +1,48 @@
+package ignitecmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func NewAccountTxBroadcast() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "broadcast [tx-file]",
+		Short: "Broadcast a signed tx",
+		Long: `Broadcast a signed tx to --node and wait for it to be included in a block.
+
+The tx can be signed by "ignite account tx sign", "ignite account combine-signatures"
+or by the blockchain's binary.`,
+		Example: `  ignite account tx broadcast signed.json --node http://localhost:26657`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    accountTxBroadcastHandler,
+	}
+
+	c.Flags().String(flagNode, "http://localhost:26657", "RPC address of the blockchain's node")
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+
+	return c
+}
+
+func accountTxBroadcastHandler(cmd *cobra.Command, args []string) error {
+	txJSON, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	client, err := getSigningClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.BroadcastSignedTx(txJSON)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Tx broadcasted: %s (height %d)\n", resp.TxHash, resp.Height)
+	return nil
+}
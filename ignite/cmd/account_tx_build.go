@@ -0,0 +1,76 @@
+package ignitecmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/spf13/cobra"
+)
+
+func NewAccountTxBuild() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "build [msg]...",
+		Short: "Build an unsigned tx",
+		Long: `Build an unsigned tx with the messages of an account.
+
+Each message is a JSON object with the type URL of the message in "@type", or a file
+containing it. The type must be a message of the Cosmos SDK modules known by Ignite CLI.
+
+The gas of the tx is simulated with --node unless --gas is set, the tx is built offline
+when both --gas and --chain-id are set.`,
+		Example: `  ignite account tx build '{"@type":"/cosmos.bank.v1beta1.MsgSend","from_address":"cosmos1...","to_address":"cosmos1...","amount":[{"denom":"stake","amount":"10"}]}' --from alice`,
+		Args:    cobra.MinimumNArgs(1),
+		RunE:    accountTxBuildHandler,
+	}
+
+	c.Flags().String(flagFrom, "", "Account that sends the tx")
+	c.Flags().String(flagNode, "http://localhost:26657", "RPC address of the blockchain's node")
+	c.Flags().String(flagChainID, "", "Chain ID of the blockchain, fetched from the node by default")
+	c.Flags().AddFlagSet(flagSetTxFees())
+	c.Flags().StringP(flagOutput, "o", "", "File to write the tx to, printed by default")
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+
+	return c
+}
+
+func accountTxBuildHandler(cmd *cobra.Command, args []string) error {
+	var (
+		from      = getFrom(cmd)
+		output, _ = cmd.Flags().GetString(flagOutput)
+	)
+
+	if from == "" {
+		return fmt.Errorf("--%s is required", flagFrom)
+	}
+
+	client, err := getSigningClient(cmd, getTxFeeOptions(cmd)...)
+	if err != nil {
+		return err
+	}
+
+	var msgs []sdktypes.Msg
+	for _, arg := range args {
+		msgJSON := []byte(arg)
+		if !json.Valid(msgJSON) {
+			if msgJSON, err = os.ReadFile(arg); err != nil {
+				return err
+			}
+		}
+
+		msg, err := client.DecodeMsgJSON(msgJSON)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+
+	txJSON, err := client.BuildUnsignedTx(from, msgs...)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(output, txJSON)
+}
@@ -0,0 +1,66 @@
+package ignitecmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+func NewAccountTxSign() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "sign [tx-file]",
+		Short: "Sign a tx",
+		Long: `Sign a tx with an account and write the signed tx as JSON.
+
+The signature is added to the existing signatures of the tx, so a tx with several signers
+can be signed by each of them in turn.
+
+The tx is signed offline when --chain-id, --account-number and --sequence are set,
+otherwise they are fetched from --node.`,
+		Example: `  ignite account tx sign tx.json --from alice --chain-id mars --account-number 3 --sequence 12 -o signed.json`,
+		Args:    cobra.ExactArgs(1),
+		RunE:    accountTxSignHandler,
+	}
+
+	c.Flags().String(flagFrom, "", "Account that signs the tx")
+	c.Flags().AddFlagSet(flagSetTxSigning())
+	c.Flags().StringP(flagOutput, "o", "", "File to write the signed tx to, printed by default")
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+
+	return c
+}
+
+func accountTxSignHandler(cmd *cobra.Command, args []string) error {
+	var (
+		from      = getFrom(cmd)
+		output, _ = cmd.Flags().GetString(flagOutput)
+	)
+
+	if from == "" {
+		return fmt.Errorf("--%s is required", flagFrom)
+	}
+
+	txJSON, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	signer, err := getSignerData(cmd)
+	if err != nil {
+		return err
+	}
+
+	client, err := getSigningClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	signedJSON, err := client.SignTx(txJSON, from, signer)
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(output, signedJSON)
+}
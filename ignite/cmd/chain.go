@@ -19,6 +19,8 @@ func NewChain() *cobra.Command {
 		NewChainInit(),
 		NewChainFaucet(),
 		NewChainSimulate(),
+		NewChainProto(),
+		NewChainQuery(),
 	)
 
 	return c
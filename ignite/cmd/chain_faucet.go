@@ -1,12 +1,18 @@
 package ignitecmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/spf13/cobra"
 
+	"github.com/ignite/cli/ignite/chainconfig"
 	"github.com/ignite/cli/ignite/pkg/chaincmd"
+	"github.com/ignite/cli/ignite/pkg/cosmosfaucet"
+	"github.com/ignite/cli/ignite/pkg/xurl"
 	"github.com/ignite/cli/ignite/services/chain"
 )
 
@@ -43,9 +49,19 @@ func chainFaucetHandler(cmd *cobra.Command, args []string) error {
 	}
 
 	faucet, err := c.Faucet(cmd.Context())
+	if errors.Is(err, cosmosfaucet.ErrLedgerInUse) {
+		// the ledger is held by the faucet served with the chain, the coins are requested from it.
+		if err := transferFromServedFaucet(cmd.Context(), c, toAddress, coins); err != nil {
+			return err
+		}
+
+		fmt.Println("📨 Coins sent.")
+		return nil
+	}
 	if err != nil {
 		return err
 	}
+	defer faucet.Close()
 
 	// parse provided coins
 	parsedCoins, err := sdk.ParseCoinsNormalized(coins)
@@ -61,3 +77,26 @@ func chainFaucetHandler(cmd *cobra.Command, args []string) error {
 	fmt.Println("📨 Coins sent.")
 	return nil
 }
+
+// transferFromServedFaucet requests coins for toAddress from the faucet served by chain serve.
+func transferFromServedFaucet(ctx context.Context, c *chain.Chain, toAddress, coins string) error {
+	conf, err := c.Config()
+	if err != nil {
+		return err
+	}
+
+	faucetAddress, err := xurl.HTTP(chainconfig.FaucetHost(conf))
+	if err != nil {
+		return err
+	}
+
+	res, err := cosmosfaucet.NewClient(faucetAddress).Transfer(ctx, cosmosfaucet.NewTransferRequest(toAddress, strings.Split(coins, ",")))
+	if err != nil {
+		return fmt.Errorf("cannot request coins from the faucet at %s: %w", faucetAddress, err)
+	}
+	if res.Error != "" {
+		return errors.New(res.Error)
+	}
+
+	return nil
+}
@@ -0,0 +1,17 @@
+package ignitecmd
+
+import "github.com/spf13/cobra"
+
+// NewChainProto returns a command that groups sub commands related to the app's proto files.
+func NewChainProto() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "proto [command]",
+		Short: "Perform actions on the proto files of the blockchain",
+		Args:  cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(NewChainProtoLint())
+	c.AddCommand(NewChainProtoUpdate())
+
+	return c
+}
@@ -0,0 +1,86 @@
+package ignitecmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/protolint"
+)
+
+const (
+	flagJSON         = "json"
+	flagExcludeRules = "exclude"
+	flagSignerFields = "signer-fields"
+)
+
+// NewChainProtoLint returns a new command to lint the app's proto files.
+func NewChainProtoLint() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "lint",
+		Short: "Check that proto files follow Cosmos SDK module conventions",
+		Long: fmt.Sprintf(`Check that proto files follow Cosmos SDK module conventions.
+
+Available rules:
+	- %s`, strings.Join(protolint.Rules, "\n\t- ")),
+		Args: cobra.NoArgs,
+		RunE: chainProtoLintHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetHome())
+	c.Flags().Bool(flagJSON, false, "Print the issues in JSON format")
+	c.Flags().StringSlice(flagExcludeRules, []string{}, "Rules to exclude from the checks")
+	c.Flags().StringSlice(flagSignerFields, []string{}, "Additional field names recognized as Msg signers")
+
+	return c
+}
+
+func chainProtoLintHandler(cmd *cobra.Command, _ []string) error {
+	var (
+		isJSON, _       = cmd.Flags().GetBool(flagJSON)
+		excludeRules, _ = cmd.Flags().GetStringSlice(flagExcludeRules)
+		signerFields, _ = cmd.Flags().GetStringSlice(flagSignerFields)
+	)
+
+	c, err := newChainWithHomeFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	issues, err := c.LintProto(
+		cmd.Context(),
+		protolint.ExcludeRules(excludeRules...),
+		protolint.SignerFields(signerFields...),
+	)
+	if err != nil {
+		return err
+	}
+
+	// the JSON output is meant to be consumed by other tools,
+	// so it is printed as is even when there are no issues.
+	if isJSON {
+		if issues == nil {
+			issues = []protolint.Issue{}
+		}
+
+		if err := json.NewEncoder(os.Stdout).Encode(issues); err != nil {
+			return err
+		}
+	} else if len(issues) == 0 {
+		fmt.Println("✅ No proto issues found.")
+	} else {
+		for _, issue := range issues {
+			fmt.Println(issue)
+		}
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("\n❌ %d proto issue(s) found", len(issues))
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package ignitecmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui/clispinner"
+	"github.com/ignite/cli/ignite/pkg/protodep"
+)
+
+// NewChainProtoUpdate returns a new command to update the proto dependencies of the app.
+func NewChainProtoUpdate() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "update",
+		Short: "Update proto dependencies and pin their new versions in the lock file",
+		Long: fmt.Sprintf(`Update proto dependencies and pin their new versions in the lock file.
+
+Proto dependencies are defined in the config.yml under build.proto.dependencies as Buf
+modules or git repositories. Their resolved commits are pinned in the %s file, which
+should be committed to make code generation deterministic.`, protodep.LockFileName),
+		Args: cobra.NoArgs,
+		RunE: chainProtoUpdateHandler,
+	}
+
+	flagSetPath(c)
+	c.Flags().AddFlagSet(flagSetHome())
+
+	return c
+}
+
+func chainProtoUpdateHandler(cmd *cobra.Command, _ []string) error {
+	s := clispinner.New().SetText("Updating proto dependencies...")
+	defer s.Stop()
+
+	c, err := newChainWithHomeFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	if err := c.UpdateProtoDependencies(cmd.Context()); err != nil {
+		return err
+	}
+
+	s.Stop()
+	fmt.Printf("🔒 Proto dependencies are pinned in %s.\n", protodep.LockFileName)
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package ignitecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/xurl"
+)
+
+const (
+	flagGRPCAddress = "grpc-address"
+	flagHeight      = "height"
+)
+
+func NewChainQuery() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "query [method] [request-json]",
+		Short: "Query the state of a blockchain with any gRPC query method",
+		Long: `Query the state of a blockchain with a gRPC query method of any of its modules.
+
+The method is the fully qualified name of the gRPC method and the request is its proto JSON.
+The queries of the Cosmos SDK modules are resolved by Ignite, the queries of the other modules
+are resolved with the gRPC reflection service of the node at --grpc-address.
+
+All the pages of a list query are fetched and merged into the response, unless the request
+sets its pagination.`,
+		Example: `  ignite chain query cosmos.bank.v1beta1.Query/AllBalances '{"address":"cosmos1..."}'
+  ignite chain query mars.mars.Query/Params --grpc-address localhost:9090 --height 100`,
+		Aliases: []string{"q"},
+		Args:    cobra.RangeArgs(1, 2),
+		RunE:    chainQueryHandler,
+	}
+
+	c.Flags().String(flagNode, "http://localhost:26657", "RPC address of the blockchain's node")
+	c.Flags().String(flagGRPCAddress, "localhost:9090", "gRPC address of the blockchain's node")
+	c.Flags().Int64(flagHeight, 0, "Height of the state to query, the latest state by default")
+
+	return c
+}
+
+func chainQueryHandler(cmd *cobra.Command, args []string) error {
+	var (
+		node, _        = cmd.Flags().GetString(flagNode)
+		grpcAddress, _ = cmd.Flags().GetString(flagGRPCAddress)
+		height, _      = cmd.Flags().GetInt64(flagHeight)
+		requestJSON    = []byte("{}")
+	)
+
+	if len(args) == 2 {
+		requestJSON = []byte(args[1])
+	}
+
+	var request map[string]json.RawMessage
+	if err := json.Unmarshal(requestJSON, &request); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	nodeAddress, err := xurl.HTTP(node)
+	if err != nil {
+		return fmt.Errorf("invalid node address format: %w", err)
+	}
+
+	client, err := cosmosclient.New(
+		cmd.Context(),
+		cosmosclient.WithNodeAddress(nodeAddress),
+		cosmosclient.WithGRPCAddress(grpcAddress),
+		cosmosclient.WithKeyringBackend(cosmosaccount.KeyringMemory),
+	)
+	if err != nil {
+		return err
+	}
+
+	options := []cosmosclient.QueryOption{cosmosclient.QueryHeight(height)}
+	if _, ok := request["pagination"]; !ok {
+		options = append(options, cosmosclient.QueryAllPages())
+	}
+
+	responseJSON, err := client.QueryJSON(cmd.Context(), args[0], requestJSON, options...)
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, responseJSON, "", "  "); err != nil {
+		return err
+	}
+
+	fmt.Println(out.String())
+	return nil
+}
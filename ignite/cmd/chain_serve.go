@@ -1,15 +1,20 @@
 package ignitecmd
 
 import (
+	"path/filepath"
+
 	"github.com/spf13/cobra"
 
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
 	"github.com/ignite/cli/ignite/services/chain"
+	"github.com/ignite/cli/ignite/services/ibcdevnet"
 )
 
 const (
 	flagForceReset = "force-reset"
 	flagResetOnce  = "reset-once"
 	flagConfig     = "config"
+	flagIBCPeer    = "ibc-peer"
 )
 
 // NewChainServe creates a new serve command to serve a blockchain.
@@ -31,6 +36,8 @@ func NewChainServe() *cobra.Command {
 	c.Flags().BoolP(flagForceReset, "f", false, "Force reset of the app state on start and every source change")
 	c.Flags().BoolP(flagResetOnce, "r", false, "Reset of the app state on first start")
 	c.Flags().StringP(flagConfig, "c", "", "Ignite config file (default: ./config.yml)")
+	c.Flags().String(flagIBCPeer, "", "Path of a second app to serve next to the app and connect to it with a relayer")
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
 
 	return c
 }
@@ -48,6 +55,9 @@ func chainServeHandler(cmd *cobra.Command, args []string) error {
 		chainOption = append(chainOption, chain.CheckDependencies())
 	}
 
+	// the peer chain uses its own config and home.
+	peerChainOption := chainOption
+
 	// check if custom config is defined
 	config, err := cmd.Flags().GetString(flagConfig)
 	if err != nil {
@@ -85,5 +95,36 @@ func chainServeHandler(cmd *cobra.Command, args []string) error {
 		serveOptions = append(serveOptions, chain.ServeResetOnce())
 	}
 
-	return c.Serve(cmd.Context(), cacheStorage, serveOptions...)
+	peerPath, err := cmd.Flags().GetString(flagIBCPeer)
+	if err != nil {
+		return err
+	}
+	if peerPath == "" {
+		return c.Serve(cmd.Context(), cacheStorage, serveOptions...)
+	}
+
+	// serve the chain with a peer chain connected by a relayer
+	absPeerPath, err := filepath.Abs(peerPath)
+	if err != nil {
+		return err
+	}
+
+	peer, err := chain.New(absPeerPath, peerChainOption...)
+	if err != nil {
+		return err
+	}
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	devnet, err := ibcdevnet.New(c, peer, ca)
+	if err != nil {
+		return err
+	}
+
+	return devnet.Serve(cmd.Context(), cacheStorage, serveOptions...)
 }
@@ -70,6 +70,7 @@ ignite scaffold chain github.com/username/mars`,
 	c.AddCommand(NewNetwork())
 	c.AddCommand(NewAccount())
 	c.AddCommand(NewRelayer())
+	c.AddCommand(NewFaucet())
 	c.AddCommand(NewTools())
 	c.AddCommand(NewDocs())
 	c.AddCommand(NewVersion())
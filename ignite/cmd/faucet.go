@@ -0,0 +1,22 @@
+package ignitecmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// NewFaucet returns a new faucet command.
+func NewFaucet() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "faucet [command]",
+		Short: "Run a faucet for a running blockchain",
+		Long: `Run a faucet for a blockchain whose node is already running, without the source code
+or the config.yml of the blockchain.
+
+To run the faucet of a blockchain that you develop, enable it in the config.yml and use "ignite chain serve".`,
+		Args: cobra.ExactArgs(1),
+	}
+
+	c.AddCommand(NewFaucetServe())
+
+	return c
+}
@@ -0,0 +1,226 @@
+package ignitecmd
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmosfaucet"
+	"github.com/ignite/cli/ignite/pkg/xhttp"
+	"github.com/ignite/cli/ignite/pkg/xurl"
+)
+
+const (
+	flagNode            = "node"
+	flagMnemonicFile    = "mnemonic-file"
+	flagAccount         = "account"
+	flagCoinType        = "coin-type"
+	flagCoins           = "coins"
+	flagCoinsMax        = "coins-max"
+	flagRefreshWindow   = "refresh-window"
+	flagFaucetHost      = "host"
+	flagLedger          = "ledger"
+	flagRequestLog      = "request-log"
+	flagRateLimitPerIP  = "rate-limit-per-ip"
+	flagRateLimitGlobal = "rate-limit-global"
+	flagRateLimitWindow = "rate-limit-window"
+	flagIPHeader        = "ip-header"
+	flagTrustedProxies  = "trusted-proxies"
+	flagPoolSize        = "pool-size"
+	flagRefillThreshold = "refill-threshold"
+	flagRefillAmount    = "refill-amount"
+	flagAPIAddress      = "api-address"
+)
+
+// NewFaucetServe creates a new faucet serve command to run a faucet for a running blockchain.
+func NewFaucetServe() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve a faucet for a running blockchain",
+		Long: `Serve a faucet for a blockchain whose node is already running, e.g. a shared testnet.
+
+The faucet sends tokens from the account of the mnemonic in --mnemonic-file, the mnemonic is only kept
+in memory. Without a mnemonic file, the faucet uses the --account of the keyring in --home.
+
+The API of the faucet is the same as the one of the faucet served by "ignite chain serve".`,
+		Example: `  ignite faucet serve --node https://rpc.testnet.example.com:443 --address-prefix cosmos \
+    --mnemonic-file ./faucet.mnemonic --coins 10000000stake --coins-max 100000000stake`,
+		Args: cobra.NoArgs,
+		RunE: faucetServeHandler,
+	}
+
+	c.Flags().String(flagNode, "http://localhost:26657", "RPC address of the blockchain's node")
+	c.Flags().String(flagChainID, "", "Chain ID of the blockchain, fetched from the node by default")
+	c.Flags().String(flagMnemonicFile, "", "File that contains the mnemonic of the faucet account")
+	c.Flags().String(flagAccount, cosmosfaucet.DefaultAccountName, "Name of the faucet account in the keyring")
+	c.Flags().String(flagCoinType, "", "Coin type number of the faucet account for HD derivation")
+	c.Flags().AddFlagSet(flagSetAccountPrefixes())
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+	c.Flags().String(flagHome, "", "Directory of the keyring, the home of the blockchain by default")
+	c.Flags().StringSlice(flagCoins, nil, "Coins sent per request (default 10000000uatom)")
+	c.Flags().StringSlice(flagCoinsMax, nil, "Max. amounts of coins sent to a single account")
+	c.Flags().Duration(flagRefreshWindow, cosmosfaucet.DefaultRefreshWindow, "Time after which the max. amounts are reset")
+	c.Flags().String(flagFaucetHost, ":4500", "Host and port to serve the faucet at")
+	c.Flags().String(flagGasPrices, "", "Gas prices of the transfers, e.g. 0.025stake (discovered from the node by default)")
+	c.Flags().String(flagAPIAddress, "", "API address of the blockchain's node for the faucet's API page")
+	c.Flags().String(flagLedger, "", "File to record the transfers in (default ~/.ignite/faucet/<chain-id>.db)")
+	c.Flags().String(flagRequestLog, "", "File to log the transfer requests in as JSON lines")
+	c.Flags().Int(flagRateLimitPerIP, 0, "Max. number of requests from a single IP within the rate limit window")
+	c.Flags().Int(flagRateLimitGlobal, 0, "Max. number of requests from all clients within the rate limit window")
+	c.Flags().Duration(flagRateLimitWindow, cosmosfaucet.DefaultRateLimitWindow, "Duration that requests are counted for")
+	c.Flags().String(flagIPHeader, "", "Header to read the client IPs from when the faucet is behind a proxy")
+	c.Flags().Int(flagTrustedProxies, 1, "Number of proxies in front of the faucet that add the client IPs to the header")
+	c.Flags().Int(flagPoolSize, 0, "Number of accounts derived from the mnemonic to send the transfers from")
+	c.Flags().StringSlice(flagRefillThreshold, nil, "Balances under which the pool accounts are refilled by the faucet account")
+	c.Flags().StringSlice(flagRefillAmount, nil, "Amounts of coins sent to refill the pool accounts")
+
+	return c
+}
+
+func faucetServeHandler(cmd *cobra.Command, _ []string) error {
+	var (
+		node, _            = cmd.Flags().GetString(flagNode)
+		chainID, _         = cmd.Flags().GetString(flagChainID)
+		mnemonicFile, _    = cmd.Flags().GetString(flagMnemonicFile)
+		account, _         = cmd.Flags().GetString(flagAccount)
+		coinType, _        = cmd.Flags().GetString(flagCoinType)
+		home, _            = cmd.Flags().GetString(flagHome)
+		coins, _           = cmd.Flags().GetStringSlice(flagCoins)
+		coinsMax, _        = cmd.Flags().GetStringSlice(flagCoinsMax)
+		refreshWindow, _   = cmd.Flags().GetDuration(flagRefreshWindow)
+		host, _            = cmd.Flags().GetString(flagFaucetHost)
+		gasPrices, _       = cmd.Flags().GetString(flagGasPrices)
+		apiAddress, _      = cmd.Flags().GetString(flagAPIAddress)
+		ledger, _          = cmd.Flags().GetString(flagLedger)
+		requestLog, _      = cmd.Flags().GetString(flagRequestLog)
+		rateLimitPerIP, _  = cmd.Flags().GetInt(flagRateLimitPerIP)
+		rateLimitGlobal, _ = cmd.Flags().GetInt(flagRateLimitGlobal)
+		rateLimitWindow, _ = cmd.Flags().GetDuration(flagRateLimitWindow)
+		ipHeader, _        = cmd.Flags().GetString(flagIPHeader)
+		trustedProxies, _  = cmd.Flags().GetInt(flagTrustedProxies)
+		poolSize, _        = cmd.Flags().GetInt(flagPoolSize)
+		refillThreshold, _ = cmd.Flags().GetStringSlice(flagRefillThreshold)
+		refillAmount, _    = cmd.Flags().GetStringSlice(flagRefillAmount)
+		keyringBackend     = getKeyringBackend(cmd)
+	)
+
+	var mnemonic string
+
+	// the account of the mnemonic is kept in memory to not store it on the disk.
+	if mnemonicFile != "" {
+		content, err := os.ReadFile(mnemonicFile)
+		if err != nil {
+			return err
+		}
+
+		if mnemonic = strings.TrimSpace(string(content)); mnemonic == "" {
+			return fmt.Errorf("mnemonic file %s is empty", mnemonicFile)
+		}
+
+		keyringBackend = cosmosaccount.KeyringMemory
+	}
+
+	nodeAddress, err := xurl.HTTP(node)
+	if err != nil {
+		return fmt.Errorf("invalid node address format: %w", err)
+	}
+
+	clientOptions := []cosmosclient.Option{
+		cosmosclient.WithNodeAddress(nodeAddress),
+		cosmosclient.WithKeyringBackend(keyringBackend),
+		cosmosclient.WithAddressPrefix(getAddressPrefix(cmd)),
+	}
+	if chainID != "" {
+		clientOptions = append(clientOptions, cosmosclient.WithChainID(chainID))
+	}
+	if home != "" {
+		clientOptions = append(clientOptions, cosmosclient.WithHome(home))
+	}
+	if gasPrices != "" {
+		clientOptions = append(clientOptions, cosmosclient.WithGasPrices(gasPrices))
+	} else {
+		clientOptions = append(clientOptions, cosmosclient.WithAutoGasPrices())
+	}
+
+	client, err := cosmosclient.New(cmd.Context(), clientOptions...)
+	if err != nil {
+		return errors.Wrap(err, "cannot connect to the node")
+	}
+
+	faucetOptions := []cosmosfaucet.Option{
+		cosmosfaucet.Account(account, mnemonic, coinType),
+		cosmosfaucet.AddressPrefix(getAddressPrefix(cmd)),
+		cosmosfaucet.ChainID(chainID),
+		cosmosfaucet.RefreshWindow(refreshWindow),
+		cosmosfaucet.RateLimit(rateLimitPerIP, rateLimitGlobal, rateLimitWindow),
+		cosmosfaucet.ClientIPHeader(ipHeader),
+		cosmosfaucet.TrustedProxies(trustedProxies),
+		cosmosfaucet.LedgerPath(ledger),
+		cosmosfaucet.RequestLogPath(requestLog),
+	}
+
+	if apiAddress != "" {
+		faucetOptions = append(faucetOptions, cosmosfaucet.OpenAPI(apiAddress))
+	}
+
+	parsedCoinsMax, err := sdk.ParseCoinsNormalized(strings.Join(coinsMax, ","))
+	if err != nil {
+		return err
+	}
+
+	for _, coin := range coins {
+		parsedCoin, err := sdk.ParseCoinNormalized(coin)
+		if err != nil {
+			return fmt.Errorf("%s: %s", err, coin)
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.Coin(
+			parsedCoin.Amount.Uint64(),
+			parsedCoinsMax.AmountOf(parsedCoin.Denom).Uint64(),
+			parsedCoin.Denom,
+		))
+	}
+
+	if poolSize > 0 {
+		faucetOptions = append(faucetOptions, cosmosfaucet.AccountPool(mnemonic, poolSize))
+	}
+
+	if len(refillThreshold) > 0 || len(refillAmount) > 0 {
+		threshold, err := sdk.ParseCoinsNormalized(strings.Join(refillThreshold, ","))
+		if err != nil {
+			return err
+		}
+
+		amount, err := sdk.ParseCoinsNormalized(strings.Join(refillAmount, ","))
+		if err != nil {
+			return err
+		}
+
+		if threshold.Empty() || amount.Empty() {
+			return errors.New("both the refill threshold and amount are required to refill the pool accounts")
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.Refill(threshold, amount))
+	}
+
+	faucet, err := cosmosfaucet.NewWithClient(cmd.Context(), client.FaucetClient(), faucetOptions...)
+	if err != nil {
+		return err
+	}
+	defer faucet.Close()
+
+	faucetAddr, _ := xurl.HTTP(host)
+	fmt.Printf("🌍 Token faucet for %s: %s\n", client.Context().ChainID, faucetAddr)
+
+	return xhttp.Serve(cmd.Context(), &http.Server{
+		Addr:    host,
+		Handler: faucet,
+	})
+}
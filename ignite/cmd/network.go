@@ -20,6 +20,11 @@ var (
 
 	spnNodeAddress   string
 	spnFaucetAddress string
+
+	spnGasPrices     string
+	spnGasAdjustment float64
+	spnFees          string
+	spnFeeGranter    string
 )
 
 const (
@@ -29,6 +34,11 @@ const (
 	flagSPNNodeAddress   = "spn-node-address"
 	flagSPNFaucetAddress = "spn-faucet-address"
 
+	flagGasPrices     = "gas-prices"
+	flagGasAdjustment = "gas-adjustment"
+	flagFees          = "fees"
+	flagFeeGranter    = "fee-granter"
+
 	spnNodeAddressNightly   = "http://178.128.251.28:26657"
 	spnFaucetAddressNightly = "http://178.128.251.28:4500"
 
@@ -52,6 +62,10 @@ func NewNetwork() *cobra.Command {
 	c.PersistentFlags().BoolVar(&nightly, flagNightly, false, "Use nightly SPN network")
 	c.PersistentFlags().StringVar(&spnNodeAddress, flagSPNNodeAddress, spnNodeAddressNightly, "SPN node address")
 	c.PersistentFlags().StringVar(&spnFaucetAddress, flagSPNFaucetAddress, spnFaucetAddressNightly, "SPN faucet address")
+	c.PersistentFlags().StringVar(&spnGasPrices, flagGasPrices, "", "Gas prices of the SPN txs, e.g. 0.025uspn (discovered from the node when neither gas prices nor fees are set)")
+	c.PersistentFlags().Float64Var(&spnGasAdjustment, flagGasAdjustment, 1, "Factor the simulated gas of the SPN txs is multiplied by")
+	c.PersistentFlags().StringVar(&spnFees, flagFees, "", "Fees of the SPN txs, e.g. 1000uspn")
+	c.PersistentFlags().StringVar(&spnFeeGranter, flagFeeGranter, "", "Address of the account that pays the fees of the SPN txs with a fee grant")
 
 	// add sub commands.
 	c.AddCommand(
@@ -156,6 +170,11 @@ func getNetworkCosmosClient(cmd *cobra.Command) (cosmosclient.Client, error) {
 		cosmosclient.WithAddressPrefix(networktypes.SPN),
 		cosmosclient.WithUseFaucet(spnFaucetAddress, networktypes.SPNDenom, 5),
 		cosmosclient.WithKeyringServiceName(cosmosaccount.KeyringServiceName),
+		cosmosclient.WithGasPrices(spnGasPrices),
+		cosmosclient.WithGasAdjustment(spnGasAdjustment),
+		cosmosclient.WithFees(spnFees),
+		cosmosclient.WithFeeGranter(spnFeeGranter),
+		cosmosclient.WithAutoGasPrices(),
 	}
 
 	keyringBackend := getKeyringBackend(cmd)
@@ -18,6 +18,11 @@ func NewRelayer() *cobra.Command {
 	c.AddCommand(
 		NewRelayerConfigure(),
 		NewRelayerConnect(),
+		NewRelayerStatus(),
+		NewRelayerClear(),
+		NewRelayerKeepAlive(),
+		NewRelayerExport(),
+		NewRelayerImport(),
 	)
 
 	return c
@@ -0,0 +1,60 @@
+package ignitecmd
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/relayer"
+)
+
+// NewRelayerClear returns a new relayer clear command to relay the pending packets of a path once.
+func NewRelayerClear() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "clear <path>",
+		Short: "Relay the pending packets and acknowledgements of a path once and exit",
+		Args:  cobra.ExactArgs(1),
+		RunE:  relayerClearHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+
+	return c
+}
+
+func relayerClearHandler(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		err = handleRelayerAccountErr(err)
+	}()
+
+	session := cliui.New()
+	defer session.Cleanup()
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	var (
+		id = args[0]
+		r  = relayer.New(ca)
+	)
+
+	session.StartSpinner("Relaying pending packets...")
+
+	if err := r.Clear(cmd.Context(), id); err != nil {
+		return err
+	}
+
+	status, err := r.Status(cmd.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	session.StopSpinner()
+	session.Print(formatPathStatus(status))
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package ignitecmd
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	relayerconfig "github.com/ignite/cli/ignite/pkg/relayer/config"
+)
+
+const flagFormat = "format"
+
+// NewRelayerExport returns a new relayer export command to convert the relayer config to the config
+// of another relayer.
+func NewRelayerExport() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "export",
+		Short: "Export the chains and paths of the relayer to the config of Hermes or the Go relayer",
+		Long: `Export the chains and paths of the relayer to the config file of Hermes (hermes) or the
+Go relayer v2 (rly).
+
+The accounts of the chains are exported as key names, the keys must be imported into the keyring of
+the other relayer. The gRPC addresses of the chains are assumed to be on port 9090 of their RPC hosts.`,
+		Args: cobra.NoArgs,
+		RunE: relayerExportHandler,
+	}
+
+	c.Flags().String(flagFormat, string(relayerconfig.FormatHermes), fmt.Sprintf("Format of the config (%s)", formatList()))
+	c.Flags().StringP(flagOutput, "o", "", "File to write the config to, printed by default")
+
+	return c
+}
+
+func relayerExportHandler(cmd *cobra.Command, _ []string) error {
+	var (
+		format, _ = cmd.Flags().GetString(flagFormat)
+		output, _ = cmd.Flags().GetString(flagOutput)
+	)
+
+	conf, err := relayerconfig.Get()
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := relayerconfig.Export(&buf, conf, relayerconfig.Format(format)); err != nil {
+		return err
+	}
+
+	return writeOutput(output, bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
+
+// formatList returns the formats of the relayer configs separated by pipes.
+func formatList() string {
+	var formats string
+	for i, format := range relayerconfig.Formats {
+		if i > 0 {
+			formats += "|"
+		}
+		formats += string(format)
+	}
+	return formats
+}
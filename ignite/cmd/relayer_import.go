@@ -0,0 +1,64 @@
+package ignitecmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	relayerconfig "github.com/ignite/cli/ignite/pkg/relayer/config"
+)
+
+// NewRelayerImport returns a new relayer import command to add the chains and paths of the config
+// of another relayer to the relayer config.
+func NewRelayerImport() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "import <file>",
+		Short: "Import the chains and paths of a Hermes or Go relayer config",
+		Long: `Import the chains and paths of the config file of Hermes (hermes) or the Go relayer v2 (rly).
+
+The chains and paths replace the ones with the same ids in the relayer config. Hermes doesn't store
+paths in its config, only its chains are imported. The paths of the Go relayer are imported with their
+clients, connections and source channels, run "ignite relayer connect" to resolve their counterparty
+channels before relaying.`,
+		Args: cobra.ExactArgs(1),
+		RunE: relayerImportHandler,
+	}
+
+	c.Flags().String(flagFormat, string(relayerconfig.FormatHermes), fmt.Sprintf("Format of the config (%s)", formatList()))
+
+	return c
+}
+
+func relayerImportHandler(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString(flagFormat)
+
+	session := cliui.New()
+	defer session.Cleanup()
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	imported, err := relayerconfig.Import(f, relayerconfig.Format(format))
+	if err != nil {
+		return err
+	}
+
+	conf, err := relayerconfig.Get()
+	if err != nil {
+		return err
+	}
+
+	conf.Merge(imported)
+
+	if err := relayerconfig.Save(conf); err != nil {
+		return err
+	}
+
+	session.StopSpinner()
+	return session.Printf("🎉 Imported %d chains and %d paths\n", len(imported.Chains), len(imported.Paths))
+}
@@ -0,0 +1,63 @@
+package ignitecmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/relayer"
+)
+
+// NewRelayerKeepAlive returns a new relayer keepalive command to update the light clients of the
+// relayer before they expire.
+func NewRelayerKeepAlive() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "keepalive",
+		Short: "Update the light clients of the chains and paths before they expire",
+		Long: `Update the light clients of the chains and linked paths before they expire.
+
+The clients set to the chains with their client ids and the clients of the linked paths are updated
+once their latest consensus states are older than a third of their trusting period, even when there
+are no packets to relay. Each update is logged with the height the client is updated to.`,
+		Args: cobra.NoArgs,
+		RunE: relayerKeepAliveHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+
+	return c
+}
+
+func relayerKeepAliveHandler(cmd *cobra.Command, _ []string) (err error) {
+	defer func() {
+		err = handleRelayerAccountErr(err)
+	}()
+
+	session := cliui.New()
+	defer session.Cleanup()
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	if err := printSection(session, "Keeping light clients alive..."); err != nil {
+		return err
+	}
+
+	return relayer.New(ca).KeepAlive(cmd.Context(), func(u relayer.ClientUpdate) {
+		session.Printf(
+			"%s updated client %s on %s to %s height %s (expires %s)\n",
+			time.Now().Format(time.RFC3339),
+			u.ClientID,
+			u.ChainID,
+			u.CounterpartyChainID,
+			u.Height,
+			u.Expiry.Format(time.RFC3339),
+		)
+	})
+}
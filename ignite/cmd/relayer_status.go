@@ -0,0 +1,121 @@
+package ignitecmd
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ignite/cli/ignite/pkg/cliui"
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/relayer"
+)
+
+// NewRelayerStatus returns a new relayer status command to show the status of all or some relayer paths.
+func NewRelayerStatus() *cobra.Command {
+	c := &cobra.Command{
+		Use:   "status [<path>,...]",
+		Short: "Show the clients, connections, channels and unrelayed packets of paths",
+		RunE:  relayerStatusHandler,
+	}
+
+	c.Flags().AddFlagSet(flagSetKeyringBackend())
+
+	return c
+}
+
+func relayerStatusHandler(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		err = handleRelayerAccountErr(err)
+	}()
+
+	session := cliui.New()
+	defer session.Cleanup()
+
+	ca, err := cosmosaccount.New(
+		cosmosaccount.WithKeyringBackend(getKeyringBackend(cmd)),
+	)
+	if err != nil {
+		return err
+	}
+
+	var (
+		ids = args
+		r   = relayer.New(ca)
+	)
+
+	// if no path ids provided, show the status of all of them.
+	if len(ids) == 0 {
+		all, err := r.ListPaths(cmd.Context())
+		if err != nil {
+			return err
+		}
+		for _, path := range all {
+			ids = append(ids, path.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		session.StopSpinner()
+		session.Println("No paths found.")
+		return nil
+	}
+
+	for _, id := range ids {
+		session.StartSpinner("Querying status...")
+
+		status, err := r.Status(cmd.Context(), id)
+		if err != nil {
+			return err
+		}
+
+		session.StopSpinner()
+		session.Print(formatPathStatus(status))
+	}
+
+	return nil
+}
+
+// formatPathStatus formats the status of a path for the output of the relayer commands.
+func formatPathStatus(status relayer.PathStatus) string {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 1, ' ', tabwriter.TabIndent)
+	fmt.Fprintf(w, "%s:\n", status.ID)
+
+	if !status.Linked {
+		fmt.Fprintf(w, "   \t%s\t<>\t%s\t(not linked)\n", status.Src.ChainID, status.Dst.ChainID)
+		fmt.Fprintln(w)
+		w.Flush()
+		return buf.String()
+	}
+
+	for _, end := range []relayer.PathEndStatus{status.Src, status.Dst} {
+		fmt.Fprintf(w, "   \t%s\n", end.ChainID)
+		fmt.Fprintf(w, "   \t\tclient:\t%s (%s, height %s)\n", end.ClientID, end.ClientStatus, end.ClientHeight)
+		fmt.Fprintf(w, "   \t\ttrusting period:\t%s (expires %s)\n", end.TrustingPeriod, end.ClientExpiry.Format(time.RFC3339))
+		fmt.Fprintf(w, "   \t\tconnection:\t%s (%s)\n", end.ConnectionID, end.ConnectionState)
+		fmt.Fprintf(w, "   \t\tchannel:\t%s (port: %s, %s)\n", end.ChannelID, end.PortID, end.ChannelState)
+		fmt.Fprintf(w, "   \t\tsearch heights:\t(packets: %d)\t(acks: %d)\n", end.PacketHeight, end.AckHeight)
+		fmt.Fprintf(w, "   \t\tunrelayed packets:\t%s\n", formatSequences(end.UnrelayedPackets))
+		fmt.Fprintf(w, "   \t\tunrelayed acks:\t%s\n", formatSequences(end.UnrelayedAcks))
+	}
+
+	fmt.Fprintln(w)
+	w.Flush()
+	return buf.String()
+}
+
+func formatSequences(sequences []uint64) string {
+	if len(sequences) == 0 {
+		return "-"
+	}
+
+	s := make([]string, len(sequences))
+	for i, sequence := range sequences {
+		s[i] = fmt.Sprint(sequence)
+	}
+	return strings.Join(s, ", ")
+}
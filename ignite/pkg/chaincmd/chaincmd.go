@@ -2,6 +2,7 @@ package chaincmd
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/ignite/cli/ignite/pkg/cmdrunner/step"
 	"github.com/ignite/cli/ignite/pkg/cosmosver"
@@ -44,6 +45,9 @@ const (
 	optionYes                              = "--yes"
 	optionHomeClient                       = "--home-client"
 	optionCoinType                         = "--coin-type"
+	optionAccountIndex                     = "--account"
+	optionAddressIndex                     = "--index"
+	optionHDPath                           = "--hd-path"
 	optionVestingAmount                    = "--vesting-amount"
 	optionVestingEndTime                   = "--vesting-end-time"
 	optionBroadcastMode                    = "--broadcast-mode"
@@ -201,8 +205,41 @@ func (c ChainCmd) InitCommand(moniker string) step.Option {
 	return c.daemonCommand(command)
 }
 
+// KeyOption for the AddKeyCommand and RecoverKeyCommand
+type KeyOption func([]string) []string
+
+// KeyWithAccountIndex provides the account index of the HD derivation path for the key commands
+func KeyWithAccountIndex(index uint32) KeyOption {
+	return func(command []string) []string {
+		if index > 0 {
+			return append(command, optionAccountIndex, strconv.FormatUint(uint64(index), 10))
+		}
+		return command
+	}
+}
+
+// KeyWithAddressIndex provides the address index of the HD derivation path for the key commands
+func KeyWithAddressIndex(index uint32) KeyOption {
+	return func(command []string) []string {
+		if index > 0 {
+			return append(command, optionAddressIndex, strconv.FormatUint(uint64(index), 10))
+		}
+		return command
+	}
+}
+
+// KeyWithHDPath provides the full HD derivation path for the key commands
+func KeyWithHDPath(hdPath string) KeyOption {
+	return func(command []string) []string {
+		if len(hdPath) > 0 {
+			return append(command, optionHDPath, hdPath)
+		}
+		return command
+	}
+}
+
 // AddKeyCommand returns the command to add a new key in the chain keyring
-func (c ChainCmd) AddKeyCommand(accountName, coinType string) step.Option {
+func (c ChainCmd) AddKeyCommand(accountName, coinType string, options ...KeyOption) step.Option {
 	command := []string{
 		commandKeys,
 		"add",
@@ -213,13 +250,16 @@ func (c ChainCmd) AddKeyCommand(accountName, coinType string) step.Option {
 	if coinType != "" {
 		command = append(command, optionCoinType, coinType)
 	}
+	for _, applyOption := range options {
+		command = applyOption(command)
+	}
 	command = c.attachKeyringBackend(command)
 
 	return c.cliCommand(command)
 }
 
 // RecoverKeyCommand returns the command to recover a key into the chain keyring from a mnemonic
-func (c ChainCmd) RecoverKeyCommand(accountName, coinType string) step.Option {
+func (c ChainCmd) RecoverKeyCommand(accountName, coinType string, options ...KeyOption) step.Option {
 	command := []string{
 		commandKeys,
 		"add",
@@ -229,6 +269,9 @@ func (c ChainCmd) RecoverKeyCommand(accountName, coinType string) step.Option {
 	if coinType != "" {
 		command = append(command, optionCoinType, coinType)
 	}
+	for _, applyOption := range options {
+		command = applyOption(command)
+	}
 	command = c.attachKeyringBackend(command)
 
 	return c.cliCommand(command)
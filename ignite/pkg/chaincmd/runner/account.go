@@ -9,6 +9,7 @@ import (
 	"os"
 	"strings"
 
+	"github.com/ignite/cli/ignite/pkg/chaincmd"
 	"github.com/ignite/cli/ignite/pkg/cmdrunner/step"
 )
 
@@ -28,9 +29,10 @@ type Account struct {
 }
 
 // AddAccount creates a new account or imports an account when mnemonic is provided.
+// the HD derivation path of the account is set by options.
 // returns with an error if the operation went unsuccessful or an account with the provided name
 // already exists.
-func (r Runner) AddAccount(ctx context.Context, name, mnemonic, coinType string) (Account, error) {
+func (r Runner) AddAccount(ctx context.Context, name, mnemonic, coinType string, options ...chaincmd.KeyOption) (Account, error) {
 	if err := r.CheckAccountExist(ctx, name); err != nil {
 		return Account{}, err
 	}
@@ -54,7 +56,7 @@ func (r Runner) AddAccount(ctx context.Context, name, mnemonic, coinType string)
 		if err := r.run(
 			ctx,
 			runOptions{},
-			r.chainCmd.RecoverKeyCommand(name, coinType),
+			r.chainCmd.RecoverKeyCommand(name, coinType, options...),
 			step.Write(input.Bytes()),
 		); err != nil {
 			return Account{}, err
@@ -64,7 +66,7 @@ func (r Runner) AddAccount(ctx context.Context, name, mnemonic, coinType string)
 			stdout: b,
 			stderr: b,
 			stdin:  os.Stdin,
-		}, r.chainCmd.AddKeyCommand(name, coinType)); err != nil {
+		}, r.chainCmd.AddKeyCommand(name, coinType, options...)); err != nil {
 			return Account{}, err
 		}
 
@@ -0,0 +1,338 @@
+package cosmosaccount
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/tendermint/crypto/bcrypt"
+	tmcrypto "github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/armor"
+	"github.com/tendermint/tendermint/crypto/xsalsa20symmetric"
+)
+
+const (
+	backupBlockType = "IGNITE ACCOUNTS BACKUP"
+	backupVersion   = "1"
+
+	// backupBcryptCost is the bcrypt security parameter used to derive the key of a backup
+	// from its passphrase, it is the same as the one of the armored private keys of the sdk.
+	backupBcryptCost = 12
+)
+
+// ErrWrongPassphrase is returned when a backup cannot be decrypted with the passphrase.
+var ErrWrongPassphrase = errors.New("wrong passphrase")
+
+// BackupCoinTypeUnknown is the coin type of the accounts whose derivation path isn't recorded
+// by the keyring, e.g. the local accounts, of which only the private keys are kept.
+const BackupCoinTypeUnknown = "unknown"
+
+// BackupAccount is an account in a backup. The private keys of the local accounts are kept
+// instead of their derivation paths, so they are restored without their coin type.
+type BackupAccount struct {
+	// Name is the name of the account.
+	Name string `json:"name"`
+
+	// Type is the type of the key of the account, i.e. local, multi or offline.
+	Type string `json:"type"`
+
+	// Algo is the signing algorithm of the key.
+	Algo string `json:"algo"`
+
+	// CoinType is the coin type of the derivation path of the account,
+	// BackupCoinTypeUnknown when the keyring doesn't record the path.
+	CoinType string `json:"coin_type"`
+
+	// HDPath is the derivation path of the account when the keyring records it.
+	HDPath string `json:"hd_path,omitempty"`
+
+	// Address is the address of the account.
+	Address string `json:"address"`
+
+	// PrivKeyArmor is the private key of a local key, armored with the passphrase of the backup.
+	PrivKeyArmor string `json:"priv_key_armor,omitempty"`
+
+	// PubKey is the amino JSON encoded public key of a multisig or offline key.
+	PubKey json.RawMessage `json:"pub_key,omitempty"`
+}
+
+// backup is the content of a backup.
+type backup struct {
+	Accounts []BackupAccount `json:"accounts"`
+}
+
+// Backup writes the accounts with names, or all accounts when no names are provided, to an archive
+// encrypted with passphrase. Ledger keys cannot be backed up, they are skipped and their names are
+// returned when all accounts are backed up.
+func (r Registry) Backup(passphrase string, names ...string) (archive []byte, skipped []string, err error) {
+	if passphrase == "" {
+		return nil, nil, errors.New("passphrase is required to encrypt the backup")
+	}
+
+	accounts, err := r.selectAccounts(names)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var b backup
+
+	for _, acc := range accounts {
+		account := BackupAccount{
+			Name:    acc.Name,
+			Type:    acc.Info.GetType().String(),
+			Algo:    string(acc.Info.GetAlgo()),
+			Address: acc.Address(AccountPrefixCosmos),
+		}
+		account.CoinType, account.HDPath = backupDerivation(acc.Info)
+
+		switch acc.Info.GetType() {
+		case keyring.TypeLocal:
+			if account.PrivKeyArmor, err = r.Keyring.ExportPrivKeyArmor(acc.Name, passphrase); err != nil {
+				return nil, nil, err
+			}
+		case keyring.TypeMulti, keyring.TypeOffline:
+			if account.PubKey, err = legacy.Cdc.MarshalJSON(acc.Info.GetPubKey()); err != nil {
+				return nil, nil, err
+			}
+		default:
+			if len(names) == 0 {
+				skipped = append(skipped, acc.Name)
+				continue
+			}
+			return nil, nil, fmt.Errorf("cannot back up the %s key of account %q", account.Type, acc.Name)
+		}
+
+		b.Accounts = append(b.Accounts, account)
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	salt := tmcrypto.CRandBytes(16)
+
+	key, err := backupKey(salt, passphrase)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headers := map[string]string{
+		"kdf":     "bcrypt",
+		"salt":    fmt.Sprintf("%X", salt),
+		"version": backupVersion,
+	}
+
+	archive = []byte(armor.EncodeArmor(backupBlockType, headers, xsalsa20symmetric.EncryptSymmetric(data, key)))
+
+	return archive, skipped, nil
+}
+
+// ReadBackup decrypts archive with passphrase and returns its accounts.
+func ReadBackup(archive []byte, passphrase string) ([]BackupAccount, error) {
+	blockType, headers, encrypted, err := armor.DecodeArmor(string(archive))
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup: %w", err)
+	}
+
+	if blockType != backupBlockType {
+		return nil, fmt.Errorf("invalid backup: unrecognized armor type %q", blockType)
+	}
+	if headers["kdf"] != "bcrypt" || headers["version"] != backupVersion {
+		return nil, fmt.Errorf("invalid backup: unsupported version %q", headers["version"])
+	}
+
+	salt, err := hex.DecodeString(headers["salt"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid backup salt: %w", err)
+	}
+
+	key, err := backupKey(salt, passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := xsalsa20symmetric.DecryptSymmetric(encrypted, key)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+
+	var b backup
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("invalid backup: %w", err)
+	}
+
+	return b.Accounts, nil
+}
+
+// RestoreConflict is an account of a backup that isn't restored because it conflicts with an existing account.
+type RestoreConflict struct {
+	// Name is the name of the account in the backup.
+	Name string
+
+	// Reason explains the conflict.
+	Reason string
+}
+
+// RestoreReport is the result of a restore.
+type RestoreReport struct {
+	// Restored are the restored accounts.
+	Restored []Account
+
+	// Conflicts are the accounts that aren't restored.
+	Conflicts []RestoreConflict
+}
+
+// Restore imports the accounts of a backup made by Backup with names, or all its accounts when no
+// names are provided. Existing accounts are never overwritten, an account is reported as a conflict
+// instead when an account with the same name or address exists.
+func (r Registry) Restore(archive []byte, passphrase string, names ...string) (RestoreReport, error) {
+	accounts, err := ReadBackup(archive, passphrase)
+	if err != nil {
+		return RestoreReport{}, err
+	}
+
+	inBackup := make(map[string]bool)
+	for _, account := range accounts {
+		inBackup[account.Name] = true
+	}
+
+	selected := make(map[string]bool)
+	for _, name := range names {
+		if !inBackup[name] {
+			return RestoreReport{}, fmt.Errorf("account %q is not in the backup", name)
+		}
+		selected[name] = true
+	}
+
+	var report RestoreReport
+
+	for _, account := range accounts {
+		if len(selected) > 0 && !selected[account.Name] {
+			continue
+		}
+
+		reason, err := r.restoreConflict(account)
+		if err != nil {
+			return report, err
+		}
+		if reason != "" {
+			report.Conflicts = append(report.Conflicts, RestoreConflict{account.Name, reason})
+			continue
+		}
+
+		if err := r.restore(account, passphrase); err != nil {
+			return report, fmt.Errorf("cannot restore account %q: %w", account.Name, err)
+		}
+
+		acc, err := r.GetByName(account.Name)
+		if err != nil {
+			return report, err
+		}
+
+		report.Restored = append(report.Restored, acc)
+	}
+
+	return report, nil
+}
+
+// restoreConflict returns the reason why account conflicts with an existing account,
+// an empty reason is returned when there is no conflict.
+func (r Registry) restoreConflict(account BackupAccount) (string, error) {
+	_, err := r.GetByName(account.Name)
+	if err == nil {
+		return "an account with the same name already exists", nil
+	}
+	var accErr *AccountDoesNotExistError
+	if !errors.As(err, &accErr) {
+		return "", err
+	}
+
+	_, address, err := bech32.DecodeAndConvert(account.Address)
+	if err != nil {
+		return "", err
+	}
+
+	info, err := r.Keyring.KeyByAddress(sdktypes.AccAddress(address))
+	if err == nil {
+		return fmt.Sprintf("the account already exists as %q", info.GetName()), nil
+	}
+	if !errors.Is(err, sdkerrors.ErrKeyNotFound) {
+		return "", err
+	}
+
+	return "", nil
+}
+
+func (r Registry) restore(account BackupAccount, passphrase string) error {
+	switch account.Type {
+	case keyring.TypeLocal.String():
+		return r.Keyring.ImportPrivKey(account.Name, account.PrivKeyArmor, passphrase)
+
+	case keyring.TypeMulti.String():
+		var pubKey cryptotypes.PubKey
+		if err := legacy.Cdc.UnmarshalJSON(account.PubKey, &pubKey); err != nil {
+			return err
+		}
+		_, err := r.Keyring.SaveMultisig(account.Name, pubKey)
+		return err
+
+	case keyring.TypeOffline.String():
+		var pubKey cryptotypes.PubKey
+		if err := legacy.Cdc.UnmarshalJSON(account.PubKey, &pubKey); err != nil {
+			return err
+		}
+		_, err := r.Keyring.SavePubKey(account.Name, pubKey, hd.PubKeyType(account.Algo))
+		return err
+
+	default:
+		return fmt.Errorf("unsupported key type %q", account.Type)
+	}
+}
+
+// backupDerivation returns the coin type and the derivation path of the account of info.
+// the coin type is BackupCoinTypeUnknown when the keyring doesn't record the path.
+func backupDerivation(info keyring.Info) (coinType, hdPath string) {
+	params, err := info.GetPath()
+	if err != nil || params == nil {
+		return BackupCoinTypeUnknown, ""
+	}
+	return strconv.FormatUint(uint64(params.CoinType), 10), params.String()
+}
+
+// selectAccounts returns the accounts with names, or all accounts when no names are provided.
+func (r Registry) selectAccounts(names []string) ([]Account, error) {
+	if len(names) == 0 {
+		return r.List()
+	}
+
+	var accounts []Account
+	for _, name := range names {
+		acc, err := r.GetByName(name)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, acc)
+	}
+
+	return accounts, nil
+}
+
+// backupKey derives the encryption key of a backup from its passphrase.
+func backupKey(salt []byte, passphrase string) ([]byte, error) {
+	key, err := bcrypt.GenerateFromPassword(salt, []byte(passphrase), backupBcryptCost)
+	if err != nil {
+		return nil, fmt.Errorf("cannot derive the key from the passphrase: %w", err)
+	}
+
+	return tmcrypto.Sha256(key), nil
+}
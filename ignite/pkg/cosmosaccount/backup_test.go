@@ -0,0 +1,85 @@
+package cosmosaccount_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+func TestBackupRestore(t *testing.T) {
+	r, err := cosmosaccount.NewInMemory()
+	require.NoError(t, err)
+
+	for _, name := range []string{"alice", "bob"} {
+		_, _, err := r.Create(name)
+		require.NoError(t, err)
+	}
+	_, err = r.CreateMultisig("treasury", []string{"alice", "bob"}, 2)
+	require.NoError(t, err)
+
+	_, _, err = r.Backup("")
+	require.Error(t, err)
+
+	archive, skipped, err := r.Backup("passphrase")
+	require.NoError(t, err)
+	require.Empty(t, skipped)
+
+	_, err = cosmosaccount.ReadBackup(archive, "wrong")
+	require.ErrorIs(t, err, cosmosaccount.ErrWrongPassphrase)
+
+	accounts, err := cosmosaccount.ReadBackup(archive, "passphrase")
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+	for _, account := range accounts {
+		require.Equal(t, cosmosaccount.BackupCoinTypeUnknown, account.CoinType, "the keyring doesn't record the paths of %s", account.Name)
+	}
+
+	// restore in an empty keyring.
+	restored, err := cosmosaccount.NewInMemory()
+	require.NoError(t, err)
+
+	report, err := restored.Restore(archive, "passphrase")
+	require.NoError(t, err)
+	require.Len(t, report.Restored, 3)
+	require.Empty(t, report.Conflicts)
+
+	for _, acc := range report.Restored {
+		original, err := r.GetByName(acc.Name)
+		require.NoError(t, err)
+		require.Equal(t, original.Address("cosmos"), acc.Address("cosmos"))
+		require.Equal(t, original.IsMultisig(), acc.IsMultisig())
+	}
+
+	// restore in a keyring with conflicting accounts.
+	conflicting, err := cosmosaccount.NewInMemory()
+	require.NoError(t, err)
+
+	_, _, err = conflicting.Create("alice")
+	require.NoError(t, err)
+
+	bobArchive, _, err := r.Backup("passphrase", "bob")
+	require.NoError(t, err)
+	report, err = conflicting.Restore(bobArchive, "passphrase")
+	require.NoError(t, err)
+	require.Len(t, report.Restored, 1)
+
+	require.NoError(t, conflicting.DeleteByName("bob"))
+	bobExport, err := r.Export("bob", "pass")
+	require.NoError(t, err)
+	_, err = conflicting.Import("robert", bobExport, "pass")
+	require.NoError(t, err)
+
+	report, err = conflicting.Restore(archive, "passphrase", "alice", "bob", "treasury")
+	require.NoError(t, err)
+	require.Len(t, report.Restored, 1)
+	require.Equal(t, "treasury", report.Restored[0].Name)
+	require.Equal(t, []cosmosaccount.RestoreConflict{
+		{Name: "alice", Reason: "an account with the same name already exists"},
+		{Name: "bob", Reason: `the account already exists as "robert"`},
+	}, report.Conflicts)
+
+	_, err = conflicting.Restore(archive, "passphrase", "carol")
+	require.Error(t, err)
+}
@@ -8,6 +8,7 @@ import (
 	dkeyring "github.com/99designs/keyring"
 	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/bech32"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
@@ -135,7 +136,12 @@ func (a Account) Address(accPrefix string) string {
 }
 
 // PubKey returns a public key for account.
+// the public key of a multisig account is described by its threshold and number of keys.
 func (a Account) PubKey() string {
+	if pk, ok := a.Info.GetPubKey().(*multisig.LegacyAminoPubKey); ok {
+		return fmt.Sprintf("multisig %d/%d", pk.Threshold, len(pk.PubKeys))
+	}
+
 	return a.Info.GetPubKey().String()
 }
 
@@ -160,8 +166,8 @@ func (r Registry) EnsureDefaultAccount() error {
 	return err
 }
 
-// Create creates a new account with name.
-func (r Registry) Create(name string) (acc Account, mnemonic string, err error) {
+// Create creates a new account with name, the account is derived from its mnemonic at the path set by options.
+func (r Registry) Create(name string, options ...DerivationOption) (acc Account, mnemonic string, err error) {
 	acc, err = r.GetByName(name)
 	if err == nil {
 		return Account{}, "", ErrAccountExists
@@ -180,11 +186,15 @@ func (r Registry) Create(name string) (acc Account, mnemonic string, err error)
 		return Account{}, "", err
 	}
 
+	hdPath, err := r.hdPath(options...)
+	if err != nil {
+		return Account{}, "", err
+	}
 	algo, err := r.algo()
 	if err != nil {
 		return Account{}, "", err
 	}
-	info, err := r.Keyring.NewAccount(name, mnemonic, "", r.hdPath(), algo)
+	info, err := r.Keyring.NewAccount(name, mnemonic, "", hdPath, algo)
 	if err != nil {
 		return Account{}, "", err
 	}
@@ -198,8 +208,8 @@ func (r Registry) Create(name string) (acc Account, mnemonic string, err error)
 }
 
 // Import imports an existing account with name and passphrase and secret where secret can be a
-// mnemonic or a private key.
-func (r Registry) Import(name, secret, passphrase string) (Account, error) {
+// mnemonic or a private key. The account is derived from a mnemonic at the path set by options.
+func (r Registry) Import(name, secret, passphrase string, options ...DerivationOption) (Account, error) {
 	_, err := r.GetByName(name)
 	if err == nil {
 		return Account{}, ErrAccountExists
@@ -210,11 +220,15 @@ func (r Registry) Import(name, secret, passphrase string) (Account, error) {
 	}
 
 	if bip39.IsMnemonicValid(secret) {
+		hdPath, err := r.hdPath(options...)
+		if err != nil {
+			return Account{}, err
+		}
 		algo, err := r.algo()
 		if err != nil {
 			return Account{}, err
 		}
-		_, err = r.Keyring.NewAccount(name, secret, passphrase, r.hdPath(), algo)
+		_, err = r.Keyring.NewAccount(name, secret, passphrase, hdPath, algo)
 		if err != nil {
 			return Account{}, err
 		}
@@ -289,10 +303,6 @@ func (r Registry) DeleteByName(name string) error {
 	return err
 }
 
-func (r Registry) hdPath() string {
-	return hd.CreateHDPath(sdktypes.GetConfig().GetCoinType(), 0, 0).String()
-}
-
 func (r Registry) algo() (keyring.SignatureAlgo, error) {
 	algos, _ := r.Keyring.SupportedAlgorithms()
 	return keyring.NewSigningAlgoFromString(string(hd.Secp256k1Type), algos)
@@ -0,0 +1,119 @@
+package cosmosaccount
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DerivationOption configures the HD derivation path of the accounts created from a mnemonic.
+type DerivationOption func(*derivation)
+
+// derivation is the BIP-0044 derivation path of an account. the path is
+// m/44'/<coin type>'/<account index>'/0/<address index> unless hdPath is set.
+type derivation struct {
+	accountIndex uint32
+	addressIndex uint32
+	hdPath       string
+}
+
+// AccountIndex sets the account index of the derivation path, 0 by default.
+func AccountIndex(index uint32) DerivationOption {
+	return func(d *derivation) {
+		d.accountIndex = index
+	}
+}
+
+// AddressIndex sets the address index of the derivation path, 0 by default.
+func AddressIndex(index uint32) DerivationOption {
+	return func(d *derivation) {
+		d.addressIndex = index
+	}
+}
+
+// HDPath sets the full derivation path, e.g. m/44'/118'/0'/0/0, the account and address indexes are ignored.
+func HDPath(path string) DerivationOption {
+	return func(d *derivation) {
+		d.hdPath = path
+	}
+}
+
+// DerivedAccount is an account derived from a mnemonic.
+type DerivedAccount struct {
+	// HDPath is the derivation path of the account.
+	HDPath string
+
+	// AddressIndex is the address index of the derivation path.
+	AddressIndex uint32
+
+	// PubKey is the public key of the account.
+	PubKey cryptotypes.PubKey
+}
+
+// Address returns the address of the account from given prefix.
+func (a DerivedAccount) Address(accPrefix string) string {
+	if accPrefix == "" {
+		accPrefix = AccountPrefixCosmos
+	}
+
+	return toBench32(accPrefix, a.PubKey.Address())
+}
+
+// Derive derives count accounts from mnemonic without storing them, starting from the derivation
+// path set by options and incrementing its address index.
+func (r Registry) Derive(mnemonic string, count int, options ...DerivationOption) ([]DerivedAccount, error) {
+	params, err := r.hdParams(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []DerivedAccount
+
+	for i := 0; i < count; i++ {
+		path := params.String()
+
+		derived, err := hd.Secp256k1.Derive()(mnemonic, "", path)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, DerivedAccount{
+			HDPath:       path,
+			AddressIndex: params.AddressIndex,
+			PubKey:       hd.Secp256k1.Generate()(derived).PubKey(),
+		})
+
+		params.AddressIndex++
+	}
+
+	return accounts, nil
+}
+
+// hdPath returns the derivation path set by options.
+func (r Registry) hdPath(options ...DerivationOption) (string, error) {
+	params, err := r.hdParams(options...)
+	if err != nil {
+		return "", err
+	}
+	return params.String(), nil
+}
+
+func (r Registry) hdParams(options ...DerivationOption) (*hd.BIP44Params, error) {
+	var d derivation
+	for _, apply := range options {
+		apply(&d)
+	}
+
+	if d.hdPath == "" {
+		return hd.NewFundraiserParams(d.accountIndex, sdktypes.GetConfig().GetCoinType(), d.addressIndex), nil
+	}
+
+	params, err := hd.NewParamsFromPath(d.hdPath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid HD path %q: %w", d.hdPath, err)
+	}
+
+	return params, nil
+}
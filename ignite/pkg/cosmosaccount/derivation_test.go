@@ -0,0 +1,38 @@
+package cosmosaccount_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+const testMnemonic = "ozone unfold device pave lemon potato omit insect column wise cover hint narrow large provide kidney episode clay notable milk mention dizzy muffin crazy"
+
+func TestDerive(t *testing.T) {
+	r, err := cosmosaccount.NewInMemory()
+	require.NoError(t, err)
+
+	derived, err := r.Derive(testMnemonic, 3, cosmosaccount.AccountIndex(1), cosmosaccount.AddressIndex(2))
+	require.NoError(t, err)
+	require.Len(t, derived, 3)
+	require.Equal(t, "m/44'/118'/1'/0/2", derived[0].HDPath)
+	require.Equal(t, "m/44'/118'/1'/0/4", derived[2].HDPath)
+	require.Equal(t, uint32(4), derived[2].AddressIndex)
+
+	acc, err := r.Import("indexes", testMnemonic, "", cosmosaccount.AccountIndex(1), cosmosaccount.AddressIndex(3))
+	require.NoError(t, err)
+	require.Equal(t, derived[1].Address("cosmos"), acc.Address("cosmos"))
+
+	acc, err = r.Import("path", testMnemonic, "", cosmosaccount.HDPath("m/44'/118'/1'/0/4"))
+	require.NoError(t, err)
+	require.Equal(t, derived[2].Address("cosmos"), acc.Address("cosmos"))
+
+	acc, err = r.Import("default", testMnemonic, "")
+	require.NoError(t, err)
+	require.NotEqual(t, derived[0].Address("cosmos"), acc.Address("cosmos"))
+
+	_, _, err = r.Create("invalid", cosmosaccount.HDPath("44/118"))
+	require.Error(t, err)
+}
@@ -0,0 +1,71 @@
+package cosmosaccount
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+)
+
+// CreateMultisig creates a multisig account with name from the public keys of the keys accounts,
+// threshold of the keys are required to sign a tx of the account. Only the public key of the
+// multisig is stored, the keys are sorted by address so the account doesn't depend on their order.
+func (r Registry) CreateMultisig(name string, keys []string, threshold int) (Account, error) {
+	_, err := r.GetByName(name)
+	if err == nil {
+		return Account{}, ErrAccountExists
+	}
+	var accErr *AccountDoesNotExistError
+	if !errors.As(err, &accErr) {
+		return Account{}, err
+	}
+
+	if len(keys) == 0 {
+		return Account{}, errors.New("at least one key is required")
+	}
+	if threshold <= 0 || threshold > len(keys) {
+		return Account{}, fmt.Errorf("threshold must be between 1 and the number of keys (%d)", len(keys))
+	}
+
+	var (
+		pubKeys []cryptotypes.PubKey
+		added   = make(map[string]bool)
+	)
+
+	for _, key := range keys {
+		if added[key] {
+			return Account{}, fmt.Errorf("duplicate key %q", key)
+		}
+		added[key] = true
+
+		acc, err := r.GetByName(key)
+		if err != nil {
+			return Account{}, err
+		}
+
+		pubKeys = append(pubKeys, acc.Info.GetPubKey())
+	}
+
+	sort.Slice(pubKeys, func(i, j int) bool {
+		return bytes.Compare(pubKeys[i].Address(), pubKeys[j].Address()) < 0
+	})
+
+	info, err := r.Keyring.SaveMultisig(name, multisig.NewLegacyAminoPubKey(threshold, pubKeys))
+	if err != nil {
+		return Account{}, err
+	}
+
+	return Account{
+		Name: name,
+		Info: info,
+	}, nil
+}
+
+// IsMultisig checks if the account is a multisig account.
+func (a Account) IsMultisig() bool {
+	return a.Info.GetType() == keyring.TypeMulti
+}
@@ -0,0 +1,47 @@
+package cosmosaccount_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+func TestCreateMultisig(t *testing.T) {
+	r, err := cosmosaccount.NewInMemory()
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "c"} {
+		_, _, err := r.Create(name)
+		require.NoError(t, err)
+	}
+
+	acc, err := r.CreateMultisig("ab", []string{"a", "b", "c"}, 2)
+	require.NoError(t, err)
+	require.True(t, acc.IsMultisig())
+	require.Equal(t, "multisig 2/3", acc.PubKey())
+
+	// the address doesn't depend on the order of the keys.
+	require.NoError(t, r.DeleteByName("ab"))
+	reordered, err := r.CreateMultisig("ab", []string{"c", "b", "a"}, 2)
+	require.NoError(t, err)
+	require.Equal(t, acc.Address("cosmos"), reordered.Address("cosmos"))
+
+	single, err := r.GetByName("a")
+	require.NoError(t, err)
+	require.False(t, single.IsMultisig())
+
+	_, err = r.CreateMultisig("ab", []string{"a", "b"}, 1)
+	require.ErrorIs(t, err, cosmosaccount.ErrAccountExists)
+
+	_, err = r.CreateMultisig("invalid", []string{"a", "b"}, 3)
+	require.Error(t, err)
+
+	_, err = r.CreateMultisig("invalid", []string{"a", "a"}, 1)
+	require.Error(t, err)
+
+	var accErr *cosmosaccount.AccountDoesNotExistError
+	_, err = r.CreateMultisig("invalid", []string{"a", "d"}, 1)
+	require.ErrorAs(t, err, &accErr)
+}
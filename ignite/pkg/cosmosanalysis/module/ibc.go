@@ -0,0 +1,127 @@
+package module
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+)
+
+// IBCPort is the port of an IBC module scaffolded in an app.
+type IBCPort struct {
+	// Module is the name of the module.
+	Module string
+
+	// PortID is the id of the port the module binds to.
+	PortID string
+
+	// Version is the version of the channels of the module.
+	Version string
+
+	// Ordering is the ordering of the channels of the module.
+	Ordering string
+}
+
+// DiscoverIBCPorts discovers the ports of the IBC modules scaffolded in the app at appPath,
+// from the PortID and Version values in the types/keys.go files of the modules under x/.
+func DiscoverIBCPorts(appPath string) ([]IBCPort, error) {
+	keysPaths, err := filepath.Glob(filepath.Join(appPath, "x", "*", "types", "keys.go"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keysPaths)
+
+	var ports []IBCPort
+	for _, keysPath := range keysPaths {
+		values, err := stringValues(keysPath, "PortID", "Version")
+		if err != nil {
+			return nil, err
+		}
+
+		portID, ok := values["PortID"]
+		if !ok {
+			continue
+		}
+
+		moduleDir := filepath.Dir(filepath.Dir(keysPath))
+		ordering, err := ibcOrdering(filepath.Join(moduleDir, "module_ibc.go"))
+		if err != nil {
+			return nil, err
+		}
+
+		ports = append(ports, IBCPort{
+			Module:   filepath.Base(moduleDir),
+			PortID:   portID,
+			Version:  values["Version"],
+			Ordering: ordering,
+		})
+	}
+
+	return ports, nil
+}
+
+// stringValues returns the string literals assigned to the consts and vars with names declared
+// in the Go file at path.
+func stringValues(path string, names ...string) (map[string]string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	wanted := make(map[string]bool)
+	for _, name := range names {
+		wanted[name] = true
+	}
+
+	values := make(map[string]string)
+	for _, decl := range f.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || (genDecl.Tok != token.CONST && genDecl.Tok != token.VAR) {
+			continue
+		}
+
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, name := range valueSpec.Names {
+				if !wanted[name.Name] || i >= len(valueSpec.Values) {
+					continue
+				}
+
+				lit, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+
+				if values[name.Name], err = strconv.Unquote(lit.Value); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// ibcOrdering returns the ordering of the channels accepted by the IBC module implemented in the
+// file at path. The modules scaffolded without ordering accept unordered channels.
+func ibcOrdering(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return channeltypes.UNORDERED.String(), nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(string(content), "channeltypes.ORDERED") {
+		return channeltypes.ORDERED.String(), nil
+	}
+
+	return channeltypes.UNORDERED.String(), nil
+}
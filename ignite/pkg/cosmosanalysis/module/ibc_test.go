@@ -0,0 +1,62 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverIBCPorts(t *testing.T) {
+	appPath := t.TempDir()
+
+	write := func(path, content string) {
+		path = filepath.Join(appPath, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+
+	write("x/blog/types/keys.go", `package types
+
+const (
+	ModuleName = "blog"
+
+	// Version defines the current version the IBC module supports
+	Version = "blog-1"
+
+	// PortID is the default port id that module binds to
+	PortID = "blog"
+)
+`)
+	write("x/blog/module_ibc.go", `package blog
+
+func check(order channeltypes.Order) bool {
+	return order != channeltypes.ORDERED
+}
+`)
+	write("x/dex/types/keys.go", `package types
+
+const (
+	Version = "dex-1"
+	PortID  = "dex"
+)
+`)
+	write("x/dex/module_ibc.go", `package dex
+
+func check(order channeltypes.Order) bool {
+	return order != channeltypes.UNORDERED
+}
+`)
+	write("x/bank/types/keys.go", `package types
+
+const ModuleName = "bank"
+`)
+
+	ports, err := DiscoverIBCPorts(appPath)
+	require.NoError(t, err)
+	require.Equal(t, []IBCPort{
+		{Module: "blog", PortID: "blog", Version: "blog-1", Ordering: "ORDER_ORDERED"},
+		{Module: "dex", PortID: "dex", Version: "dex-1", Ordering: "ORDER_UNORDERED"},
+	}, ports)
+}
@@ -10,7 +10,6 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/cenkalti/backoff"
@@ -21,13 +20,13 @@ import (
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
 	sdktypes "github.com/cosmos/cosmos-sdk/types"
-	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/types/tx/signing"
 	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	staking "github.com/cosmos/cosmos-sdk/x/staking/types"
 	commitmenttypes "github.com/cosmos/ibc-go/v3/modules/core/23-commitment/types"
+	ibctypes "github.com/cosmos/ibc-go/v3/modules/core/types"
 	"github.com/gogo/protobuf/proto"
 	prototypes "github.com/gogo/protobuf/types"
 	"github.com/pkg/errors"
@@ -75,7 +74,17 @@ type Client struct {
 
 	addressPrefix string
 
+	// sequences hands out the sequences of the accounts.
+	sequences *sequenceManager
+
+	fees          feeOptions
+	autoGasPrices bool
+	// autoGasDenoms are the denoms preferred to pay the fees in with auto gas prices.
+	autoGasDenoms []string
+	gasPrices     *gasPricesCache
+
 	nodeAddress string
+	grpcAddress string
 	out         io.Writer
 	chainID     string
 
@@ -116,6 +125,14 @@ func WithKeyringBackend(backend cosmosaccount.KeyringBackend) Option {
 	}
 }
 
+// WithAccountRegistry sets the registry to access the accounts that sign txs, it is used
+// instead of a registry built from the keyring options.
+func WithAccountRegistry(registry cosmosaccount.Registry) Option {
+	return func(c *Client) {
+		c.AccountRegistry = registry
+	}
+}
+
 // WithNodeAddress sets the node address of your chain. When this option is not provided
 // `http://localhost:26657` is used as default.
 func WithNodeAddress(addr string) Option {
@@ -124,12 +141,76 @@ func WithNodeAddress(addr string) Option {
 	}
 }
 
+// WithGRPCAddress sets the gRPC address of the node, e.g. localhost:9090. It is used to resolve
+// the queries of the modules unknown to the client with the gRPC reflection service of the node.
+func WithGRPCAddress(addr string) Option {
+	return func(c *Client) {
+		c.grpcAddress = addr
+	}
+}
+
+// WithChainID sets the chain id of your chain, the node isn't queried for the chain id when it's set.
+// this option allows to sign txs offline.
+func WithChainID(chainID string) Option {
+	return func(c *Client) {
+		c.chainID = chainID
+	}
+}
+
 func WithAddressPrefix(prefix string) Option {
 	return func(c *Client) {
 		c.addressPrefix = prefix
 	}
 }
 
+// WithGas sets the gas limit of the txs, the gas of a tx is simulated when it's 0, which is the default.
+func WithGas(gas uint64) Option {
+	return func(c *Client) {
+		c.fees.gas = gas
+	}
+}
+
+// WithGasAdjustment sets the factor the simulated gas of a tx is multiplied by, it is 1 by default.
+func WithGasAdjustment(adjustment float64) Option {
+	return func(c *Client) {
+		c.fees.gasAdjustment = adjustment
+	}
+}
+
+// WithGasPrices sets the gas prices the fees of the txs are computed from, e.g. 0.025stake.
+func WithGasPrices(gasPrices string) Option {
+	return func(c *Client) {
+		c.fees.gasPrices = gasPrices
+	}
+}
+
+// WithFees sets the fees of the txs, e.g. 1000stake.
+func WithFees(fees string) Option {
+	return func(c *Client) {
+		c.fees.fees = fees
+	}
+}
+
+// WithFeeGranter sets the address of the account that pays the fees of the txs with a fee grant.
+func WithFeeGranter(address string) Option {
+	return func(c *Client) {
+		c.fees.feeGranter = address
+	}
+}
+
+// WithAutoGasPrices discovers the min. gas prices of the node when neither fees nor gas prices are set.
+// The node doesn't expose its min. gas prices, they are learned from the rejection of the first tx:
+// a tx rejected for insufficient fees is broadcasted again with the fees required by the node, and
+// the gas prices derived from them are used by the next txs.
+// The fees are paid in a single denom, the first of denoms required by the node or, when none of
+// them is, the first denom required by the node.
+func WithAutoGasPrices(denoms ...string) Option {
+	return func(c *Client) {
+		c.autoGasPrices = true
+		c.autoGasDenoms = denoms
+	}
+}
+
 func WithUseFaucet(faucetAddress, denom string, minAmount uint64) Option {
 	return func(c *Client) {
 		c.useFaucet = true
@@ -153,6 +234,9 @@ func New(ctx context.Context, options ...Option) (Client, error) {
 		faucetDenom:     defaultFaucetDenom,
 		faucetMinAmount: defaultFaucetMinAmount,
 		out:             io.Discard,
+		sequences:       newSequenceManager(),
+		fees:            feeOptions{gasAdjustment: defaultGasAdjustment},
+		gasPrices:       &gasPricesCache{},
 	}
 
 	var err error
@@ -161,16 +245,22 @@ func New(ctx context.Context, options ...Option) (Client, error) {
 		apply(&c)
 	}
 
-	if c.RPC, err = rpchttp.New(c.nodeAddress, "/websocket"); err != nil {
+	if err := c.fees.validate(); err != nil {
 		return Client{}, err
 	}
 
-	statusResp, err := c.RPC.Status(ctx)
-	if err != nil {
+	if c.RPC, err = rpchttp.New(c.nodeAddress, "/websocket"); err != nil {
 		return Client{}, err
 	}
 
-	c.chainID = statusResp.NodeInfo.Network
+	if c.chainID == "" {
+		statusResp, err := c.RPC.Status(ctx)
+		if err != nil {
+			return Client{}, err
+		}
+
+		c.chainID = statusResp.NodeInfo.Network
+	}
 
 	if c.homePath == "" {
 		home, err := os.UserHomeDir()
@@ -180,13 +270,15 @@ func New(ctx context.Context, options ...Option) (Client, error) {
 		c.homePath = filepath.Join(home, "."+c.chainID)
 	}
 
-	c.AccountRegistry, err = cosmosaccount.New(
-		cosmosaccount.WithKeyringServiceName(c.keyringServiceName),
-		cosmosaccount.WithKeyringBackend(c.keyringBackend),
-		cosmosaccount.WithHome(c.homePath),
-	)
-	if err != nil {
-		return Client{}, err
+	if c.AccountRegistry.Keyring == nil {
+		c.AccountRegistry, err = cosmosaccount.New(
+			cosmosaccount.WithKeyringServiceName(c.keyringServiceName),
+			cosmosaccount.WithKeyringBackend(c.keyringBackend),
+			cosmosaccount.WithHome(c.homePath),
+		)
+		if err != nil {
+			return Client{}, err
+		}
 	}
 
 	c.context = newContext(c.RPC, c.out, c.chainID, c.homePath).WithKeyring(c.AccountRegistry.Keyring)
@@ -319,76 +411,115 @@ func (c Client) BroadcastTx(accountName string, msgs ...sdktypes.Msg) (Response,
 	return broadcast()
 }
 
-// protects sdktypes.Config.
-var mconf sync.Mutex
-
+// BroadcastTxWithProvision simulates a tx with given messages for account and returns the gas it
+// needs and a function to broadcast the tx. The tx is signed with the next sequence of the account
+// when it's broadcasted.
 func (c Client) BroadcastTxWithProvision(accountName string, msgs ...sdktypes.Msg) (
 	gas uint64, broadcast func() (Response, error), err error,
 ) {
-	if err := c.prepareBroadcast(context.Background(), accountName, msgs); err != nil {
-		return 0, nil, err
-	}
-
-	// TODO find a better way if possible.
-	mconf.Lock()
-	defer mconf.Unlock()
-	config := sdktypes.GetConfig()
-	config.SetBech32PrefixForAccount(c.addressPrefix, c.addressPrefix+"pub")
-
-	accountAddress, err := c.Address(accountName)
+	txf, ctx, err := c.provision(accountName, msgs)
 	if err != nil {
 		return 0, nil, err
 	}
 
-	ctx := c.context.
-		WithFromName(accountName).
-		WithFromAddress(accountAddress)
+	// Return the provision function
+	return txf.Gas(), func() (Response, error) {
+		resp, err := c.submitTx(ctx, txf, msgs)
+		if err != nil {
+			return Response{}, err
+		}
+
+		return c.awaitTx(context.Background(), resp)
+	}, nil
+}
 
-	txf, err := prepareFactory(ctx, c.Factory)
+// BroadcastTxAsync creates and broadcasts a tx with given messages for account without waiting for
+// the tx to be included in a block. The response only holds the hash of the tx once it's accepted
+// in the mempool. The txs of an account can be broadcasted concurrently, their sequences are
+// handed out locally.
+func (c Client) BroadcastTxAsync(accountName string, msgs ...sdktypes.Msg) (Response, error) {
+	txf, ctx, err := c.provision(accountName, msgs)
 	if err != nil {
-		return 0, nil, err
+		return Response{}, err
 	}
 
-	_, gas, err = tx.CalculateGas(ctx, txf, msgs...)
+	resp, err := c.submitTx(ctx, txf, msgs)
 	if err != nil {
-		return 0, nil, err
+		return Response{}, err
 	}
-	// the simulated gas can vary from the actual gas needed for a real transaction
-	// we add an additional amount to endure sufficient gas is provided
-	gas += 10000
-	txf = txf.WithGas(gas)
 
-	// Return the provision function
-	return gas, func() (Response, error) {
-		txUnsigned, err := tx.BuildUnsignedTx(txf, msgs...)
+	return Response{
+		Codec:      ctx.Codec,
+		TxResponse: resp,
+	}, nil
+}
+
+// BroadcastTxBatch broadcasts a tx for each list of messages in txs for account and waits for all
+// of them to be included in a block. The txs are broadcasted one after the other with consecutive
+// sequences without waiting for the previous ones to be included. The responses are in the order of
+// txs, the error is the one of the first tx that failed.
+func (c Client) BroadcastTxBatch(accountName string, txs ...[]sdktypes.Msg) ([]Response, error) {
+	submitted := make([]*sdktypes.TxResponse, len(txs))
+
+	for i, msgs := range txs {
+		txf, ctx, err := c.provision(accountName, msgs)
 		if err != nil {
-			return Response{}, err
+			return nil, errors.Wrapf(err, "tx %d", i)
 		}
 
-		txUnsigned.SetFeeGranter(ctx.GetFeeGranterAddress())
-		if err := tx.Sign(txf, accountName, txUnsigned, true); err != nil {
-			return Response{}, err
+		if submitted[i], err = c.submitTx(ctx, txf, msgs); err != nil {
+			return nil, errors.Wrapf(err, "tx %d", i)
 		}
+	}
 
-		txBytes, err := ctx.TxConfig.TxEncoder()(txUnsigned.GetTx())
-		if err != nil {
-			return Response{}, err
-		}
+	var (
+		responses = make([]Response, len(txs))
+		firstErr  error
+	)
 
-		resp, err := ctx.BroadcastTx(txBytes)
-		if err == sdkerrors.ErrInsufficientFunds {
-			err = c.makeSureAccountHasTokens(context.Background(), accountAddress.String())
-			if err != nil {
-				return Response{}, err
-			}
-			resp, err = ctx.BroadcastTx(txBytes)
+	for i, resp := range submitted {
+		var err error
+		responses[i], err = c.awaitTx(context.Background(), resp)
+		if err != nil && firstErr == nil {
+			firstErr = errors.Wrapf(err, "tx %d", i)
 		}
+	}
 
-		return Response{
-			Codec:      ctx.Codec,
-			TxResponse: resp,
-		}, handleBroadcastResult(resp, err)
-	}, nil
+	return responses, firstErr
+}
+
+// provision prepares the account to broadcast msgs and returns the factory of their tx
+// with the gas they need, the gas is simulated unless it's set.
+func (c Client) provision(accountName string, msgs []sdktypes.Msg) (txf tx.Factory, ctx client.Context, err error) {
+	if txf, err = c.txFactory(); err != nil {
+		return txf, ctx, err
+	}
+
+	if err := c.prepareBroadcast(context.Background(), accountName, msgs); err != nil {
+		return txf, ctx, err
+	}
+
+	accountAddress, err := c.Address(accountName)
+	if err != nil {
+		return txf, ctx, err
+	}
+
+	feeGranter, err := c.feeGranterAddress()
+	if err != nil {
+		return txf, ctx, err
+	}
+
+	ctx = c.context.
+		WithFromName(accountName).
+		WithFromAddress(accountAddress).
+		WithFeeGranterAddress(feeGranter)
+
+	if c.fees.gas > 0 {
+		return txf.WithGas(c.fees.gas), ctx, nil
+	}
+
+	gas, err := c.simulateGas(ctx, txf, msgs)
+	return txf.WithGas(gas), ctx, err
 }
 
 // prepareBroadcast performs checks and operations before broadcasting messages
@@ -474,32 +605,6 @@ func handleBroadcastResult(resp *sdktypes.TxResponse, err error) error {
 	return nil
 }
 
-func prepareFactory(clientCtx client.Context, txf tx.Factory) (tx.Factory, error) {
-	from := clientCtx.GetFromAddress()
-
-	if err := txf.AccountRetriever().EnsureExists(clientCtx, from); err != nil {
-		return txf, err
-	}
-
-	initNum, initSeq := txf.AccountNumber(), txf.Sequence()
-	if initNum == 0 || initSeq == 0 {
-		num, seq, err := txf.AccountRetriever().GetAccountNumberSequence(clientCtx, from)
-		if err != nil {
-			return txf, err
-		}
-
-		if initNum == 0 {
-			txf = txf.WithAccountNumber(num)
-		}
-
-		if initSeq == 0 {
-			txf = txf.WithSequence(seq)
-		}
-	}
-
-	return txf, nil
-}
-
 func newContext(
 	c *rpchttp.HTTP,
 	out io.Writer,
@@ -517,7 +622,9 @@ func newContext(
 	cryptocodec.RegisterInterfaces(interfaceRegistry)
 	sdktypes.RegisterInterfaces(interfaceRegistry)
 	staking.RegisterInterfaces(interfaceRegistry)
+	banktypes.RegisterInterfaces(interfaceRegistry)
 	cryptocodec.RegisterInterfaces(interfaceRegistry)
+	ibctypes.RegisterInterfaces(interfaceRegistry)
 
 	return client.Context{}.
 		WithChainID(chainID).
@@ -0,0 +1,132 @@
+package cosmosclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// Event is an event emitted by a block or a tx.
+type Event struct {
+	// Height is the height of the block that emitted the event.
+	Height int64
+
+	// TxHash is the hash of the tx that emitted the event, it is empty for the events of a block.
+	TxHash string
+
+	// ABCI is the event.
+	ABCI abci.Event
+
+	// Typed is the event decoded into its proto message when it's a typed event, i.e. an event
+	// emitted with EmitTypedEvent, it is nil otherwise.
+	Typed proto.Message
+}
+
+// Block is a block included in the chain.
+type Block struct {
+	*tmtypes.Block
+
+	// Events are the events emitted at the beginning and at the end of the block.
+	Events []Event
+}
+
+// TxResult is the result of a tx included in a block.
+type TxResult struct {
+	// Height is the height of the block that includes the tx.
+	Height int64
+
+	// Index is the index of the tx in the block.
+	Index uint32
+
+	// Hash is the hash of the tx.
+	Hash string
+
+	// Tx is the encoded tx.
+	Tx tmtypes.Tx
+
+	// Result is the result of the execution of the tx.
+	Result abci.ResponseDeliverTx
+
+	// Events are the events emitted by the tx.
+	Events []Event
+}
+
+// txResult converts the result of a tx from an event of Tendermint.
+func (c Client) txResult(result abci.TxResult) TxResult {
+	hash := fmt.Sprintf("%X", tmtypes.Tx(result.Tx).Hash())
+
+	return TxResult{
+		Height: result.Height,
+		Index:  result.Index,
+		Hash:   hash,
+		Tx:     result.Tx,
+		Result: result.Result,
+		Events: c.events(result.Height, hash, result.Result.Events),
+	}
+}
+
+// block converts a block from an event of Tendermint.
+func (c Client) block(data tmtypes.EventDataNewBlock) Block {
+	var events []abci.Event
+	events = append(events, data.ResultBeginBlock.Events...)
+	events = append(events, data.ResultEndBlock.Events...)
+
+	return Block{
+		Block:  data.Block,
+		Events: c.events(data.Block.Height, "", events),
+	}
+}
+
+// events converts the abci events emitted at height by the tx with hash, or by the block when hash is empty.
+func (c Client) events(height int64, hash string, abciEvents []abci.Event) []Event {
+	events := make([]Event, 0, len(abciEvents))
+
+	for _, e := range abciEvents {
+		events = append(events, Event{
+			Height: height,
+			TxHash: hash,
+			ABCI:   e,
+			Typed:  c.decodeTypedEvent(e),
+		})
+	}
+
+	return events
+}
+
+// decodeTypedEvent decodes a typed event with the codec of the client, nil is returned when the
+// type of the event isn't a known proto message or its attributes can't be decoded into it.
+func (c Client) decodeTypedEvent(event abci.Event) proto.Message {
+	msg, err := c.context.InterfaceRegistry.Resolve("/" + event.Type)
+	if err != nil {
+		goType := proto.MessageType(event.Type)
+		if goType == nil || goType.Kind() != reflect.Ptr {
+			return nil
+		}
+
+		var ok bool
+		if msg, ok = reflect.New(goType.Elem()).Interface().(proto.Message); !ok {
+			return nil
+		}
+	}
+
+	// the attributes of a typed event are the JSON encoded fields of its message.
+	attributes := make(map[string]json.RawMessage)
+	for _, attr := range event.Attributes {
+		attributes[string(attr.Key)] = attr.Value
+	}
+
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return nil
+	}
+
+	if err := c.context.Codec.UnmarshalJSON(data, msg); err != nil {
+		return nil
+	}
+
+	return msg
+}
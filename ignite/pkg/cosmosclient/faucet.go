@@ -0,0 +1,39 @@
+package cosmosclient
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosfaucet"
+)
+
+// FaucetClient returns a client for a faucet that sends its transfers with c, so the transfers are
+// signed with the gas and fee settings of c and the sequences of the accounts handed out by c.
+func (c Client) FaucetClient() cosmosfaucet.ChainClient {
+	return faucetClient{c}
+}
+
+type faucetClient struct {
+	c Client
+}
+
+func (f faucetClient) Context() client.Context {
+	return f.c.Context()
+}
+
+func (f faucetClient) SendTx(accountName string, msgs ...sdktypes.Msg) (txHash string, err error) {
+	resp, err := f.c.BroadcastTxAsync(accountName, msgs...)
+	if err != nil {
+		return "", err
+	}
+	return resp.TxHash, nil
+}
+
+// AwaitTx polls the node for the tx, the txs of a faucet don't open a subscription each.
+func (f faucetClient) AwaitTx(ctx context.Context, txHash string) error {
+	_, err := f.c.pollTx(ctx, strings.ToUpper(txHash))
+	return err
+}
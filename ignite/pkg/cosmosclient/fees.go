@@ -0,0 +1,192 @@
+package cosmosclient
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/pkg/errors"
+)
+
+// requiredFeesRe matches the fees required by the node in the log of a tx rejected for insufficient fees,
+// e.g. "insufficient fees; got: 1stake required: 2000stake,20token: insufficient fee".
+var requiredFeesRe = regexp.MustCompile(`required: ([^\s:]+)`)
+
+// feeOptions are the gas and fee settings of txs.
+type feeOptions struct {
+	gas           uint64
+	gasAdjustment float64
+	gasPrices     string
+	fees          string
+	feeGranter    string
+}
+
+// TxOption overrides the gas and fee settings of the client for txs, see Client.WithTxOptions.
+type TxOption func(*feeOptions)
+
+// TxGas sets the gas limit of txs, the gas is simulated when it's 0.
+func TxGas(gas uint64) TxOption {
+	return func(o *feeOptions) {
+		o.gas = gas
+	}
+}
+
+// TxGasAdjustment sets the factor the simulated gas is multiplied by.
+func TxGasAdjustment(adjustment float64) TxOption {
+	return func(o *feeOptions) {
+		o.gasAdjustment = adjustment
+	}
+}
+
+// TxGasPrices sets the gas prices the fees of txs are computed from, e.g. 0.025stake,
+// it replaces the fees.
+func TxGasPrices(gasPrices string) TxOption {
+	return func(o *feeOptions) {
+		o.gasPrices = gasPrices
+		o.fees = ""
+	}
+}
+
+// TxFees sets the fees of txs, e.g. 1000stake, it replaces the gas prices.
+func TxFees(fees string) TxOption {
+	return func(o *feeOptions) {
+		o.fees = fees
+		o.gasPrices = ""
+	}
+}
+
+// TxFeeGranter sets the address of the account that pays the fees of txs with a fee grant.
+func TxFeeGranter(address string) TxOption {
+	return func(o *feeOptions) {
+		o.feeGranter = address
+	}
+}
+
+// WithTxOptions returns a copy of the client that broadcasts txs with the gas and
+// fee settings overridden by options, e.g.:
+//
+//	c.WithTxOptions(cosmosclient.TxFees("1000stake")).BroadcastTx(account, msg)
+func (c Client) WithTxOptions(options ...TxOption) Client {
+	for _, apply := range options {
+		apply(&c.fees)
+	}
+	return c
+}
+
+func (o feeOptions) validate() error {
+	if o.fees != "" && o.gasPrices != "" {
+		return errors.New("cannot provide both fees and gas prices")
+	}
+	if _, err := sdktypes.ParseCoinsNormalized(o.fees); err != nil {
+		return errors.Wrapf(err, "invalid fees %q", o.fees)
+	}
+	if _, err := sdktypes.ParseDecCoins(o.gasPrices); err != nil {
+		return errors.Wrapf(err, "invalid gas prices %q", o.gasPrices)
+	}
+	if o.gasAdjustment <= 0 {
+		return errors.New("gas adjustment must be positive")
+	}
+	if o.feeGranter != "" {
+		if _, _, err := bech32.DecodeAndConvert(o.feeGranter); err != nil {
+			return errors.Wrapf(err, "invalid fee granter %q", o.feeGranter)
+		}
+	}
+	return nil
+}
+
+// gasPricesCache holds the gas prices discovered from the node, it is shared by the copies of a client.
+type gasPricesCache struct {
+	mu     sync.RWMutex
+	prices sdktypes.DecCoins
+}
+
+func (g *gasPricesCache) get() sdktypes.DecCoins {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	return g.prices
+}
+
+func (g *gasPricesCache) set(prices sdktypes.DecCoins) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.prices = prices
+}
+
+// txFactory returns the factory to build txs with the gas and fee settings of the client.
+func (c Client) txFactory() (tx.Factory, error) {
+	if err := c.fees.validate(); err != nil {
+		return tx.Factory{}, err
+	}
+
+	txf := c.Factory.
+		WithGasAdjustment(c.fees.gasAdjustment).
+		WithFees(c.fees.fees).
+		WithGasPrices(c.fees.gasPrices)
+
+	if c.discoversGasPrices() {
+		if prices := c.gasPrices.get(); !prices.IsZero() {
+			txf = txf.WithGasPrices(prices.String())
+		}
+	}
+
+	return txf, nil
+}
+
+// feeGranterAddress returns the address of the fee granter, it is nil when there is no fee granter.
+func (c Client) feeGranterAddress() (sdktypes.AccAddress, error) {
+	if c.fees.feeGranter == "" {
+		return nil, nil
+	}
+
+	_, address, err := bech32.DecodeAndConvert(c.fees.feeGranter)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid fee granter %q", c.fees.feeGranter)
+	}
+
+	return address, nil
+}
+
+// discoversGasPrices checks if the gas prices are discovered from the node,
+// i.e. when neither fees nor gas prices are set.
+func (c Client) discoversGasPrices() bool {
+	return c.autoGasPrices && c.fees.fees == "" && c.fees.gasPrices == ""
+}
+
+// discoverGasPrices derives the min. gas prices of the node from a tx with gas rejected for
+// insufficient fees, the node listing the fees it requires in the log of the tx. The fees are
+// accepted when any of the required amounts is paid, so the price of a single denom is kept,
+// see WithAutoGasPrices. The prices are used by the next txs, false is returned when there are
+// no prices to discover.
+func (c Client) discoverGasPrices(resp *sdktypes.TxResponse, gas uint64) (sdktypes.DecCoins, bool) {
+	if !c.discoversGasPrices() || gas == 0 ||
+		resp.Codespace != sdkerrors.ErrInsufficientFee.Codespace() ||
+		resp.Code != sdkerrors.ErrInsufficientFee.ABCICode() {
+		return nil, false
+	}
+
+	match := requiredFeesRe.FindStringSubmatch(resp.RawLog)
+	if match == nil {
+		return nil, false
+	}
+
+	required, err := sdktypes.ParseCoinsNormalized(match[1])
+	if err != nil || required.IsZero() {
+		return nil, false
+	}
+
+	fee := required[0]
+	for _, denom := range c.autoGasDenoms {
+		if amount := required.AmountOf(denom); amount.IsPositive() {
+			fee = sdktypes.NewCoin(denom, amount)
+			break
+		}
+	}
+
+	prices := sdktypes.NewDecCoins(sdktypes.NewDecCoinFromCoin(fee)).QuoDec(sdktypes.NewDec(int64(gas)))
+	c.gasPrices.set(prices)
+
+	return prices, true
+}
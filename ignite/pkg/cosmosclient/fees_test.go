@@ -0,0 +1,96 @@
+package cosmosclient
+
+import (
+	"context"
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+func newOfflineClient(t *testing.T, options ...Option) (Client, error) {
+	options = append([]Option{
+		WithChainID("test"),
+		WithKeyringBackend(cosmosaccount.KeyringMemory),
+		WithHome(t.TempDir()),
+	}, options...)
+
+	return New(context.Background(), options...)
+}
+
+func TestFeeOptions(t *testing.T) {
+	_, err := newOfflineClient(t, WithFees("10stake"), WithGasPrices("0.1stake"))
+	require.Error(t, err, "fees and gas prices are exclusive")
+
+	_, err = newOfflineClient(t, WithGasPrices("stake"))
+	require.Error(t, err, "invalid gas prices")
+
+	_, err = newOfflineClient(t, WithFeeGranter("granter"))
+	require.Error(t, err, "invalid fee granter")
+
+	c, err := newOfflineClient(t, WithGasPrices("0.1stake"), WithGasAdjustment(1.5))
+	require.NoError(t, err)
+
+	txf, err := c.txFactory()
+	require.NoError(t, err)
+	require.Equal(t, "0.100000000000000000stake", txf.GasPrices().String())
+	require.Equal(t, 1.5, txf.GasAdjustment())
+
+	// per tx overrides replace the settings of the client without changing the client.
+	txf, err = c.WithTxOptions(TxFees("10token")).txFactory()
+	require.NoError(t, err)
+	require.True(t, txf.GasPrices().IsZero())
+	require.Equal(t, "10token", txf.Fees().String())
+
+	txf, err = c.txFactory()
+	require.NoError(t, err)
+	require.True(t, txf.Fees().IsZero())
+}
+
+func TestDiscoverGasPrices(t *testing.T) {
+	insufficientFee := &sdktypes.TxResponse{
+		Codespace: sdkerrors.ErrInsufficientFee.Codespace(),
+		Code:      sdkerrors.ErrInsufficientFee.ABCICode(),
+		RawLog:    "insufficient fees; got:  required: 2000stake: insufficient fee",
+	}
+
+	c, err := newOfflineClient(t)
+	require.NoError(t, err)
+
+	_, ok := c.discoverGasPrices(insufficientFee, 100000)
+	require.False(t, ok, "gas prices are discovered only with auto gas prices")
+
+	c, err = newOfflineClient(t, WithAutoGasPrices())
+	require.NoError(t, err)
+
+	_, ok = c.WithTxOptions(TxGasPrices("0.1stake")).discoverGasPrices(insufficientFee, 100000)
+	require.False(t, ok, "gas prices are set")
+
+	prices, ok := c.discoverGasPrices(insufficientFee, 100000)
+	require.True(t, ok)
+	require.Equal(t, "0.020000000000000000stake", prices.String())
+
+	txf, err := c.txFactory()
+	require.NoError(t, err)
+	require.Equal(t, prices, txf.GasPrices(), "discovered gas prices are used by the next txs")
+
+	_, ok = c.discoverGasPrices(&sdktypes.TxResponse{Code: sdkerrors.ErrOutOfGas.ABCICode()}, 100000)
+	require.False(t, ok)
+
+	// the fees are paid in a single denom when several are required.
+	insufficientFee.RawLog = "insufficient fees; got:  required: 2000stake,100token: insufficient fee"
+
+	prices, ok = c.discoverGasPrices(insufficientFee, 100000)
+	require.True(t, ok)
+	require.Equal(t, "0.020000000000000000stake", prices.String())
+
+	c, err = newOfflineClient(t, WithAutoGasPrices("uatom", "token"))
+	require.NoError(t, err)
+
+	prices, ok = c.discoverGasPrices(insufficientFee, 100000)
+	require.True(t, ok)
+	require.Equal(t, "0.001000000000000000token", prices.String(), "the preferred denom is used")
+}
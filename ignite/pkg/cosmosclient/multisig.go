@@ -0,0 +1,134 @@
+package cosmosclient
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	kmultisig "github.com/cosmos/cosmos-sdk/crypto/keys/multisig"
+	"github.com/cosmos/cosmos-sdk/crypto/types/multisig"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	"github.com/pkg/errors"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+// SignMultisigTx signs the tx in txJSON with accountName on behalf of the multisig account
+// multisigName and returns the signature as JSON, to be combined with the signatures of the other
+// keys of the multisig by CombineSignatures. The account number and sequence of the multisig
+// account are queried from the node when signer is nil.
+func (c Client) SignMultisigTx(txJSON []byte, accountName, multisigName string, signer *SignerData) ([]byte, error) {
+	multisigAccount, err := c.multisigAccount(multisigName)
+	if err != nil {
+		return nil, err
+	}
+
+	txBuilder, err := c.decodeTxJSON(txJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	txf, err := c.signerFactory(multisigAccount.Info.GetAddress(), signer)
+	if err != nil {
+		return nil, err
+	}
+
+	// multisig accounts require amino JSON signatures.
+	txf = txf.WithSignMode(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+
+	// the signatures of a multisig are collected independently, previous signatures are ignored.
+	if err := tx.Sign(txf, accountName, txBuilder, true); err != nil {
+		return nil, err
+	}
+
+	sigs, err := txBuilder.GetTx().GetSignaturesV2()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.context.TxConfig.MarshalSignatureJSON(sigs)
+}
+
+// CombineSignatures combines the signatures of the keys of the multisig account multisigName
+// into the tx in txJSON and returns the signed tx as JSON, which can be broadcasted with
+// BroadcastSignedTx. Each signature is verified, and at least the threshold of the multisig
+// is required. The account number and sequence of the multisig account are queried from the node
+// when signer is nil.
+func (c Client) CombineSignatures(txJSON []byte, multisigName string, signatures [][]byte, signer *SignerData) ([]byte, error) {
+	multisigAccount, err := c.multisigAccount(multisigName)
+	if err != nil {
+		return nil, err
+	}
+
+	multisigPubKey := multisigAccount.Info.GetPubKey().(*kmultisig.LegacyAminoPubKey)
+
+	txBuilder, err := c.decodeTxJSON(txJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	txf, err := c.signerFactory(multisigAccount.Info.GetAddress(), signer)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		multisigSig = multisig.NewMultisig(len(multisigPubKey.PubKeys))
+		signerData  = authsigning.SignerData{
+			ChainID:       txf.ChainID(),
+			AccountNumber: txf.AccountNumber(),
+			Sequence:      txf.Sequence(),
+		}
+	)
+
+	for _, signature := range signatures {
+		sigs, err := c.context.TxConfig.UnmarshalSignatureJSON(signature)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid signature")
+		}
+
+		for _, sig := range sigs {
+			err := authsigning.VerifySignature(sig.PubKey, signerData, sig.Data, c.context.TxConfig.SignModeHandler(), txBuilder.GetTx())
+			if err != nil {
+				addr, _ := bech32.ConvertAndEncode(c.addressPrefix, sig.PubKey.Address())
+				return nil, errors.Wrapf(err, "cannot verify the signature of %s", addr)
+			}
+
+			if err := multisig.AddSignatureV2(multisigSig, sig, multisigPubKey.GetPubKeys()); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// a signature provided more than once replaces the previous one of its key,
+	// so the keys that have signed are counted instead of the signatures.
+	signed := multisigSig.BitArray.NumTrueBitsBefore(len(multisigPubKey.PubKeys))
+	if signed < int(multisigPubKey.Threshold) {
+		return nil, fmt.Errorf("%d signatures are required, only %d are provided", multisigPubKey.Threshold, signed)
+	}
+
+	err = txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   multisigPubKey,
+		Data:     multisigSig,
+		Sequence: txf.Sequence(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.context.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+func (c Client) multisigAccount(name string) (account cosmosaccount.Account, err error) {
+	account, err = c.Account(name)
+	if err != nil {
+		return account, err
+	}
+
+	if !account.IsMultisig() {
+		return account, fmt.Errorf("account %q is not a multisig account", name)
+	}
+
+	return account, nil
+}
@@ -0,0 +1,80 @@
+package cosmosclient
+
+import (
+	"context"
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	signingtypes "github.com/cosmos/cosmos-sdk/types/tx/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+)
+
+func TestMultisigSigning(t *testing.T) {
+	c, err := New(
+		context.Background(),
+		WithChainID("test"),
+		WithKeyringBackend(cosmosaccount.KeyringMemory),
+		WithHome(t.TempDir()),
+	)
+	require.NoError(t, err)
+
+	for _, name := range []string{"a", "b", "c"} {
+		_, _, err := c.AccountRegistry.Create(name)
+		require.NoError(t, err)
+	}
+
+	multisigAccount, err := c.AccountRegistry.CreateMultisig("multisig", []string{"a", "b", "c"}, 2)
+	require.NoError(t, err)
+
+	receiver, err := c.Account("a")
+	require.NoError(t, err)
+
+	txBuilder := c.context.TxConfig.NewTxBuilder()
+	err = txBuilder.SetMsgs(banktypes.NewMsgSend(
+		multisigAccount.Info.GetAddress(),
+		receiver.Info.GetAddress(),
+		sdktypes.NewCoins(sdktypes.NewInt64Coin("token", 10)),
+	))
+	require.NoError(t, err)
+	txBuilder.SetGasLimit(defaultGasLimit)
+
+	txJSON, err := c.context.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+	require.NoError(t, err)
+
+	signer := &SignerData{AccountNumber: 1, Sequence: 2}
+
+	var signatures [][]byte
+	for _, name := range []string{"a", "b"} {
+		signature, err := c.SignMultisigTx(txJSON, name, "multisig", signer)
+		require.NoError(t, err)
+		signatures = append(signatures, signature)
+	}
+
+	_, err = c.CombineSignatures(txJSON, "multisig", signatures[:1], signer)
+	require.Error(t, err, "the threshold is not reached")
+
+	_, err = c.CombineSignatures(txJSON, "multisig", [][]byte{signatures[0], signatures[0]}, signer)
+	require.Error(t, err, "the threshold is not reached with the same signature twice")
+
+	_, err = c.CombineSignatures(txJSON, "multisig", signatures, &SignerData{AccountNumber: 1, Sequence: 3})
+	require.Error(t, err, "the signatures are made for another sequence")
+
+	signedJSON, err := c.CombineSignatures(txJSON, "multisig", signatures, signer)
+	require.NoError(t, err)
+
+	signed, err := c.decodeTxJSON(signedJSON)
+	require.NoError(t, err)
+
+	sigs, err := signed.GetTx().GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	require.True(t, multisigAccount.Info.GetPubKey().Equals(sigs[0].PubKey))
+	require.Equal(t, uint64(2), sigs[0].Sequence)
+	require.Len(t, sigs[0].Data.(*signingtypes.MultiSignatureData).Signatures, 2)
+
+	_, err = c.SignMultisigTx(txJSON, "a", "a", signer)
+	require.Error(t, err, "a is not a multisig account")
+}
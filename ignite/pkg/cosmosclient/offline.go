@@ -0,0 +1,160 @@
+package cosmosclient
+
+import (
+	"context"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/pkg/errors"
+)
+
+// SignerData is the account number and sequence of the account that signs a tx.
+// they are required to sign a tx offline, without querying the node.
+type SignerData struct {
+	AccountNumber uint64
+	Sequence      uint64
+}
+
+// BuildUnsignedTx builds a tx with msgs for the account with accountName without signing it, and
+// returns it as JSON, in the same format as the txs generated by the CLI of the chain with
+// --generate-only. The gas of the tx is simulated unless it's set with WithGas or TxGas, the tx is
+// built offline when the gas and the chain id are set.
+func (c Client) BuildUnsignedTx(accountName string, msgs ...sdktypes.Msg) ([]byte, error) {
+	txf, err := c.txFactory()
+	if err != nil {
+		return nil, err
+	}
+
+	address, err := c.Address(accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	feeGranter, err := c.feeGranterAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	if c.fees.gas > 0 {
+		txf = txf.WithGas(c.fees.gas)
+	} else {
+		ctx := c.context.
+			WithFromName(accountName).
+			WithFromAddress(address)
+
+		gas, err := c.simulateGas(ctx, txf, msgs)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot simulate the gas of the tx")
+		}
+		txf = txf.WithGas(gas)
+	}
+
+	txBuilder, err := tx.BuildUnsignedTx(txf, msgs...)
+	if err != nil {
+		return nil, err
+	}
+	txBuilder.SetFeeGranter(feeGranter)
+
+	return c.context.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// SignTx signs the tx in txJSON with the account with accountName and returns the signed tx as
+// JSON. The signature is added to the signatures of the tx, so the txs with several signers can be
+// signed by each of them in turn, in the order of the signers of the tx. The account number and
+// sequence of the account are queried from the node when signer is nil.
+func (c Client) SignTx(txJSON []byte, accountName string, signer *SignerData) ([]byte, error) {
+	address, err := c.Address(accountName)
+	if err != nil {
+		return nil, err
+	}
+
+	txBuilder, err := c.decodeTxJSON(txJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	txf, err := c.signerFactory(address, signer)
+	if err != nil {
+		return nil, err
+	}
+
+	// amino JSON signatures don't sign the signer infos of the tx, unlike direct signatures,
+	// so the signatures made before are still valid once the signature is added.
+	txf = txf.WithSignMode(signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+
+	if err := tx.Sign(txf, accountName, txBuilder, false); err != nil {
+		return nil, err
+	}
+
+	return c.context.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+}
+
+// BroadcastSignedTx broadcasts the signed tx in txJSON and waits for it to be included in a block.
+func (c Client) BroadcastSignedTx(txJSON []byte) (Response, error) {
+	txBuilder, err := c.decodeTxJSON(txJSON)
+	if err != nil {
+		return Response{}, err
+	}
+
+	txBytes, err := c.context.TxConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return Response{}, err
+	}
+
+	resp, err := c.context.WithBroadcastMode(flags.BroadcastSync).BroadcastTx(txBytes)
+	if err := handleBroadcastResult(resp, err); err != nil {
+		return Response{}, err
+	}
+
+	return c.awaitTx(context.Background(), resp)
+}
+
+// DecodeMsgJSON decodes a message encoded as proto JSON with its type URL in @type, e.g.
+// {"@type":"/cosmos.bank.v1beta1.MsgSend",...}. The type of the message must be registered
+// in the interface registry of the client.
+func (c Client) DecodeMsgJSON(msgJSON []byte) (sdktypes.Msg, error) {
+	var msgAny codectypes.Any
+	if err := c.context.Codec.UnmarshalJSON(msgJSON, &msgAny); err != nil {
+		return nil, errors.Wrap(err, "cannot decode the message")
+	}
+
+	var msg sdktypes.Msg
+	if err := c.context.InterfaceRegistry.UnpackAny(&msgAny, &msg); err != nil {
+		return nil, errors.Wrap(err, "cannot decode the message")
+	}
+
+	return msg, nil
+}
+
+// decodeTxJSON decodes a tx encoded as JSON, e.g. a tx generated with --generate-only.
+func (c Client) decodeTxJSON(txJSON []byte) (client.TxBuilder, error) {
+	decoded, err := c.context.TxConfig.TxJSONDecoder()(txJSON)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot decode the tx")
+	}
+
+	return c.context.TxConfig.WrapTxBuilder(decoded)
+}
+
+// signerFactory returns the factory to sign a tx of address, the account number and sequence are
+// queried from the node when signer is nil.
+func (c Client) signerFactory(address sdktypes.AccAddress, signer *SignerData) (tx.Factory, error) {
+	txf := c.Factory
+
+	if signer != nil {
+		return txf.
+			WithAccountNumber(signer.AccountNumber).
+			WithSequence(signer.Sequence), nil
+	}
+
+	num, seq, err := c.accountNumberSequence(context.Background(), address)
+	if err != nil {
+		return txf, errors.Wrap(err, "cannot query the account number and sequence")
+	}
+
+	return txf.WithAccountNumber(num).WithSequence(seq), nil
+}
@@ -0,0 +1,118 @@
+package cosmosclient
+
+import (
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineTx(t *testing.T) {
+	c, err := newOfflineClient(t, WithGas(200000), WithFees("10token"))
+	require.NoError(t, err)
+
+	for _, name := range []string{"alice", "bob"} {
+		_, _, err := c.AccountRegistry.Create(name)
+		require.NoError(t, err)
+	}
+
+	alice, err := c.Account("alice")
+	require.NoError(t, err)
+	bob, err := c.Account("bob")
+	require.NoError(t, err)
+
+	msgJSON := []byte(`{
+		"@type": "/cosmos.bank.v1beta1.MsgSend",
+		"from_address": "` + alice.Address("cosmos") + `",
+		"to_address": "` + bob.Address("cosmos") + `",
+		"amount": [{"denom": "token", "amount": "5"}]
+	}`)
+
+	msg, err := c.DecodeMsgJSON(msgJSON)
+	require.NoError(t, err)
+	require.Equal(t, banktypes.NewMsgSend(
+		alice.Info.GetAddress(),
+		bob.Info.GetAddress(),
+		sdktypes.NewCoins(sdktypes.NewInt64Coin("token", 5)),
+	), msg)
+
+	_, err = c.DecodeMsgJSON([]byte(`{"@type": "/unknown.MsgUnknown"}`))
+	require.Error(t, err)
+
+	unsignedJSON, err := c.BuildUnsignedTx("alice", msg)
+	require.NoError(t, err)
+
+	unsigned, err := c.decodeTxJSON(unsignedJSON)
+	require.NoError(t, err)
+	require.Equal(t, uint64(200000), unsigned.GetTx().GetGas())
+	require.Equal(t, "10token", unsigned.GetTx().GetFee().String())
+
+	signer := &SignerData{AccountNumber: 4, Sequence: 7}
+
+	signedJSON, err := c.SignTx(unsignedJSON, "alice", signer)
+	require.NoError(t, err)
+
+	signed, err := c.decodeTxJSON(signedJSON)
+	require.NoError(t, err)
+
+	sigs, err := signed.GetTx().GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	require.Equal(t, uint64(7), sigs[0].Sequence)
+
+	err = authsigning.VerifySignature(sigs[0].PubKey, authsigning.SignerData{
+		ChainID:       "test",
+		AccountNumber: signer.AccountNumber,
+		Sequence:      signer.Sequence,
+	}, sigs[0].Data, c.context.TxConfig.SignModeHandler(), signed.GetTx())
+	require.NoError(t, err)
+}
+
+func TestSignTxWithSeveralSigners(t *testing.T) {
+	c, err := newOfflineClient(t, WithGas(200000), WithFees("10token"))
+	require.NoError(t, err)
+
+	var accounts []sdktypes.AccAddress
+	for _, name := range []string{"alice", "bob"} {
+		acc, _, err := c.AccountRegistry.Create(name)
+		require.NoError(t, err)
+		accounts = append(accounts, acc.Info.GetAddress())
+	}
+
+	coins := sdktypes.NewCoins(sdktypes.NewInt64Coin("token", 5))
+
+	unsignedJSON, err := c.BuildUnsignedTx(
+		"alice",
+		banktypes.NewMsgSend(accounts[0], accounts[1], coins),
+		banktypes.NewMsgSend(accounts[1], accounts[0], coins),
+	)
+	require.NoError(t, err)
+
+	signers := []*SignerData{{AccountNumber: 4, Sequence: 7}, {AccountNumber: 5, Sequence: 2}}
+
+	signedJSON, err := c.SignTx(unsignedJSON, "alice", signers[0])
+	require.NoError(t, err)
+	signedJSON, err = c.SignTx(signedJSON, "bob", signers[1])
+	require.NoError(t, err)
+
+	signed, err := c.decodeTxJSON(signedJSON)
+	require.NoError(t, err)
+
+	sigs, err := signed.GetTx().GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+
+	// the signature of alice is still valid once bob has signed.
+	for i, sig := range sigs {
+		require.Equal(t, accounts[i], sdktypes.AccAddress(sig.PubKey.Address()))
+
+		err = authsigning.VerifySignature(sig.PubKey, authsigning.SignerData{
+			ChainID:       "test",
+			AccountNumber: signers[i].AccountNumber,
+			Sequence:      signers[i].Sequence,
+		}, sig.Data, c.context.TxConfig.SignModeHandler(), signed.GetTx())
+		require.NoError(t, err)
+	}
+}
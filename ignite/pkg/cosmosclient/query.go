@@ -0,0 +1,307 @@
+package cosmosclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/gogo/protobuf/proto"
+	"github.com/pkg/errors"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+)
+
+// QueryOption configures a query.
+type QueryOption func(*queryOptions)
+
+type queryOptions struct {
+	height   int64
+	allPages bool
+}
+
+// QueryHeight queries the state at height, the latest state is queried by default.
+func QueryHeight(height int64) QueryOption {
+	return func(o *queryOptions) {
+		o.height = height
+	}
+}
+
+// QueryAllPages queries all the pages of a list query, i.e. a query with a pagination in its request
+// and response. The lists of the pages are merged into the lists of the response. All the pages are
+// queried at the same height.
+func QueryAllPages() QueryOption {
+	return func(o *queryOptions) {
+		o.allPages = true
+	}
+}
+
+// queryMethod is a gRPC query method of the chain.
+type queryMethod struct {
+	// name is the fully qualified name of the method, e.g. /cosmos.bank.v1beta1.Query/Balance.
+	name string
+
+	// encodeRequest encodes a JSON request to the proto binary format.
+	encodeRequest func(requestJSON []byte) ([]byte, error)
+
+	// decodeResponse decodes a proto binary response to JSON.
+	decodeResponse func(response []byte) ([]byte, error)
+}
+
+// Query invokes the gRPC query method, e.g. /cosmos.bank.v1beta1.Query/Balance, with request
+// and decodes its response into response.
+func (c Client) Query(ctx context.Context, method string, request, response codec.ProtoMarshaler, options ...QueryOption) error {
+	requestJSON, err := c.context.Codec.MarshalJSON(request)
+	if err != nil {
+		return err
+	}
+
+	m := queryMethod{
+		name:           normalizeQueryMethod(method),
+		encodeRequest:  c.protoEncoder(reflect.TypeOf(request)),
+		decodeResponse: c.protoDecoder(reflect.TypeOf(response)),
+	}
+
+	responseJSON, err := c.queryJSON(ctx, m, requestJSON, options...)
+	if err != nil {
+		return err
+	}
+
+	return c.context.Codec.UnmarshalJSON(responseJSON, response)
+}
+
+// QueryJSON invokes the gRPC query method, e.g. /cosmos.bank.v1beta1.Query/Balance, with
+// requestJSON, the request encoded as proto JSON, and returns the response as proto JSON.
+//
+// The types of the queries of the Cosmos SDK modules are known by the client. The types of the
+// queries of other modules are resolved from the gRPC reflection service of the node, its address
+// must be set with WithGRPCAddress.
+func (c Client) QueryJSON(ctx context.Context, method string, requestJSON []byte, options ...QueryOption) ([]byte, error) {
+	m, err := c.resolveQueryMethod(ctx, normalizeQueryMethod(method))
+	if err != nil {
+		return nil, err
+	}
+
+	return c.queryJSON(ctx, m, requestJSON, options...)
+}
+
+// resolveQueryMethod resolves the request and response types of a query method, from the types
+// registered in the client first, and from the reflection service of the node otherwise.
+func (c Client) resolveQueryMethod(ctx context.Context, name string) (queryMethod, error) {
+	service, method, err := splitQueryMethod(name)
+	if err != nil {
+		return queryMethod{}, err
+	}
+
+	// the request and response types of the queries of the Cosmos SDK are named after their
+	// service and method, e.g. QueryBalanceRequest for the Balance method of Query.
+	var (
+		i            = strings.LastIndex(service, ".")
+		typesPrefix  = fmt.Sprintf("%s.%s%s", service[:i], service[i+1:], method)
+		requestType  = proto.MessageType(typesPrefix + "Request")
+		responseType = proto.MessageType(typesPrefix + "Response")
+	)
+
+	if requestType != nil && responseType != nil {
+		return queryMethod{
+			name:           name,
+			encodeRequest:  c.protoEncoder(requestType),
+			decodeResponse: c.protoDecoder(responseType),
+		}, nil
+	}
+
+	if c.grpcAddress == "" {
+		return queryMethod{}, fmt.Errorf("unknown query method %s, the gRPC address of the node is required to resolve it", name)
+	}
+
+	return reflectQueryMethod(ctx, c.grpcAddress, name, service, method)
+}
+
+func (c Client) protoEncoder(typ reflect.Type) func([]byte) ([]byte, error) {
+	return func(requestJSON []byte) ([]byte, error) {
+		request, ok := reflect.New(typ.Elem()).Interface().(codec.ProtoMarshaler)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a proto message", typ)
+		}
+
+		if err := c.context.Codec.UnmarshalJSON(requestJSON, request); err != nil {
+			return nil, errors.Wrap(err, "invalid request")
+		}
+
+		return c.context.Codec.Marshal(request)
+	}
+}
+
+func (c Client) protoDecoder(typ reflect.Type) func([]byte) ([]byte, error) {
+	return func(data []byte) ([]byte, error) {
+		response, ok := reflect.New(typ.Elem()).Interface().(codec.ProtoMarshaler)
+		if !ok {
+			return nil, fmt.Errorf("%s is not a proto message", typ)
+		}
+
+		if err := c.context.Codec.Unmarshal(data, response); err != nil {
+			return nil, err
+		}
+
+		return c.context.Codec.MarshalJSON(response)
+	}
+}
+
+// queryJSON invokes the query method m with requestJSON and returns its response as JSON.
+func (c Client) queryJSON(ctx context.Context, m queryMethod, requestJSON []byte, options ...QueryOption) ([]byte, error) {
+	var o queryOptions
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	if len(bytes.TrimSpace(requestJSON)) == 0 {
+		requestJSON = []byte("{}")
+	}
+
+	if !o.allPages {
+		responseJSON, _, err := c.invokeQuery(ctx, m, requestJSON, o.height)
+		return responseJSON, err
+	}
+
+	var request map[string]json.RawMessage
+	if err := json.Unmarshal(requestJSON, &request); err != nil {
+		return nil, errors.Wrap(err, "invalid request")
+	}
+
+	var (
+		pageRequest = make(map[string]json.RawMessage)
+		merged      map[string]json.RawMessage
+		height      = o.height
+	)
+
+	if err := unmarshalIfSet(request["pagination"], &pageRequest); err != nil {
+		return nil, errors.Wrap(err, "invalid pagination")
+	}
+
+	for {
+		responseJSON, responseHeight, err := c.invokeQuery(ctx, m, requestJSON, height)
+		if err != nil {
+			return nil, err
+		}
+
+		// the next pages are queried at the height of the first one.
+		height = responseHeight
+
+		var page map[string]json.RawMessage
+		if err := json.Unmarshal(responseJSON, &page); err != nil {
+			return nil, err
+		}
+
+		if merged == nil {
+			merged = page
+		} else if err := mergeListFields(merged, page); err != nil {
+			return nil, err
+		}
+
+		var pageResponse struct {
+			NextKey []byte `json:"next_key"`
+		}
+		if err := unmarshalIfSet(page["pagination"], &pageResponse); err != nil {
+			return nil, err
+		}
+		if len(pageResponse.NextKey) == 0 {
+			merged["pagination"] = page["pagination"]
+			break
+		}
+
+		// the next page starts from the key returned by the previous page, an offset can't be set with a key.
+		delete(pageRequest, "offset")
+		if pageRequest["key"], err = json.Marshal(pageResponse.NextKey); err != nil {
+			return nil, err
+		}
+		if request["pagination"], err = json.Marshal(pageRequest); err != nil {
+			return nil, err
+		}
+		if requestJSON, err = json.Marshal(request); err != nil {
+			return nil, err
+		}
+	}
+
+	return json.Marshal(merged)
+}
+
+// invokeQuery invokes the query method m with requestJSON at height, and returns its response as
+// JSON with the height of the queried state.
+func (c Client) invokeQuery(ctx context.Context, m queryMethod, requestJSON []byte, height int64) ([]byte, int64, error) {
+	request, err := m.encodeRequest(requestJSON)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, err := c.RPC.ABCIQueryWithOptions(ctx, m.name, request, rpcclient.ABCIQueryOptions{Height: height})
+	if err != nil {
+		return nil, 0, err
+	}
+	if !res.Response.IsOK() {
+		return nil, 0, sdkerrors.ABCIError(res.Response.Codespace, res.Response.Code, res.Response.Log)
+	}
+
+	responseJSON, err := m.decodeResponse(res.Response.Value)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "cannot decode the response")
+	}
+
+	return responseJSON, res.Response.Height, nil
+}
+
+// mergeListFields appends the lists of page to the lists of merged.
+func mergeListFields(merged, page map[string]json.RawMessage) error {
+	for field, value := range page {
+		if !isJSONArray(value) {
+			continue
+		}
+
+		var list []json.RawMessage
+		if err := json.Unmarshal(value, &list); err != nil {
+			return err
+		}
+
+		var mergedList []json.RawMessage
+		if err := unmarshalIfSet(merged[field], &mergedList); err != nil {
+			return err
+		}
+
+		data, err := json.Marshal(append(mergedList, list...))
+		if err != nil {
+			return err
+		}
+		merged[field] = data
+	}
+
+	return nil
+}
+
+func isJSONArray(data json.RawMessage) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("["))
+}
+
+// unmarshalIfSet decodes data into v unless data is empty or null.
+func unmarshalIfSet(data json.RawMessage, v interface{}) error {
+	if len(data) == 0 || string(data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// normalizeQueryMethod prefixes the name of a query method with a slash, e.g.
+// cosmos.bank.v1beta1.Query/Balance becomes /cosmos.bank.v1beta1.Query/Balance.
+func normalizeQueryMethod(name string) string {
+	return "/" + strings.TrimPrefix(name, "/")
+}
+
+// splitQueryMethod returns the fully qualified service and the method of a query method name.
+func splitQueryMethod(name string) (service, method string, err error) {
+	parts := strings.Split(strings.TrimPrefix(name, "/"), "/")
+	if len(parts) != 2 || parts[1] == "" || !strings.Contains(parts[0], ".") {
+		return "", "", fmt.Errorf("invalid query method %q, expected <package>.<service>/<method>", name)
+	}
+	return parts[0], parts[1], nil
+}
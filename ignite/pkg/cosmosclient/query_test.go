@@ -0,0 +1,138 @@
+package cosmosclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// newBalancesNode starts a Tendermint RPC server that answers the AllBalances queries with one
+// balance per page, it returns the heights of the queries.
+func newBalancesNode(t *testing.T, balances sdktypes.Coins) (address string, heights chan int64) {
+	heights = make(chan int64, len(balances))
+
+	routes := map[string]*rpcserver.RPCFunc{
+		"abci_query": rpcserver.NewRPCFunc(func(_ *rpctypes.Context, path string, data bytes.HexBytes, height int64, _ bool) (*ctypes.ResultABCIQuery, error) {
+			require.Equal(t, "/cosmos.bank.v1beta1.Query/AllBalances", path)
+			heights <- height
+
+			var req banktypes.QueryAllBalancesRequest
+			require.NoError(t, req.Unmarshal(data))
+
+			// the key of a page is the index of its balance.
+			i := 0
+			if req.Pagination != nil && len(req.Pagination.Key) > 0 {
+				i = int(req.Pagination.Key[0])
+			}
+
+			res := banktypes.QueryAllBalancesResponse{
+				Balances:   sdktypes.NewCoins(balances[i]),
+				Pagination: &query.PageResponse{Total: uint64(len(balances))},
+			}
+			if i+1 < len(balances) {
+				res.Pagination.NextKey = []byte{byte(i + 1)}
+			}
+
+			value, err := res.Marshal()
+			require.NoError(t, err)
+
+			return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: value, Height: 5}}, nil
+		}, "path,data,height,prove"),
+	}
+
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, routes, log.NewNopLogger())
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server.URL, heights
+}
+
+func TestQuery(t *testing.T) {
+	var (
+		balances      = sdktypes.NewCoins(sdktypes.NewInt64Coin("stake", 1), sdktypes.NewInt64Coin("token", 2))
+		addr, heights = newBalancesNode(t, balances)
+		c             = newTestClient(t, addr)
+		req           = &banktypes.QueryAllBalancesRequest{Address: sdktypes.AccAddress("alice").String()}
+	)
+
+	var res banktypes.QueryAllBalancesResponse
+	err := c.Query(context.Background(), "cosmos.bank.v1beta1.Query/AllBalances", req, &res, QueryHeight(3))
+	require.NoError(t, err)
+	require.Equal(t, sdktypes.NewCoins(balances[0]), res.Balances)
+	require.Equal(t, []byte{1}, res.Pagination.NextKey)
+	require.Equal(t, int64(3), <-heights)
+}
+
+func TestQueryJSONAllPages(t *testing.T) {
+	var (
+		balances      = sdktypes.NewCoins(sdktypes.NewInt64Coin("stake", 1), sdktypes.NewInt64Coin("token", 2))
+		addr, heights = newBalancesNode(t, balances)
+		c             = newTestClient(t, addr)
+		reqJSON       = []byte(`{"address":"` + sdktypes.AccAddress("alice").String() + `"}`)
+	)
+
+	resJSON, err := c.QueryJSON(context.Background(), "/cosmos.bank.v1beta1.Query/AllBalances", reqJSON, QueryAllPages())
+	require.NoError(t, err)
+
+	var res banktypes.QueryAllBalancesResponse
+	require.NoError(t, c.context.Codec.UnmarshalJSON(resJSON, &res))
+	require.Equal(t, balances, res.Balances)
+	require.Empty(t, res.Pagination.NextKey)
+	require.Equal(t, uint64(2), res.Pagination.Total)
+
+	// the second page is queried at the height of the first one.
+	require.Equal(t, int64(0), <-heights)
+	require.Equal(t, int64(5), <-heights)
+}
+
+func TestQueryJSONUnknownMethod(t *testing.T) {
+	c := newTestClient(t, "http://localhost:26657")
+
+	_, err := c.QueryJSON(context.Background(), "/foo.bar.Query/Baz", nil)
+	require.EqualError(t, err, "unknown query method /foo.bar.Query/Baz, the gRPC address of the node is required to resolve it")
+
+	_, err = c.QueryJSON(context.Background(), "Balance", nil)
+	require.Error(t, err)
+}
+
+func TestMergeListFields(t *testing.T) {
+	merged := map[string]json.RawMessage{
+		"balances":   json.RawMessage(`[{"denom":"stake"}]`),
+		"pagination": json.RawMessage(`{"next_key":"AQ=="}`),
+	}
+	page := map[string]json.RawMessage{
+		"balances":   json.RawMessage(`[{"denom":"token"}]`),
+		"pagination": json.RawMessage(`{"next_key":null}`),
+	}
+
+	require.NoError(t, mergeListFields(merged, page))
+	require.JSONEq(t, `[{"denom":"stake"},{"denom":"token"}]`, string(merged["balances"]))
+	require.JSONEq(t, `{"next_key":"AQ=="}`, string(merged["pagination"]))
+}
+
+func TestSplitQueryMethod(t *testing.T) {
+	service, method, err := splitQueryMethod(normalizeQueryMethod("cosmos.bank.v1beta1.Query/Balance"))
+	require.NoError(t, err)
+	require.Equal(t, "cosmos.bank.v1beta1.Query", service)
+	require.Equal(t, "Balance", method)
+
+	for _, name := range []string{"Query/Balance", "/cosmos.bank.v1beta1.Query/", "/cosmos.bank.v1beta1.Query"} {
+		_, _, err := splitQueryMethod(name)
+		require.Error(t, err, name)
+	}
+}
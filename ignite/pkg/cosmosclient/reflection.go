@@ -0,0 +1,164 @@
+package cosmosclient
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// reflectQueryMethod resolves the query method name of service from the gRPC reflection service
+// of the node at grpcAddress. The requests and responses are encoded with dynamic messages built
+// from the proto files of the node.
+func reflectQueryMethod(ctx context.Context, grpcAddress, name, service, method string) (queryMethod, error) {
+	files, err := fetchProtoFiles(ctx, grpcAddress, service)
+	if err != nil {
+		return queryMethod{}, errors.Wrapf(err, "cannot resolve query method %s with the reflection service", name)
+	}
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return queryMethod{}, errors.Wrapf(err, "unknown query service %s", service)
+	}
+
+	serviceDesc, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return queryMethod{}, fmt.Errorf("%s is not a service", service)
+	}
+
+	methodDesc := serviceDesc.Methods().ByName(protoreflect.Name(method))
+	if methodDesc == nil {
+		return queryMethod{}, fmt.Errorf("unknown query method %s", name)
+	}
+
+	// the types of the proto files resolve the types of the Any fields.
+	types := messageTypes(files)
+
+	return queryMethod{
+		name: name,
+		encodeRequest: func(requestJSON []byte) ([]byte, error) {
+			request := dynamicpb.NewMessage(methodDesc.Input())
+			if err := (protojson.UnmarshalOptions{Resolver: types}).Unmarshal(requestJSON, request); err != nil {
+				return nil, errors.Wrap(err, "invalid request")
+			}
+			return proto.Marshal(request)
+		},
+		decodeResponse: func(data []byte) ([]byte, error) {
+			response := dynamicpb.NewMessage(methodDesc.Output())
+			if err := (proto.UnmarshalOptions{Resolver: types}).Unmarshal(data, response); err != nil {
+				return nil, err
+			}
+			// the JSON format is the same as the one of the Cosmos SDK.
+			return protojson.MarshalOptions{
+				Resolver:        types,
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			}.Marshal(response)
+		},
+	}, nil
+}
+
+// fetchProtoFiles fetches the proto file that declares symbol and all its dependencies
+// from the gRPC reflection service of the node at grpcAddress.
+func fetchProtoFiles(ctx context.Context, grpcAddress, symbol string) (*protoregistry.Files, error) {
+	conn, err := grpc.DialContext(ctx, grpcAddress, grpc.WithInsecure())
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stream.CloseSend()
+
+	var (
+		fetched = make(map[string]*descriptorpb.FileDescriptorProto)
+		request = &rpb.ServerReflectionRequest{
+			MessageRequest: &rpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: symbol},
+		}
+	)
+
+	for request != nil {
+		if err := stream.Send(request); err != nil {
+			return nil, err
+		}
+
+		res, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if errRes := res.GetErrorResponse(); errRes != nil {
+			// the dependencies that the node doesn't know, e.g. the files of the proto options, are optional.
+			if _, ok := request.MessageRequest.(*rpb.ServerReflectionRequest_FileByFilename); ok {
+				fetched[request.GetFileByFilename()] = nil
+			} else {
+				return nil, errors.New(errRes.ErrorMessage)
+			}
+		}
+
+		for _, data := range res.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			var file descriptorpb.FileDescriptorProto
+			if err := proto.Unmarshal(data, &file); err != nil {
+				return nil, err
+			}
+			fetched[file.GetName()] = &file
+		}
+
+		// fetch the next missing dependency.
+		request = nil
+		for _, file := range fetched {
+			for _, dep := range file.GetDependency() {
+				if _, ok := fetched[dep]; !ok {
+					request = &rpb.ServerReflectionRequest{
+						MessageRequest: &rpb.ServerReflectionRequest_FileByFilename{FileByFilename: dep},
+					}
+					break
+				}
+			}
+			if request != nil {
+				break
+			}
+		}
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	for _, file := range fetched {
+		if file != nil {
+			set.File = append(set.File, file)
+		}
+	}
+
+	return protodesc.FileOptions{AllowUnresolvable: true}.NewFiles(&set)
+}
+
+// messageTypes returns the types of the messages declared in files.
+func messageTypes(files *protoregistry.Files) *protoregistry.Types {
+	types := &protoregistry.Types{}
+
+	var register func(protoreflect.MessageDescriptors)
+	register = func(messages protoreflect.MessageDescriptors) {
+		for i := 0; i < messages.Len(); i++ {
+			// the registration only fails for the duplicated types, which are ignored.
+			_ = types.RegisterMessage(dynamicpb.NewMessageType(messages.Get(i)))
+			register(messages.Get(i).Messages())
+		}
+	}
+
+	files.RangeFiles(func(file protoreflect.FileDescriptor) bool {
+		register(file.Messages())
+		return true
+	})
+
+	return types
+}
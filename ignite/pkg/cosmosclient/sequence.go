@@ -0,0 +1,285 @@
+package cosmosclient
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxSequenceRetries is the max. number of times a tx is signed again with the sequence
+	// synced from the chain after the tx is rejected for a wrong sequence.
+	maxSequenceRetries = 3
+
+	// gasMargin is added to the simulated gas because the gas of the actual tx can vary.
+	gasMargin = 10000
+)
+
+var (
+	// TxInclusionTimeout is the max. duration to wait for a broadcasted tx to be included in a block.
+	TxInclusionTimeout = time.Minute
+
+	// txPollInterval is the interval at which the node is queried for a broadcasted tx.
+	txPollInterval = 500 * time.Millisecond
+)
+
+// sequenceManager hands out the sequences of the accounts locally, so the txs of an account
+// can be broadcasted without waiting for its previous txs to be included in a block.
+type sequenceManager struct {
+	mu       sync.Mutex
+	accounts map[string]*accountSequence
+}
+
+func newSequenceManager() *sequenceManager {
+	return &sequenceManager{
+		accounts: make(map[string]*accountSequence),
+	}
+}
+
+// account returns the sequence of the account with address.
+func (m *sequenceManager) account(address sdktypes.AccAddress) *accountSequence {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := string(address)
+	if _, ok := m.accounts[key]; !ok {
+		m.accounts[key] = &accountSequence{}
+	}
+
+	return m.accounts[key]
+}
+
+// accountSequence is the account number and the next sequence of an account. the sequence is locked
+// while a tx of the account is signed and broadcasted, so the txs enter the mempool in the order of
+// their sequences.
+type accountSequence struct {
+	mu     sync.Mutex
+	synced bool
+	number uint64
+	next   uint64
+}
+
+// release unlocks the sequence, the sequence is incremented when it is used by a tx accepted in the mempool.
+func (s *accountSequence) release(used bool) {
+	if used {
+		s.next++
+	}
+	s.mu.Unlock()
+}
+
+// lockSequence locks the sequence of the account with address, the sequence is synced from
+// the chain first when it's not handed out locally yet or a tx is rejected for a wrong sequence.
+func (c Client) lockSequence(ctx context.Context, address sdktypes.AccAddress) (*accountSequence, error) {
+	s := c.sequences.account(address)
+	s.mu.Lock()
+
+	if !s.synced {
+		number, sequence, err := c.accountNumberSequence(ctx, address)
+		if err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+
+		s.number, s.next, s.synced = number, sequence, true
+	}
+
+	return s, nil
+}
+
+// accountNumberSequence queries the account number and sequence of the account with address.
+// the address is encoded with the prefix of the client, the global sdk config isn't used.
+func (c Client) accountNumberSequence(ctx context.Context, address sdktypes.AccAddress) (number, sequence uint64, err error) {
+	bech32Address, err := bech32.ConvertAndEncode(c.addressPrefix, address)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	res, err := authtypes.NewQueryClient(c.context).Account(ctx, &authtypes.QueryAccountRequest{
+		Address: bech32Address,
+	})
+	if err != nil {
+		return 0, 0, errors.Wrapf(err, "cannot query account %s", bech32Address)
+	}
+
+	var account authtypes.AccountI
+	if err := c.context.InterfaceRegistry.UnpackAny(res.Account, &account); err != nil {
+		return 0, 0, err
+	}
+
+	return account.GetAccountNumber(), account.GetSequence(), nil
+}
+
+// simulateGas simulates a tx built by txf with msgs for the account of ctx and returns
+// the gas it needs, multiplied by the gas adjustment of txf.
+func (c Client) simulateGas(ctx client.Context, txf tx.Factory, msgs []sdktypes.Msg) (uint64, error) {
+	for attempt := 0; ; attempt++ {
+		s, err := c.lockSequence(context.Background(), ctx.GetFromAddress())
+		if err != nil {
+			return 0, err
+		}
+		txf := txf.
+			WithAccountNumber(s.number).
+			WithSequence(s.next)
+		s.release(false)
+
+		_, gas, err := tx.CalculateGas(ctx, txf, msgs...)
+		if isWrongSequence(err) && attempt < maxSequenceRetries {
+			c.resyncSequence(ctx.GetFromAddress())
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+
+		// the simulated gas can vary from the actual gas needed for a real transaction
+		// we add an additional amount to endure sufficient gas is provided
+		return gas + gasMargin, nil
+	}
+}
+
+// submitTx signs a tx built by txf with msgs for the account of ctx with the next sequence of the
+// account, and broadcasts it until it is accepted in the mempool. The tx is signed again with the
+// sequence synced from the chain when it's rejected for a wrong sequence, and with the fees required
+// by the node when it's rejected for insufficient fees while the gas prices are discovered.
+func (c Client) submitTx(ctx client.Context, txf tx.Factory, msgs []sdktypes.Msg) (*sdktypes.TxResponse, error) {
+	var feesDiscovered, funded bool
+
+	for attempt := 0; ; attempt++ {
+		s, err := c.lockSequence(context.Background(), ctx.GetFromAddress())
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := c.signAndBroadcast(ctx, txf.WithAccountNumber(s.number).WithSequence(s.next), msgs)
+
+		// the sequence is synced again when the tx may have been accepted or not.
+		wrongSequence := isWrongSequence(err) || (resp != nil && isWrongSequenceCode(resp.Codespace, resp.Code))
+		if err != nil || wrongSequence {
+			s.synced = false
+		}
+		s.release(err == nil && resp.Code == 0)
+
+		if wrongSequence && attempt < maxSequenceRetries {
+			continue
+		}
+
+		if err == nil && !feesDiscovered {
+			if prices, ok := c.discoverGasPrices(resp, txf.Gas()); ok {
+				txf = txf.WithGasPrices(prices.String())
+				feesDiscovered = true
+				continue
+			}
+		}
+
+		if err == nil && c.useFaucet && resp.Codespace == sdkerrors.ErrInsufficientFunds.Codespace() &&
+			resp.Code == sdkerrors.ErrInsufficientFunds.ABCICode() && !funded {
+			address, err := bech32.ConvertAndEncode(c.addressPrefix, ctx.GetFromAddress())
+			if err != nil {
+				return nil, err
+			}
+			if err := c.makeSureAccountHasTokens(context.Background(), address); err != nil {
+				return nil, err
+			}
+			funded = true
+			continue
+		}
+
+		return resp, handleBroadcastResult(resp, err)
+	}
+}
+
+// signAndBroadcast signs a tx with msgs for the account of ctx and broadcasts it synchronously,
+// i.e. it returns once the tx is checked by the mempool.
+func (c Client) signAndBroadcast(ctx client.Context, txf tx.Factory, msgs []sdktypes.Msg) (*sdktypes.TxResponse, error) {
+	txBytes, err := c.signTx(ctx, txf, msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.WithBroadcastMode(flags.BroadcastSync).BroadcastTx(txBytes)
+}
+
+// protects sdktypes.Config.
+var mconf sync.Mutex
+
+// signTx builds a tx with msgs for the account of ctx, signs and encodes it.
+// The signers of msgs are decoded with the account prefix of the sdk config, so it's set to the
+// address prefix of the client while the tx is signed.
+func (c Client) signTx(ctx client.Context, txf tx.Factory, msgs []sdktypes.Msg) ([]byte, error) {
+	mconf.Lock()
+	defer mconf.Unlock()
+
+	restore := setAccountPrefix(c.addressPrefix)
+	defer restore()
+
+	txUnsigned, err := tx.BuildUnsignedTx(txf, msgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	txUnsigned.SetFeeGranter(ctx.GetFeeGranterAddress())
+	if err := tx.Sign(txf, ctx.GetFromName(), txUnsigned, true); err != nil {
+		return nil, err
+	}
+
+	return ctx.TxConfig.TxEncoder()(txUnsigned.GetTx())
+}
+
+// setAccountPrefix sets the account prefix of the sdk config to prefix and returns a func
+// that sets the previous one back. The config is left untouched when it already uses prefix,
+// as it can't be changed once sealed.
+func setAccountPrefix(prefix string) (restore func()) {
+	config := sdktypes.GetConfig()
+	accountPrefix, pubPrefix := config.GetBech32AccountAddrPrefix(), config.GetBech32AccountPubPrefix()
+	if prefix == accountPrefix {
+		return func() {}
+	}
+
+	config.SetBech32PrefixForAccount(prefix, prefix+"pub")
+	return func() {
+		config.SetBech32PrefixForAccount(accountPrefix, pubPrefix)
+	}
+}
+
+// resyncSequence syncs the sequence of the account with address from the chain before its next tx.
+func (c Client) resyncSequence(address sdktypes.AccAddress) {
+	s := c.sequences.account(address)
+	s.mu.Lock()
+	s.synced = false
+	s.mu.Unlock()
+}
+
+// awaitTx waits for the tx accepted in the mempool with resp to be included in a block
+// and returns its result. The node is polled for the tx instead of subscribing to it like
+// WaitForTx, as each subscription opens a websocket connection that the node limits the number of.
+func (c Client) awaitTx(ctx context.Context, resp *sdktypes.TxResponse) (Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, TxInclusionTimeout)
+	defer cancel()
+
+	res, err := c.pollTx(ctx, resp.TxHash)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return Response{}, errors.Wrapf(err, "tx %s is not included in a block", resp.TxHash)
+	}
+
+	return res, err
+}
+
+// isWrongSequence checks if err is caused by a wrong account sequence, e.g. an error of a simulation.
+func isWrongSequence(err error) bool {
+	return err != nil && strings.Contains(err.Error(), sdkerrors.ErrWrongSequence.Error())
+}
+
+// isWrongSequenceCode checks if the code of a tx response is the one of a wrong account sequence.
+func isWrongSequenceCode(codespace string, code uint32) bool {
+	return codespace == sdkerrors.ErrWrongSequence.Codespace() && code == sdkerrors.ErrWrongSequence.ABCICode()
+}
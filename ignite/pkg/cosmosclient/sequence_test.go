@@ -0,0 +1,96 @@
+package cosmosclient
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+
+	cosmostestutilnode "github.com/ignite/cli/ignite/pkg/cosmostestutil/node"
+)
+
+func TestSequenceManager(t *testing.T) {
+	var (
+		m     = newSequenceManager()
+		alice = sdktypes.AccAddress("alice")
+		bob   = sdktypes.AccAddress("bob")
+	)
+
+	s := m.account(alice)
+	require.Same(t, s, m.account(alice))
+	require.NotSame(t, s, m.account(bob))
+
+	s.mu.Lock()
+	s.next = 5
+	s.release(true)
+	require.Equal(t, uint64(6), s.next)
+
+	s.mu.Lock()
+	s.release(false)
+	require.Equal(t, uint64(6), s.next)
+}
+
+func TestIsWrongSequence(t *testing.T) {
+	simulationErr := fmt.Errorf("rpc error: code = Unknown desc = account sequence mismatch, expected 6, got 5: %s", sdkerrors.ErrWrongSequence)
+
+	require.True(t, isWrongSequence(simulationErr))
+	require.True(t, isWrongSequence(sdkerrors.Wrap(sdkerrors.ErrWrongSequence, "expected 6, got 5")))
+	require.False(t, isWrongSequence(errors.New("insufficient funds")))
+	require.False(t, isWrongSequence(nil))
+
+	require.True(t, isWrongSequenceCode(sdkerrors.RootCodespace, sdkerrors.ErrWrongSequence.ABCICode()))
+	require.False(t, isWrongSequenceCode(sdkerrors.RootCodespace, sdkerrors.ErrInsufficientFunds.ABCICode()))
+	require.False(t, isWrongSequenceCode("bank", sdkerrors.ErrWrongSequence.ABCICode()))
+}
+
+func TestBroadcastTxWithAddressPrefix(t *testing.T) {
+	var (
+		node = cosmostestutilnode.New(t)
+		c    = newTestClient(t, node.URL, WithAddressPrefix("mars"), WithGas(200000))
+	)
+
+	for _, name := range []string{"alice", "bob"} {
+		_, _, err := c.AccountRegistry.Create(name)
+		require.NoError(t, err)
+	}
+
+	alice, err := c.Account("alice")
+	require.NoError(t, err)
+	bob, err := c.Account("bob")
+	require.NoError(t, err)
+
+	msg := &banktypes.MsgSend{
+		FromAddress: alice.Address("mars"),
+		ToAddress:   bob.Address("mars"),
+		Amount:      sdktypes.NewCoins(sdktypes.NewInt64Coin("token", 5)),
+	}
+
+	_, err = c.BroadcastTx("alice", msg)
+	require.NoError(t, err)
+
+	broadcasted := node.Broadcasted()
+	require.Len(t, broadcasted, 1)
+
+	signed, err := c.context.TxConfig.TxDecoder()(broadcasted[0])
+	require.NoError(t, err)
+
+	sigTx := signed.(authsigning.SigVerifiableTx)
+	sigs, err := sigTx.GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+
+	err = authsigning.VerifySignature(sigs[0].PubKey, authsigning.SignerData{
+		ChainID:       "test",
+		AccountNumber: 1,
+		Sequence:      0,
+	}, sigs[0].Data, c.context.TxConfig.SignModeHandler(), sigTx)
+	require.NoError(t, err)
+
+	// the prefix of the sdk config is set back once the tx is signed.
+	require.Equal(t, sdktypes.Bech32MainPrefix, sdktypes.GetConfig().GetBech32AccountAddrPrefix())
+}
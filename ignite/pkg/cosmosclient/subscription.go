@@ -0,0 +1,676 @@
+package cosmosclient
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	tmpubsub "github.com/tendermint/tendermint/libs/pubsub"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	jsonrpcclient "github.com/tendermint/tendermint/rpc/jsonrpc/client"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	// txSearchPerPage is the number of txs queried per page to resume a subscription.
+	txSearchPerPage = 100
+
+	// resubscribeDelay is the delay before subscribing again when the node cancels a subscription.
+	resubscribeDelay = time.Second
+)
+
+var errConnectionLost = errors.New("connection to the node is lost")
+
+// subscription is the state shared by the subscriptions.
+type subscription struct {
+	mu  sync.Mutex
+	err error
+}
+
+// Err returns the error that ended the subscription. It is nil while the subscription
+// is running and when it's ended by the cancellation of its context.
+func (s *subscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *subscription) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// Subscription is a subscription to the events of the blocks and txs that match a query.
+type Subscription struct {
+	*subscription
+	events chan Event
+}
+
+// Events returns the events of the subscription, it is closed when the subscription ends.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// BlockSubscription is a subscription to the new blocks.
+type BlockSubscription struct {
+	*subscription
+	blocks chan Block
+}
+
+// Blocks returns the new blocks, it is closed when the subscription ends.
+func (s *BlockSubscription) Blocks() <-chan Block {
+	return s.blocks
+}
+
+// TxSubscription is a subscription to the results of the txs that match a query.
+type TxSubscription struct {
+	*subscription
+	txs chan TxResult
+}
+
+// Txs returns the results of the txs, it is closed when the subscription ends.
+func (s *TxSubscription) Txs() <-chan TxResult {
+	return s.txs
+}
+
+// Subscribe subscribes to the events of the blocks and txs that match query over the websocket of
+// the node, e.g. "tm.event='Tx' AND message.sender='cosmos1...'". The events of a block or a tx are
+// all sent when it matches query. The subscription ends when ctx is canceled.
+//
+// The connection to the node is reestablished when it's lost. For queries on txs or new blocks, the
+// events emitted while the connection was lost are sent first, resuming from the last height.
+func (c Client) Subscribe(ctx context.Context, query string) (*Subscription, error) {
+	sub, results, err := c.subscribe(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Subscription{
+		subscription: sub,
+		events:       make(chan Event),
+	}
+
+	go func() {
+		defer close(s.events)
+
+		for result := range results {
+			for _, event := range c.resultEvents(result.Data) {
+				select {
+				case s.events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+// SubscribeBlocks subscribes to the new blocks, see Subscribe.
+func (c Client) SubscribeBlocks(ctx context.Context) (*BlockSubscription, error) {
+	sub, results, err := c.subscribe(ctx, tmtypes.EventQueryNewBlock.String())
+	if err != nil {
+		return nil, err
+	}
+
+	s := &BlockSubscription{
+		subscription: sub,
+		blocks:       make(chan Block),
+	}
+
+	go func() {
+		defer close(s.blocks)
+
+		for result := range results {
+			data, ok := result.Data.(tmtypes.EventDataNewBlock)
+			if !ok {
+				continue
+			}
+
+			select {
+			case s.blocks <- c.block(data):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+// SubscribeTxs subscribes to the results of the txs that match query, e.g. "message.action='send'",
+// all txs match an empty query. See Subscribe.
+func (c Client) SubscribeTxs(ctx context.Context, query string) (*TxSubscription, error) {
+	txQuery := tmtypes.EventQueryTx.String()
+	if query != "" {
+		txQuery = fmt.Sprintf("%s AND %s", txQuery, query)
+	}
+
+	sub, results, err := c.subscribe(ctx, txQuery)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &TxSubscription{
+		subscription: sub,
+		txs:          make(chan TxResult),
+	}
+
+	go func() {
+		defer close(s.txs)
+
+		for result := range results {
+			data, ok := result.Data.(tmtypes.EventDataTx)
+			if !ok {
+				continue
+			}
+
+			select {
+			case s.txs <- c.txResult(data.TxResult):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return s, nil
+}
+
+// WaitForTx waits for the tx with hash to be included in a block and returns its result, an error
+// is returned with the result when the tx failed. The node is polled for the tx when it doesn't
+// accept subscriptions. Each call opens a websocket connection to the node for its subscription.
+func (c Client) WaitForTx(ctx context.Context, hash string) (Response, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	hash = strings.ToUpper(hash)
+	if _, err := hex.DecodeString(hash); err != nil {
+		return Response{}, errors.Wrapf(err, "invalid tx hash %q", hash)
+	}
+
+	sub, err := c.SubscribeTxs(ctx, fmt.Sprintf("%s='%s'", tmtypes.TxHashKey, hash))
+	if err != nil {
+		return c.pollTx(ctx, hash)
+	}
+
+	// the tx may be included before the subscription.
+	if resp, err := c.queryTx(ctx, hash); err == nil {
+		return resp, handleBroadcastResult(resp.TxResponse, nil)
+	}
+
+	select {
+	case tx, ok := <-sub.Txs():
+		if !ok {
+			if err := sub.Err(); err != nil {
+				return Response{}, err
+			}
+			return Response{}, ctx.Err()
+		}
+
+		resp := c.txResponse(&ctypes.ResultTx{
+			Hash:     tmtypes.Tx(tx.Tx).Hash(),
+			Height:   tx.Height,
+			Index:    tx.Index,
+			TxResult: tx.Result,
+			Tx:       tx.Tx,
+		})
+
+		return resp, handleBroadcastResult(resp.TxResponse, nil)
+
+	case <-ctx.Done():
+		return Response{}, ctx.Err()
+	}
+}
+
+// pollTx polls the node until the tx with hash is included in a block.
+func (c Client) pollTx(ctx context.Context, hash string) (resp Response, err error) {
+	err = backoff.Retry(func() error {
+		resp, err = c.queryTx(ctx, hash)
+		return err
+	}, backoff.WithContext(backoff.NewConstantBackOff(txPollInterval), ctx))
+	if err != nil {
+		return Response{}, err
+	}
+
+	return resp, handleBroadcastResult(resp.TxResponse, nil)
+}
+
+func (c Client) queryTx(ctx context.Context, hash string) (Response, error) {
+	txHash, err := hex.DecodeString(hash)
+	if err != nil {
+		return Response{}, err
+	}
+
+	res, err := c.RPC.Tx(ctx, txHash, false)
+	if err != nil {
+		return Response{}, err
+	}
+
+	return c.txResponse(res), nil
+}
+
+func (c Client) txResponse(res *ctypes.ResultTx) Response {
+	return Response{
+		Codec:      c.context.Codec,
+		TxResponse: sdktypes.NewResponseResultTx(res, nil, ""),
+	}
+}
+
+// resultEvents returns the events of a block or a tx sent by a subscription.
+func (c Client) resultEvents(data tmtypes.TMEventData) []Event {
+	switch data := data.(type) {
+	case tmtypes.EventDataTx:
+		return c.txResult(data.TxResult).Events
+	case tmtypes.EventDataNewBlock:
+		return c.block(data).Events
+	case tmtypes.EventDataNewBlockHeader:
+		var events []abci.Event
+		events = append(events, data.ResultBeginBlock.Events...)
+		events = append(events, data.ResultEndBlock.Events...)
+		return c.events(data.Header.Height, "", events)
+	default:
+		return nil
+	}
+}
+
+// resumeMode is how the events missed by a subscription while its connection was lost are queried.
+type resumeMode int
+
+const (
+	// resumeNone is used when the missed events cannot be queried.
+	resumeNone resumeMode = iota
+
+	// resumeTxs queries the missed txs from the tx index of the node.
+	resumeTxs
+
+	// resumeBlocks queries the missed blocks and their results.
+	resumeBlocks
+)
+
+// eventStream streams the Tendermint events that match a query over a websocket connection.
+type eventStream struct {
+	c           Client
+	query       *tmquery.Query
+	mode        resumeMode
+	ws          *jsonrpcclient.WSClient
+	reconnected chan struct{}
+	out         chan ctypes.ResultEvent
+	sub         *subscription
+
+	// pending are the events received before the subscription is confirmed.
+	pending []ctypes.ResultEvent
+
+	// lastHeight is the height of the last block or tx sent.
+	lastHeight int64
+
+	// resumedHeight is the height up to which the missed events are sent after the subscription
+	// is resumed, the events received from the node up to this height are already sent.
+	resumedHeight int64
+}
+
+// subscribe subscribes to the Tendermint events that match query with a dedicated websocket connection.
+func (c Client) subscribe(ctx context.Context, query string) (*subscription, <-chan ctypes.ResultEvent, error) {
+	q, err := tmquery.New(query)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "invalid query %q", query)
+	}
+
+	mode, err := queryResumeMode(q)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s := &eventStream{
+		c:           c,
+		query:       q,
+		mode:        mode,
+		reconnected: make(chan struct{}, 1),
+		out:         make(chan ctypes.ResultEvent),
+		sub:         &subscription{},
+	}
+
+	s.ws, err = jsonrpcclient.NewWS(c.nodeAddress, "/websocket", jsonrpcclient.OnReconnect(func() {
+		select {
+		case s.reconnected <- struct{}{}:
+		default:
+		}
+	}))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.ws.Start(); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot connect to the websocket of the node")
+	}
+
+	if err := s.start(ctx); err != nil {
+		s.ws.Stop()
+		return nil, nil, err
+	}
+
+	go s.run(ctx)
+
+	return s.sub, s.out, nil
+}
+
+// start subscribes to the events and waits for the subscription to be confirmed. The height of the
+// node is the one the events are resumed from until an event is received.
+func (s *eventStream) start(ctx context.Context) error {
+	if err := s.ws.Subscribe(ctx, s.query.String()); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+
+		case resp, ok := <-s.ws.ResponsesCh:
+			if !ok {
+				return errConnectionLost
+			}
+			if resp.Error != nil {
+				return errors.Wrap(resp.Error, "cannot subscribe")
+			}
+
+			event, ok := decodeResultEvent(resp)
+			if ok {
+				s.pending = append(s.pending, event)
+				continue
+			}
+
+			if s.mode == resumeNone {
+				return nil
+			}
+
+			status, err := s.c.RPC.Status(ctx)
+			if err != nil {
+				return err
+			}
+			s.lastHeight = status.SyncInfo.LatestBlockHeight
+
+			return nil
+		}
+	}
+}
+
+func (s *eventStream) run(ctx context.Context) {
+	defer close(s.out)
+	defer s.ws.Stop()
+
+	for _, event := range s.pending {
+		if !s.send(ctx, event) {
+			return
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case <-s.reconnected:
+			if err := s.resubscribe(ctx); err != nil {
+				s.sub.fail(err)
+				return
+			}
+
+		case resp, ok := <-s.ws.ResponsesCh:
+			if !ok {
+				s.sub.fail(errConnectionLost)
+				return
+			}
+
+			if resp.Error != nil {
+				if strings.Contains(resp.Error.Error(), tmpubsub.ErrAlreadySubscribed.Error()) {
+					continue
+				}
+
+				// the subscription is canceled by the node, e.g. when it's stopping or when
+				// the events aren't received fast enough.
+				select {
+				case <-time.After(resubscribeDelay):
+				case <-ctx.Done():
+					return
+				}
+				if err := s.resubscribe(ctx); err != nil {
+					s.sub.fail(err)
+					return
+				}
+				continue
+			}
+
+			event, ok := decodeResultEvent(resp)
+			if !ok {
+				continue
+			}
+
+			if height := eventHeight(event.Data); height != 0 && height <= s.resumedHeight {
+				continue
+			}
+
+			if !s.send(ctx, event) {
+				return
+			}
+		}
+	}
+}
+
+func (s *eventStream) send(ctx context.Context, event ctypes.ResultEvent) bool {
+	select {
+	case s.out <- event:
+	case <-ctx.Done():
+		return false
+	}
+
+	if height := eventHeight(event.Data); height > s.lastHeight {
+		s.lastHeight = height
+	}
+
+	return true
+}
+
+// resubscribe subscribes again to the events and sends the events missed since the last height.
+func (s *eventStream) resubscribe(ctx context.Context) error {
+	if err := s.ws.Subscribe(ctx, s.query.String()); err != nil {
+		return err
+	}
+
+	if s.mode == resumeNone {
+		return nil
+	}
+
+	status, err := s.c.RPC.Status(ctx)
+	if err != nil {
+		return errors.Wrap(err, "cannot resume the subscription")
+	}
+	latest := status.SyncInfo.LatestBlockHeight
+
+	switch s.mode {
+	case resumeTxs:
+		err = s.resumeTxs(ctx, latest)
+	case resumeBlocks:
+		err = s.resumeBlocks(ctx, latest)
+	}
+	if err != nil {
+		return errors.Wrap(err, "cannot resume the subscription")
+	}
+
+	if latest > s.resumedHeight {
+		s.resumedHeight = latest
+	}
+
+	return nil
+}
+
+// resumeTxs sends the txs that match the query of the stream from the last height to latest.
+func (s *eventStream) resumeTxs(ctx context.Context, latest int64) error {
+	if latest <= s.lastHeight {
+		return nil
+	}
+
+	var (
+		query   = fmt.Sprintf("%s AND tx.height > %d AND tx.height <= %d", s.query, s.lastHeight, latest)
+		perPage = txSearchPerPage
+	)
+
+	for page := 1; ; page++ {
+		res, err := s.c.RPC.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		if err != nil {
+			return err
+		}
+
+		for _, tx := range res.Txs {
+			event := ctypes.ResultEvent{
+				Query: s.query.String(),
+				Data: tmtypes.EventDataTx{TxResult: abci.TxResult{
+					Height: tx.Height,
+					Index:  tx.Index,
+					Tx:     tx.Tx,
+					Result: tx.TxResult,
+				}},
+			}
+
+			if !s.send(ctx, event) {
+				return ctx.Err()
+			}
+		}
+
+		if page*perPage >= res.TotalCount {
+			return nil
+		}
+	}
+}
+
+// resumeBlocks sends the blocks that match the query of the stream from the last height to latest.
+func (s *eventStream) resumeBlocks(ctx context.Context, latest int64) error {
+	for height := s.lastHeight + 1; height <= latest; height++ {
+		h := height
+
+		block, err := s.c.RPC.Block(ctx, &h)
+		if err != nil {
+			return err
+		}
+
+		results, err := s.c.RPC.BlockResults(ctx, &h)
+		if err != nil {
+			return err
+		}
+
+		data := tmtypes.EventDataNewBlock{
+			Block:            block.Block,
+			ResultBeginBlock: abci.ResponseBeginBlock{Events: results.BeginBlockEvents},
+			ResultEndBlock: abci.ResponseEndBlock{
+				ValidatorUpdates:      results.ValidatorUpdates,
+				ConsensusParamUpdates: results.ConsensusParamUpdates,
+				Events:                results.EndBlockEvents,
+			},
+		}
+
+		events := compositeEvents(tmtypes.EventNewBlock, data.ResultBeginBlock.Events, data.ResultEndBlock.Events)
+		matches, err := s.query.Matches(events)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			continue
+		}
+
+		event := ctypes.ResultEvent{
+			Query:  s.query.String(),
+			Data:   data,
+			Events: events,
+		}
+
+		if !s.send(ctx, event) {
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// queryResumeMode returns how the events of query are resumed from its tm.event condition.
+func queryResumeMode(query *tmquery.Query) (resumeMode, error) {
+	conditions, err := query.Conditions()
+	if err != nil {
+		return resumeNone, err
+	}
+
+	for _, cond := range conditions {
+		if cond.CompositeKey != tmtypes.EventTypeKey || cond.Op != tmquery.OpEqual {
+			continue
+		}
+
+		switch cond.Operand {
+		case tmtypes.EventTx:
+			return resumeTxs, nil
+		case tmtypes.EventNewBlock:
+			return resumeBlocks, nil
+		}
+	}
+
+	return resumeNone, nil
+}
+
+// compositeEvents returns the events as composite keys, e.g. transfer.recipient, mapped
+// to their values, which is the format that queries are matched against.
+func compositeEvents(eventType string, abciEvents ...[]abci.Event) map[string][]string {
+	events := map[string][]string{
+		tmtypes.EventTypeKey: {eventType},
+	}
+
+	for _, list := range abciEvents {
+		for _, e := range list {
+			for _, attr := range e.Attributes {
+				if len(attr.Key) == 0 {
+					continue
+				}
+
+				key := fmt.Sprintf("%s.%s", e.Type, attr.Key)
+				events[key] = append(events[key], string(attr.Value))
+			}
+		}
+	}
+
+	return events
+}
+
+// decodeResultEvent decodes an event sent by the node, the responses to
+// subscribe requests aren't events.
+func decodeResultEvent(resp rpctypes.RPCResponse) (ctypes.ResultEvent, bool) {
+	var event ctypes.ResultEvent
+	if err := tmjson.Unmarshal(resp.Result, &event); err != nil || event.Data == nil {
+		return event, false
+	}
+
+	return event, true
+}
+
+// eventHeight returns the height of a block or a tx event, 0 is returned for other events.
+func eventHeight(data tmtypes.TMEventData) int64 {
+	switch data := data.(type) {
+	case tmtypes.EventDataTx:
+		return data.Height
+	case tmtypes.EventDataNewBlock:
+		if data.Block != nil {
+			return data.Block.Height
+		}
+	case tmtypes.EventDataNewBlockHeader:
+		return data.Header.Height
+	}
+
+	return 0
+}
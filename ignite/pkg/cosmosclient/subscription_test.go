@@ -0,0 +1,139 @@
+package cosmosclient
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	cosmostestutilnode "github.com/ignite/cli/ignite/pkg/cosmostestutil/node"
+)
+
+func newTestClient(t *testing.T, nodeAddress string, options ...Option) Client {
+	options = append([]Option{
+		WithNodeAddress(nodeAddress),
+		WithChainID("test"),
+		WithKeyringBackend(cosmosaccount.KeyringMemory),
+		WithHome(t.TempDir()),
+	}, options...)
+
+	c, err := New(context.Background(), options...)
+	require.NoError(t, err)
+	return c
+}
+
+func TestSubscribeTxs(t *testing.T) {
+	var (
+		node = cosmostestutilnode.New(t)
+		c    = newTestClient(t, node.URL)
+		msg  = banktypes.NewMsgSend(
+			sdktypes.AccAddress("alice"),
+			sdktypes.AccAddress("bob"),
+			sdktypes.NewCoins(sdktypes.NewInt64Coin("token", 10)),
+		)
+	)
+
+	typedEvent, err := sdktypes.TypedEventToEvent(msg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := c.SubscribeTxs(ctx, "message.action='send'")
+	require.NoError(t, err)
+
+	tx := tmtypes.Tx("tx")
+	node.Publish(t, <-node.Subscriptions, tmtypes.EventDataTx{TxResult: abci.TxResult{
+		Height: 2,
+		Tx:     tx,
+		Result: abci.ResponseDeliverTx{Events: []abci.Event{
+			{Type: "message", Attributes: []abci.EventAttribute{{Key: []byte("action"), Value: []byte("send")}}},
+			abci.Event(typedEvent),
+		}},
+	}})
+
+	result := <-sub.Txs()
+	require.Equal(t, int64(2), result.Height)
+	require.Equal(t, tx.Hash(), tmtypes.Tx(result.Tx).Hash())
+	require.Len(t, result.Events, 2)
+	require.Equal(t, "message", result.Events[0].ABCI.Type)
+	require.Nil(t, result.Events[0].Typed)
+	require.Equal(t, msg, result.Events[1].Typed)
+	require.Equal(t, result.Hash, result.Events[1].TxHash)
+
+	cancel()
+	_, ok := <-sub.Txs()
+	require.False(t, ok)
+	require.NoError(t, sub.Err())
+}
+
+func TestWaitForTx(t *testing.T) {
+	var (
+		node = cosmostestutilnode.New(t)
+		c    = newTestClient(t, node.URL)
+		tx   = tmtypes.Tx("tx")
+		hash = fmt.Sprintf("%X", tx.Hash())
+	)
+
+	go func() {
+		node.Publish(t, <-node.Subscriptions, tmtypes.EventDataTx{TxResult: abci.TxResult{
+			Height: 3,
+			Tx:     tx,
+			Result: abci.ResponseDeliverTx{Code: 0},
+		}})
+	}()
+
+	resp, err := c.WaitForTx(context.Background(), hash)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), resp.Height)
+	require.Equal(t, hash, resp.TxHash)
+}
+
+func TestAwaitTx(t *testing.T) {
+	var (
+		node = cosmostestutilnode.New(t)
+		c    = newTestClient(t, node.URL)
+		tx   = tmtypes.Tx("tx")
+		hash = fmt.Sprintf("%X", tx.Hash())
+	)
+
+	go func() {
+		time.Sleep(txPollInterval)
+		node.IncludeTx(&ctypes.ResultTx{Hash: tx.Hash(), Height: 4, Tx: tx})
+	}()
+
+	resp, err := c.awaitTx(context.Background(), &sdktypes.TxResponse{TxHash: hash})
+	require.NoError(t, err)
+	require.Equal(t, int64(4), resp.Height)
+
+	// the node is polled without subscribing to the tx.
+	require.Empty(t, node.Subscriptions)
+}
+
+func TestQueryResumeMode(t *testing.T) {
+	tests := []struct {
+		query string
+		mode  resumeMode
+	}{
+		{"tm.event='Tx' AND message.sender='cosmos1'", resumeTxs},
+		{"tm.event = 'NewBlock'", resumeBlocks},
+		{"message.sender='cosmos1'", resumeNone},
+		{"tm.event='NewBlockHeader'", resumeNone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			mode, err := queryResumeMode(tmquery.MustParse(tt.query))
+			require.NoError(t, err)
+			require.Equal(t, tt.mode, mode)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package cosmosconsole
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// Status is the status of the chain.
+type Status struct {
+	ChainID       string `json:"chain_id"`
+	Height        int64  `json:"height"`
+	AddressPrefix string `json:"address_prefix"`
+}
+
+// Block is a summary of a block.
+type Block struct {
+	Height   int64     `json:"height"`
+	Hash     string    `json:"hash"`
+	Time     time.Time `json:"time"`
+	Proposer string    `json:"proposer"`
+	NumTxs   int       `json:"num_txs"`
+
+	// Txs holds the hashes of the block's transactions.
+	// it is only set when a single block is requested.
+	Txs []string `json:"txs,omitempty"`
+}
+
+// Status returns the status of the chain.
+func (c Console) Status(ctx context.Context) (Status, error) {
+	status, err := c.client.RPC.Status(ctx)
+	if err != nil {
+		return Status{}, err
+	}
+
+	return Status{
+		ChainID:       status.NodeInfo.Network,
+		Height:        status.SyncInfo.LatestBlockHeight,
+		AddressPrefix: c.addressPrefix,
+	}, nil
+}
+
+// Blocks returns the most recent blocks, latest block comes first.
+func (c Console) Blocks(ctx context.Context, limit int) ([]Block, error) {
+	if limit <= 0 {
+		limit = DefaultBlocksLimit
+	}
+
+	status, err := c.client.RPC.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		blocks []Block
+		max    = status.SyncInfo.LatestBlockHeight
+	)
+
+	// Tendermint returns at most 20 blocks for each request.
+	for max > 0 && len(blocks) < limit {
+		res, err := c.client.RPC.BlockchainInfo(ctx, 1, max)
+		if err != nil {
+			return nil, err
+		}
+		if len(res.BlockMetas) == 0 {
+			break
+		}
+
+		for _, meta := range res.BlockMetas {
+			if len(blocks) == limit {
+				break
+			}
+			blocks = append(blocks, newBlock(meta))
+		}
+
+		max = res.BlockMetas[len(res.BlockMetas)-1].Header.Height - 1
+	}
+
+	return blocks, nil
+}
+
+// Block returns the block at height including the hashes of its transactions.
+func (c Console) Block(ctx context.Context, height int64) (Block, error) {
+	res, err := c.client.RPC.Block(ctx, &height)
+	if err != nil {
+		return Block{}, err
+	}
+
+	block := newBlock(&tmtypes.BlockMeta{
+		BlockID: res.BlockID,
+		Header:  res.Block.Header,
+		NumTxs:  len(res.Block.Txs),
+	})
+	block.Txs = make([]string, 0, len(res.Block.Txs))
+
+	for _, tx := range res.Block.Txs {
+		block.Txs = append(block.Txs, fmt.Sprintf("%X", tx.Hash()))
+	}
+
+	return block, nil
+}
+
+// newBlock creates a block summary from a Tendermint block meta.
+func newBlock(meta *tmtypes.BlockMeta) Block {
+	return Block{
+		Height:   meta.Header.Height,
+		Hash:     meta.BlockID.Hash.String(),
+		Time:     meta.Header.Time,
+		Proposer: meta.Header.ProposerAddress.String(),
+		NumTxs:   meta.NumTxs,
+	}
+}
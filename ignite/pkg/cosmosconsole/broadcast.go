@@ -0,0 +1,89 @@
+package cosmosconsole
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ErrUnknownAccount is returned when a message is signed by an account that is not available in the console.
+var ErrUnknownAccount = errors.New("account is not available in the console")
+
+// Account is an account that can sign messages through the console.
+type Account struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// Accounts returns the accounts that can sign messages through the console.
+func (c Console) Accounts() ([]Account, error) {
+	accounts := make([]Account, 0, len(c.accounts))
+
+	for _, name := range c.accounts {
+		acc, err := c.client.Account(name)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, Account{
+			Name:    name,
+			Address: acc.Address(c.addressPrefix),
+		})
+	}
+
+	return accounts, nil
+}
+
+// decodeMessages decodes JSON encoded messages, each message must have its type set in the @type field.
+func (c Console) decodeMessages(messages []json.RawMessage) ([]sdktypes.Msg, error) {
+	if len(messages) == 0 {
+		return nil, errors.New("at least one message is required")
+	}
+
+	msgs := make([]sdktypes.Msg, 0, len(messages))
+
+	for i, m := range messages {
+		var msg sdktypes.Msg
+		if err := c.cdc.UnmarshalInterfaceJSON(m, &msg); err != nil {
+			return nil, fmt.Errorf("message #%d: %w", i, err)
+		}
+		msgs = append(msgs, msg)
+	}
+
+	return msgs, nil
+}
+
+// Broadcast signs the JSON encoded messages with the account, broadcasts them in
+// a transaction and returns the decoded transaction once it is included in a block.
+func (c Console) Broadcast(ctx context.Context, accountName string, messages []json.RawMessage) (Tx, error) {
+	if !c.hasAccount(accountName) {
+		return Tx{}, fmt.Errorf("%w: %s", ErrUnknownAccount, accountName)
+	}
+
+	msgs, err := c.decodeMessages(messages)
+	if err != nil {
+		return Tx{}, err
+	}
+
+	res, err := c.client.BroadcastTx(accountName, msgs...)
+
+	// transactions that fail during their execution are still included in a block,
+	// their result is returned to inspect the events and the log.
+	if err != nil && (res.TxResponse == nil || res.Height == 0) {
+		return Tx{}, err
+	}
+
+	return c.Tx(ctx, res.TxHash)
+}
+
+func (c Console) hasAccount(name string) bool {
+	for _, n := range c.accounts {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// Package cosmosconsole is an embedded developer console for Cosmos SDK chains.
+// It lists recent blocks, shows decoded transactions with their events, runs gRPC
+// queries over Tendermint RPC and signs & broadcasts messages with local accounts.
+// The console doesn't depend on any external service, so it works offline.
+package cosmosconsole
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	vestingtypes "github.com/cosmos/cosmos-sdk/x/auth/vesting/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	crisistypes "github.com/cosmos/cosmos-sdk/x/crisis/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	evidencetypes "github.com/cosmos/cosmos-sdk/x/evidence/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	slashingtypes "github.com/cosmos/cosmos-sdk/x/slashing/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	ibctransfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	ibccoretypes "github.com/cosmos/ibc-go/v3/modules/core/types"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+)
+
+// DefaultBlocksLimit is the default number of recent blocks listed by the console.
+const DefaultBlocksLimit = 20
+
+// Console serves the developer console of a chain.
+type Console struct {
+	client cosmosclient.Client
+
+	// cdc is used to decode messages, queries and transactions.
+	cdc      codec.Codec
+	registry codectypes.InterfaceRegistry
+
+	// accounts is the list of account names that can sign messages.
+	accounts []string
+
+	// addressPrefix is the bech32 address prefix of the chain.
+	addressPrefix string
+
+	// title is the page title of the console.
+	title string
+}
+
+// Option configures the console.
+type Option func(*Console)
+
+// Accounts sets the names of the accounts in the keyring that can sign messages through the console.
+func Accounts(names ...string) Option {
+	return func(c *Console) {
+		c.accounts = names
+	}
+}
+
+// AddressPrefix sets the bech32 address prefix used to display account addresses.
+func AddressPrefix(prefix string) Option {
+	return func(c *Console) {
+		c.addressPrefix = prefix
+	}
+}
+
+// Title sets the page title of the console.
+func Title(title string) Option {
+	return func(c *Console) {
+		c.title = title
+	}
+}
+
+// New creates a new console that accesses the chain through client.
+// Messages and query types of the Cosmos SDK and IBC modules are registered to the
+// client's interface registry, so they can be decoded from and encoded to JSON.
+func New(client cosmosclient.Client, options ...Option) Console {
+	c := Console{
+		client:        client,
+		cdc:           client.Context().Codec,
+		registry:      client.Context().InterfaceRegistry,
+		addressPrefix: "cosmos",
+		title:         "Console",
+	}
+
+	for _, apply := range options {
+		apply(&c)
+	}
+
+	RegisterInterfaces(c.registry)
+
+	return c
+}
+
+// RegisterInterfaces registers the interfaces and implementations of the Cosmos SDK and IBC modules
+// that are known by the console.
+func RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	for _, register := range []func(codectypes.InterfaceRegistry){
+		authz.RegisterInterfaces,
+		banktypes.RegisterInterfaces,
+		crisistypes.RegisterInterfaces,
+		distrtypes.RegisterInterfaces,
+		evidencetypes.RegisterInterfaces,
+		feegrant.RegisterInterfaces,
+		govtypes.RegisterInterfaces,
+		slashingtypes.RegisterInterfaces,
+		upgradetypes.RegisterInterfaces,
+		vestingtypes.RegisterInterfaces,
+		ibctransfertypes.RegisterInterfaces,
+		ibccoretypes.RegisterInterfaces,
+	} {
+		register(registry)
+	}
+}
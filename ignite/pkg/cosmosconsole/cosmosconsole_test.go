@@ -0,0 +1,191 @@
+package cosmosconsole
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdktypes "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/gogo/protobuf/proto"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func newCodec() (codec.Codec, codectypes.InterfaceRegistry) {
+	registry := codectypes.NewInterfaceRegistry()
+	sdktypes.RegisterInterfaces(registry)
+	RegisterInterfaces(registry)
+	return codec.NewProtoCodec(registry), registry
+}
+
+func TestParseQueryMethod(t *testing.T) {
+	m, err := parseQueryMethod("/cosmos.bank.v1beta1.Query/AllBalances")
+	require.NoError(t, err)
+	require.Equal(t, queryMethod{Package: "cosmos.bank.v1beta1", Service: "Query", Name: "AllBalances"}, m)
+	require.Equal(t, "/cosmos.bank.v1beta1.Query/AllBalances", m.String())
+
+	req, res, err := m.types()
+	require.NoError(t, err)
+	require.IsType(t, &banktypes.QueryAllBalancesRequest{}, req)
+	require.IsType(t, &banktypes.QueryAllBalancesResponse{}, res)
+
+	m, err = parseQueryMethod("cosmos.bank.v1beta1.Query/Balance")
+	require.NoError(t, err)
+	require.Equal(t, "Balance", m.Name)
+
+	for _, method := range []string{"", "/Query/Balance", "/cosmos.bank.v1beta1.Query", "/cosmos.bank.v1beta1.Query/"} {
+		_, err := parseQueryMethod(method)
+		require.Error(t, err, method)
+	}
+
+	m, err = parseQueryMethod("/mars.Query/Unknown")
+	require.NoError(t, err)
+	_, _, err = m.types()
+	require.EqualError(t, err, "type mars.QueryUnknownRequest is not known by the console")
+}
+
+func TestDecodeTx(t *testing.T) {
+	cdc, registry := newCodec()
+
+	send, err := codectypes.NewAnyWithValue(&banktypes.MsgSend{
+		FromAddress: "cosmos1from",
+		ToAddress:   "cosmos1to",
+		Amount:      sdktypes.NewCoins(sdktypes.NewInt64Coin("token", 10)),
+	})
+	require.NoError(t, err)
+
+	body, err := proto.Marshal(&txtypes.TxBody{
+		Messages: []*codectypes.Any{send, {TypeUrl: "/mars.MsgUnknown", Value: []byte{1, 2}}},
+		Memo:     "hello",
+	})
+	require.NoError(t, err)
+
+	authInfo, err := proto.Marshal(&txtypes.AuthInfo{
+		Fee: &txtypes.Fee{Amount: sdktypes.NewCoins(sdktypes.NewInt64Coin("stake", 5))},
+	})
+	require.NoError(t, err)
+
+	rawTx, err := proto.Marshal(&txtypes.TxRaw{BodyBytes: body, AuthInfoBytes: authInfo})
+	require.NoError(t, err)
+
+	var tx Tx
+	require.NoError(t, decodeTx(cdc, registry, rawTx, &tx))
+	require.Equal(t, "hello", tx.Memo)
+	require.Equal(t, "5stake", tx.Fee)
+	require.Len(t, tx.Messages, 2)
+	require.JSONEq(t, `{
+		"@type": "/cosmos.bank.v1beta1.MsgSend",
+		"from_address": "cosmos1from",
+		"to_address": "cosmos1to",
+		"amount": [{"denom": "token", "amount": "10"}]
+	}`, string(tx.Messages[0]))
+	require.JSONEq(t, `{"@type": "/mars.MsgUnknown", "value": "AQI="}`, string(tx.Messages[1]))
+}
+
+func TestNewEvents(t *testing.T) {
+	events := newEvents([]abci.Event{
+		{
+			Type: "transfer",
+			Attributes: []abci.EventAttribute{
+				{Key: []byte("recipient"), Value: []byte("cosmos1to")},
+				{Key: []byte("amount"), Value: []byte("10token")},
+			},
+		},
+	})
+
+	require.Equal(t, []Event{
+		{
+			Type: "transfer",
+			Attributes: []EventAttribute{
+				{Key: "recipient", Value: "cosmos1to"},
+				{Key: "amount", Value: "10token"},
+			},
+		},
+	}, events)
+}
+
+func TestDecodeMessages(t *testing.T) {
+	cdc, registry := newCodec()
+	c := Console{cdc: cdc, registry: registry}
+
+	msgs, err := c.decodeMessages([]json.RawMessage{
+		json.RawMessage(`{"@type": "/cosmos.bank.v1beta1.MsgSend", "from_address": "cosmos1from", "to_address": "cosmos1to"}`),
+	})
+	require.NoError(t, err)
+	require.Len(t, msgs, 1)
+	require.Equal(t, "cosmos1from", msgs[0].(*banktypes.MsgSend).FromAddress)
+
+	_, err = c.decodeMessages(nil)
+	require.Error(t, err)
+
+	_, err = c.decodeMessages([]json.RawMessage{json.RawMessage(`{"@type": "/mars.MsgUnknown"}`)})
+	require.Error(t, err)
+}
+
+func TestServeUI(t *testing.T) {
+	c := Console{title: "Mars"}
+
+	cases := []struct {
+		path        string
+		contains    string
+		contentType string
+	}{
+		{"/", "<title>Mars</title>", "text/html"},
+		{"/assets/console.js", "api(", "text/javascript"},
+		{"/assets/console.css", "body", "text/css"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c.ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			res := w.Result()
+			body, _ := io.ReadAll(res.Body)
+
+			require.Equal(t, http.StatusOK, res.StatusCode)
+			require.Contains(t, res.Header.Get("Content-Type"), tt.contentType)
+			require.Contains(t, string(body), tt.contains)
+		})
+	}
+}
+
+func TestBroadcastOrigin(t *testing.T) {
+	c := Console{}
+
+	cases := []struct {
+		name        string
+		origin      string
+		contentType string
+		code        int
+		err         error
+	}{
+		{"same origin", "http://localhost:4600", "application/json", http.StatusBadRequest, ErrUnknownAccount},
+		{"without origin", "", "application/json; charset=utf-8", http.StatusBadRequest, ErrUnknownAccount},
+		{"cross origin", "http://example.com", "application/json", http.StatusForbidden, ErrCrossOrigin},
+		{"form", "", "text/plain", http.StatusUnsupportedMediaType, ErrUnsupportedContentType},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "http://localhost:4600/api/txs", strings.NewReader(`{"account":"alice"}`))
+			r.Header.Set("Content-Type", tt.contentType)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+
+			w := httptest.NewRecorder()
+			c.ServeHTTP(w, r)
+
+			require.Equal(t, tt.code, w.Code)
+			require.Contains(t, w.Body.String(), tt.err.Error())
+		})
+	}
+}
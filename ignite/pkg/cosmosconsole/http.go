@@ -0,0 +1,232 @@
+package cosmosconsole
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gorilla/mux"
+
+	"github.com/ignite/cli/ignite/pkg/xhttp"
+)
+
+//go:embed ui
+var ui embed.FS
+
+var indexTemplate = template.Must(template.ParseFS(ui, "ui/index.html"))
+
+var (
+	// ErrUnsupportedContentType is returned when messages are broadcasted with a payload that isn't JSON.
+	ErrUnsupportedContentType = errors.New("content type must be application/json")
+
+	// ErrCrossOrigin is returned when messages are broadcasted from another page than the console.
+	ErrCrossOrigin = errors.New("messages can only be broadcasted from the console")
+)
+
+// BroadcastRequest is the payload to sign and broadcast messages.
+type BroadcastRequest struct {
+	// Account is the name of the account that signs the messages.
+	Account string `json:"account"`
+
+	// Messages are the JSON encoded messages with their type set in the @type field.
+	Messages []json.RawMessage `json:"messages"`
+}
+
+// QueryRequest is the payload to run a gRPC query.
+type QueryRequest struct {
+	// Method is the fully qualified gRPC method, e.g. /cosmos.bank.v1beta1.Query/AllBalances.
+	Method string `json:"method"`
+
+	// Request is the JSON encoded request of the method.
+	Request json.RawMessage `json:"request"`
+}
+
+// ServeHTTP implements http.Handler to serve the console page and its API.
+func (c Console) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	router := mux.NewRouter()
+
+	assets, _ := fs.Sub(ui, "ui")
+
+	router.HandleFunc("/", c.indexHandler).
+		Methods(http.MethodGet)
+
+	router.PathPrefix("/assets/").
+		Handler(http.StripPrefix("/assets/", http.FileServer(http.FS(assets)))).
+		Methods(http.MethodGet)
+
+	api := router.PathPrefix("/api").Subrouter()
+
+	api.HandleFunc("/status", c.statusHandler).
+		Methods(http.MethodGet)
+
+	api.HandleFunc("/accounts", c.accountsHandler).
+		Methods(http.MethodGet)
+
+	api.HandleFunc("/blocks", c.blocksHandler).
+		Methods(http.MethodGet)
+
+	api.HandleFunc("/blocks/{height:[0-9]+}", c.blockHandler).
+		Methods(http.MethodGet)
+
+	api.HandleFunc("/txs/{hash:[0-9a-fA-F]+}", c.txHandler).
+		Methods(http.MethodGet)
+
+	api.HandleFunc("/txs", c.broadcastHandler).
+		Methods(http.MethodPost)
+
+	api.HandleFunc("/query", c.queryHandler).
+		Methods(http.MethodPost)
+
+	router.ServeHTTP(w, r)
+}
+
+func (c Console) indexHandler(w http.ResponseWriter, _ *http.Request) {
+	indexTemplate.Execute(w, struct {
+		Title string
+	}{
+		c.title,
+	})
+}
+
+func (c Console) statusHandler(w http.ResponseWriter, r *http.Request) {
+	status, err := c.Status(r.Context())
+	if err != nil {
+		responseError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, status)
+}
+
+func (c Console) accountsHandler(w http.ResponseWriter, _ *http.Request) {
+	accounts, err := c.Accounts()
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, accounts)
+}
+
+func (c Console) blocksHandler(w http.ResponseWriter, r *http.Request) {
+	var limit int
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil {
+			responseError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	blocks, err := c.Blocks(r.Context(), limit)
+	if err != nil {
+		responseError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, blocks)
+}
+
+func (c Console) blockHandler(w http.ResponseWriter, r *http.Request) {
+	height, err := strconv.ParseInt(mux.Vars(r)["height"], 10, 64)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	block, err := c.Block(r.Context(), height)
+	if err != nil {
+		responseError(w, http.StatusNotFound, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, block)
+}
+
+func (c Console) txHandler(w http.ResponseWriter, r *http.Request) {
+	tx, err := c.Tx(r.Context(), mux.Vars(r)["hash"])
+	if err != nil {
+		responseError(w, http.StatusNotFound, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, tx)
+}
+
+func (c Console) broadcastHandler(w http.ResponseWriter, r *http.Request) {
+	if code, err := checkSameOrigin(r); err != nil {
+		responseError(w, code, err)
+		return
+	}
+
+	var req BroadcastRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	tx, err := c.Broadcast(r.Context(), req.Account, req.Messages)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, ErrUnknownAccount) {
+			code = http.StatusBadRequest
+		}
+		responseError(w, code, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, tx)
+}
+
+func (c Console) queryHandler(w http.ResponseWriter, r *http.Request) {
+	var req QueryRequest
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := c.Query(r.Context(), req.Method, req.Request)
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, res)
+}
+
+// checkSameOrigin checks that a request that signs with the accounts of the console is made by the
+// page of the console and returns the status code of the response with the error otherwise.
+// Cross-origin pages can't send JSON requests without a CORS preflight that the console doesn't
+// allow, and browsers send the origin of the page with the requests, that must be the console.
+func checkSameOrigin(r *http.Request) (code int, err error) {
+	contentType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if contentType != "application/json" {
+		return http.StatusUnsupportedMediaType, ErrUnsupportedContentType
+	}
+
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		// the request isn't made by a browser.
+		return 0, nil
+	}
+
+	u, err := url.Parse(origin)
+	if err != nil || u.Host != r.Host {
+		return http.StatusForbidden, ErrCrossOrigin
+	}
+
+	return 0, nil
+}
+
+func responseError(w http.ResponseWriter, code int, err error) {
+	xhttp.ResponseJSON(w, code, xhttp.NewErrorResponse(err))
+}
@@ -0,0 +1,107 @@
+package cosmosconsole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// queryMethod is a gRPC query method.
+type queryMethod struct {
+	// Package is the proto package of the query service, e.g. cosmos.bank.v1beta1.
+	Package string
+
+	// Service is the name of the query service, e.g. Query.
+	Service string
+
+	// Name is the name of the RPC method, e.g. AllBalances.
+	Name string
+}
+
+// parseQueryMethod parses a fully qualified gRPC method name like
+// /cosmos.bank.v1beta1.Query/AllBalances. The leading slash is optional.
+func parseQueryMethod(method string) (queryMethod, error) {
+	items := strings.Split(strings.TrimPrefix(method, "/"), "/")
+	if len(items) != 2 || items[1] == "" {
+		return queryMethod{}, fmt.Errorf("invalid query method %q, expected format is /package.Service/Method", method)
+	}
+
+	i := strings.LastIndex(items[0], ".")
+	if i <= 0 || i == len(items[0])-1 {
+		return queryMethod{}, fmt.Errorf("invalid query service %q, expected format is package.Service", items[0])
+	}
+
+	return queryMethod{
+		Package: items[0][:i],
+		Service: items[0][i+1:],
+		Name:    items[1],
+	}, nil
+}
+
+// String returns the fully qualified gRPC method name.
+func (m queryMethod) String() string {
+	return fmt.Sprintf("/%s.%s/%s", m.Package, m.Service, m.Name)
+}
+
+// types returns the request and response messages of the query method.
+// Types are found by following the Cosmos SDK naming convention for query services:
+// the Balance method of the Query service uses QueryBalanceRequest and QueryBalanceResponse.
+func (m queryMethod) types() (req, res proto.Message, err error) {
+	prefix := fmt.Sprintf("%s.%s%s", m.Package, m.Service, m.Name)
+
+	if req, err = newMessage(prefix + "Request"); err != nil {
+		return nil, nil, err
+	}
+	if res, err = newMessage(prefix + "Response"); err != nil {
+		return nil, nil, err
+	}
+
+	return req, res, nil
+}
+
+// newMessage creates a new message from its proto name.
+func newMessage(name string) (proto.Message, error) {
+	t := proto.MessageType(name)
+	if t == nil {
+		return nil, fmt.Errorf("type %s is not known by the console", name)
+	}
+
+	msg, ok := reflect.New(t.Elem()).Interface().(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not a proto message", name)
+	}
+
+	return msg, nil
+}
+
+// Query runs a gRPC query over Tendermint RPC by calling the fully qualified method
+// with a JSON encoded request. The response is returned JSON encoded as well.
+func (c Console) Query(ctx context.Context, method string, request json.RawMessage) (json.RawMessage, error) {
+	m, err := parseQueryMethod(method)
+	if err != nil {
+		return nil, err
+	}
+
+	req, res, err := m.types()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(request) == 0 {
+		request = json.RawMessage("{}")
+	}
+
+	if err := c.cdc.UnmarshalJSON(request, req); err != nil {
+		return nil, fmt.Errorf("invalid request for %s: %w", m, err)
+	}
+
+	if err := c.client.Context().Invoke(ctx, m.String(), req, res); err != nil {
+		return nil, err
+	}
+
+	return c.cdc.MarshalJSON(res)
+}
@@ -0,0 +1,158 @@
+package cosmosconsole
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// Tx is a decoded transaction with its execution result.
+type Tx struct {
+	Hash      string            `json:"hash"`
+	Height    int64             `json:"height"`
+	Code      uint32            `json:"code"`
+	Codespace string            `json:"codespace,omitempty"`
+	Log       string            `json:"log,omitempty"`
+	GasWanted int64             `json:"gas_wanted"`
+	GasUsed   int64             `json:"gas_used"`
+	Memo      string            `json:"memo,omitempty"`
+	Fee       string            `json:"fee,omitempty"`
+	Messages  []json.RawMessage `json:"messages"`
+	Events    []Event           `json:"events"`
+}
+
+// Event is an event emitted during the execution of a transaction.
+type Event struct {
+	Type       string           `json:"type"`
+	Attributes []EventAttribute `json:"attributes"`
+}
+
+// EventAttribute is a key value pair of an event.
+type EventAttribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// rawMessage is the JSON representation of a message whose type is not known by the console.
+type rawMessage struct {
+	Type  string `json:"@type"`
+	Value []byte `json:"value"`
+}
+
+// Tx returns the decoded transaction by its hex encoded hash.
+func (c Console) Tx(ctx context.Context, hash string) (Tx, error) {
+	h, err := hex.DecodeString(hash)
+	if err != nil {
+		return Tx{}, fmt.Errorf("invalid tx hash %q: %w", hash, err)
+	}
+
+	res, err := c.client.RPC.Tx(ctx, h, false)
+	if err != nil {
+		return Tx{}, err
+	}
+
+	return newTx(c.cdc, c.registry, res)
+}
+
+// newTx creates a decoded transaction from a Tendermint tx result.
+func newTx(cdc codec.Codec, registry codectypes.InterfaceRegistry, res *ctypes.ResultTx) (Tx, error) {
+	tx := Tx{
+		Hash:      fmt.Sprintf("%X", res.Hash),
+		Height:    res.Height,
+		Code:      res.TxResult.Code,
+		Codespace: res.TxResult.Codespace,
+		Log:       res.TxResult.Log,
+		GasWanted: res.TxResult.GasWanted,
+		GasUsed:   res.TxResult.GasUsed,
+		Events:    newEvents(res.TxResult.Events),
+	}
+
+	if err := decodeTx(cdc, registry, res.Tx, &tx); err != nil {
+		return Tx{}, err
+	}
+
+	return tx, nil
+}
+
+// decodeTx decodes the body and the fee of the raw tx into tx.
+// Messages are decoded into JSON when their types are registered, otherwise
+// they're represented with their type URL and protobuf encoded value.
+func decodeTx(cdc codec.Codec, registry codectypes.InterfaceRegistry, rawTx tmtypes.Tx, tx *Tx) error {
+	// the raw tx is decoded without unpacking the interfaces, so transactions
+	// that contain messages of unknown types can still be displayed.
+	var raw txtypes.TxRaw
+	if err := proto.Unmarshal(rawTx, &raw); err != nil {
+		return err
+	}
+
+	var body txtypes.TxBody
+	if err := proto.Unmarshal(raw.BodyBytes, &body); err != nil {
+		return err
+	}
+
+	var authInfo txtypes.AuthInfo
+	if err := proto.Unmarshal(raw.AuthInfoBytes, &authInfo); err != nil {
+		return err
+	}
+
+	tx.Memo = body.Memo
+	if authInfo.Fee != nil {
+		tx.Fee = authInfo.Fee.Amount.String()
+	}
+
+	tx.Messages = make([]json.RawMessage, 0, len(body.Messages))
+
+	for _, m := range body.Messages {
+		msg, err := decodeMessage(cdc, registry, m)
+		if err != nil {
+			return err
+		}
+		tx.Messages = append(tx.Messages, msg)
+	}
+
+	return nil
+}
+
+func decodeMessage(cdc codec.Codec, registry codectypes.InterfaceRegistry, m *codectypes.Any) (json.RawMessage, error) {
+	msg, err := registry.Resolve(m.TypeUrl)
+	if err != nil {
+		return json.Marshal(rawMessage{m.TypeUrl, m.Value})
+	}
+
+	if err := proto.Unmarshal(m.Value, msg); err != nil {
+		return nil, err
+	}
+
+	return cdc.MarshalInterfaceJSON(msg)
+}
+
+func newEvents(events []abci.Event) []Event {
+	evs := make([]Event, 0, len(events))
+
+	for _, e := range events {
+		ev := Event{
+			Type:       e.Type,
+			Attributes: make([]EventAttribute, 0, len(e.Attributes)),
+		}
+
+		for _, attr := range e.Attributes {
+			ev.Attributes = append(ev.Attributes, EventAttribute{
+				Key:   string(attr.Key),
+				Value: string(attr.Value),
+			})
+		}
+
+		evs = append(evs, ev)
+	}
+
+	return evs
+}
@@ -0,0 +1,235 @@
+(function () {
+  "use strict";
+
+  const refreshInterval = 5000;
+
+  // api calls the console API and returns the decoded JSON response.
+  async function api(path, body) {
+    const options = body === undefined ? {} : {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify(body),
+    };
+    const res = await fetch("api" + path, options);
+    const data = await res.json();
+    if (!res.ok) {
+      throw new Error(data.error ? data.error.message : res.statusText);
+    }
+    return data;
+  }
+
+  function el(tag, text, className) {
+    const e = document.createElement(tag);
+    if (text !== undefined) {
+      e.textContent = text;
+    }
+    if (className) {
+      e.className = className;
+    }
+    return e;
+  }
+
+  function row(cells) {
+    const tr = el("tr");
+    cells.forEach((c) => tr.appendChild(c instanceof Node ? wrap("td", c) : el("td", String(c))));
+    return tr;
+  }
+
+  function wrap(tag, child) {
+    const e = el(tag);
+    e.appendChild(child);
+    return e;
+  }
+
+  function showError(target, err) {
+    target.replaceChildren(el("p", err.message, "error"));
+  }
+
+  // tabs.
+  const tabs = document.querySelectorAll("nav button");
+
+  function openTab(name) {
+    tabs.forEach((t) => t.classList.toggle("active", t.dataset.tab === name));
+    document.querySelectorAll(".tab").forEach((t) => t.classList.toggle("active", t.id === name));
+  }
+
+  tabs.forEach((t) => t.addEventListener("click", () => openTab(t.dataset.tab)));
+
+  // status.
+  const status = document.getElementById("status");
+
+  async function refreshStatus() {
+    try {
+      const s = await api("/status");
+      status.textContent = `${s.chain_id} · height ${s.height}`;
+    } catch (err) {
+      status.textContent = "node is not reachable";
+    }
+  }
+
+  // blocks.
+  const blocksList = document.getElementById("blocks-list");
+  const blockDetail = document.getElementById("block-detail");
+
+  async function refreshBlocks() {
+    try {
+      const blocks = await api("/blocks");
+      blocksList.replaceChildren(...blocks.map((b) => {
+        const tr = row([b.height, b.hash, new Date(b.time).toLocaleString(), b.num_txs]);
+        tr.className = "link";
+        tr.addEventListener("click", () => showBlock(b.height));
+        return tr;
+      }));
+    } catch (err) {
+      blocksList.replaceChildren(row([err.message]));
+    }
+  }
+
+  async function showBlock(height) {
+    try {
+      const b = await api(`/blocks/${height}`);
+      const list = el("ul");
+      b.txs.forEach((hash) => {
+        const a = el("a", hash);
+        a.href = "#";
+        a.addEventListener("click", (e) => {
+          e.preventDefault();
+          showTx(hash);
+        });
+        list.appendChild(wrap("li", a));
+      });
+      blockDetail.replaceChildren(
+        el("h2", `Block ${b.height}`),
+        el("p", `Proposer: ${b.proposer}`),
+        b.txs.length ? list : el("p", "No transactions."),
+      );
+    } catch (err) {
+      showError(blockDetail, err);
+    }
+  }
+
+  // transactions.
+  const txForm = document.getElementById("tx-form");
+  const txHash = document.getElementById("tx-hash");
+  const txResult = document.getElementById("tx-result");
+
+  function renderTx(tx) {
+    const summary = el("table");
+    summary.append(
+      row(["Hash", tx.hash]),
+      row(["Height", tx.height]),
+      row(["Result", tx.code === 0 ? "success" : `failed with code ${tx.code} (${tx.codespace})`]),
+      row(["Gas (used / wanted)", `${tx.gas_used} / ${tx.gas_wanted}`]),
+      row(["Fee", tx.fee || "-"]),
+      row(["Memo", tx.memo || "-"]),
+    );
+    if (tx.code !== 0) {
+      summary.append(row(["Log", tx.log]));
+    }
+
+    const events = el("table");
+    events.appendChild(wrap("thead", row(["Type", "Key", "Value"])));
+    const body = el("tbody");
+    tx.events.forEach((e) => e.attributes.forEach((a) => body.appendChild(row([e.type, a.key, a.value]))));
+    events.appendChild(body);
+
+    return [
+      summary,
+      el("h2", "Messages"),
+      el("pre", JSON.stringify(tx.messages, null, 2)),
+      el("h2", "Events"),
+      events,
+    ];
+  }
+
+  async function showTx(hash) {
+    openTab("tx");
+    txHash.value = hash;
+    try {
+      txResult.replaceChildren(...renderTx(await api(`/txs/${hash}`)));
+    } catch (err) {
+      showError(txResult, err);
+    }
+  }
+
+  txForm.addEventListener("submit", (e) => {
+    e.preventDefault();
+    showTx(txHash.value.trim());
+  });
+
+  // broadcast.
+  const broadcastForm = document.getElementById("broadcast-form");
+  const broadcastAccount = document.getElementById("broadcast-account");
+  const broadcastMessages = document.getElementById("broadcast-messages");
+  const broadcastResult = document.getElementById("broadcast-result");
+
+  async function loadAccounts() {
+    try {
+      const accounts = await api("/accounts");
+      broadcastAccount.replaceChildren(...accounts.map((a) => {
+        const option = el("option", `${a.name} (${a.address})`);
+        option.value = a.name;
+        option.dataset.address = a.address;
+        return option;
+      }));
+      if (accounts.length && !broadcastMessages.value) {
+        broadcastMessages.value = JSON.stringify([{
+          "@type": "/cosmos.bank.v1beta1.MsgSend",
+          from_address: accounts[0].address,
+          to_address: accounts[accounts.length - 1].address,
+          amount: [{ denom: "token", amount: "1" }],
+        }], null, 2);
+      }
+    } catch (err) {
+      showError(broadcastResult, err);
+    }
+  }
+
+  broadcastForm.addEventListener("submit", async (e) => {
+    e.preventDefault();
+    broadcastResult.replaceChildren(el("p", "Broadcasting..."));
+    try {
+      let messages = JSON.parse(broadcastMessages.value);
+      if (!Array.isArray(messages)) {
+        messages = [messages];
+      }
+      const tx = await api("/txs", { account: broadcastAccount.value, messages });
+      broadcastResult.replaceChildren(
+        el("p", tx.code === 0 ? "Transaction included in a block." : "Transaction failed.", tx.code === 0 ? "success" : "error"),
+        ...renderTx(tx),
+      );
+    } catch (err) {
+      showError(broadcastResult, err);
+    }
+  });
+
+  // query.
+  const queryForm = document.getElementById("query-form");
+  const queryMethod = document.getElementById("query-method");
+  const queryRequest = document.getElementById("query-request");
+  const queryResult = document.getElementById("query-result");
+
+  queryForm.addEventListener("submit", async (e) => {
+    e.preventDefault();
+    queryResult.className = "";
+    try {
+      const request = queryRequest.value.trim() ? JSON.parse(queryRequest.value) : {};
+      const res = await api("/query", { method: queryMethod.value.trim(), request });
+      queryResult.textContent = JSON.stringify(res, null, 2);
+    } catch (err) {
+      queryResult.className = "error";
+      queryResult.textContent = err.message;
+    }
+  });
+
+  function refresh() {
+    refreshStatus();
+    if (document.getElementById("blocks").classList.contains("active")) {
+      refreshBlocks();
+    }
+  }
+
+  refresh();
+  loadAccounts();
+  setInterval(refresh, refreshInterval);
+})();
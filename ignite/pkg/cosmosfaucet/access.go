@@ -0,0 +1,89 @@
+package cosmosfaucet
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+var (
+	// ErrAddressNotAllowed is returned when an address isn't allowed to receive tokens.
+	ErrAddressNotAllowed = errors.New("address is not allowed to receive tokens")
+
+	// ErrInvalidAPIKey is returned when a request is made with an unknown API key.
+	ErrInvalidAPIKey = errors.New("invalid api key")
+)
+
+// Tier is a tier of quotas granted to the clients that are authenticated with an API key.
+type Tier struct {
+	// Name identifies the tier.
+	Name string
+
+	// RateLimit is the max. number of requests that can be made with the API key
+	// within the rate limit window. There is no limit when it's 0.
+	RateLimit int
+
+	// CoinsMax overwrites the max. amounts of coins that can be sent to a single account by denom.
+	CoinsMax map[string]uint64
+
+	// limiter limits the requests made with the API key.
+	limiter *rateLimiter
+}
+
+// apiKeyTier is a tier with its API key.
+type apiKeyTier struct {
+	key  string
+	tier *Tier
+}
+
+// coinsMax returns the max. amounts of coins for the tier by overwriting defaults.
+func (t Tier) coinsMax(defaults map[string]uint64) map[string]uint64 {
+	coinsMax := make(map[string]uint64, len(defaults))
+	for denom, amount := range defaults {
+		coinsMax[denom] = amount
+	}
+	for denom, amount := range t.CoinsMax {
+		coinsMax[denom] = amount
+	}
+	return coinsMax
+}
+
+// isAddressAllowed checks the normalized address against the allow and deny lists,
+// whose addresses are kept in lowercase.
+func (f Faucet) isAddressAllowed(address string) bool {
+	if f.deniedAddresses[address] {
+		return false
+	}
+	return len(f.allowedAddresses) == 0 || f.allowedAddresses[address]
+}
+
+// tier returns the tier of the API key that the request is made with.
+// a nil tier is returned when the request isn't made with an API key.
+func (f Faucet) tier(r *http.Request) (*Tier, error) {
+	key := apiKey(r)
+	if key == "" {
+		return nil, nil
+	}
+
+	// keys are compared in constant time to not leak them by timing.
+	for _, t := range f.tiers {
+		if subtle.ConstantTimeCompare([]byte(t.key), []byte(key)) == 1 {
+			return t.tier, nil
+		}
+	}
+
+	return nil, ErrInvalidAPIKey
+}
+
+// apiKey returns the API key sent in the Authorization header as a bearer token.
+func apiKey(r *http.Request) string {
+	const prefix = "Bearer "
+
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, prefix) {
+		return ""
+	}
+
+	return strings.TrimSpace(strings.TrimPrefix(auth, prefix))
+}
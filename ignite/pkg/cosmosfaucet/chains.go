@@ -0,0 +1,232 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	ibctransfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	"github.com/pkg/errors"
+)
+
+// ErrUnknownChain is returned when a transfer is requested for a chain that the faucet doesn't serve.
+var ErrUnknownChain = errors.New("chain is not served by the faucet")
+
+// chainOptions are the options of another chain served by the faucet.
+type chainOptions struct {
+	client  ChainClient
+	options []Option
+}
+
+// AdditionalChain serves the transfers of another chain with the faucet, the transfers are
+// requested for the chain by its id. client connects to the chain and holds the account to send
+// the tokens from in its keyring. The account, coins, pool, ledger and address prefix of the chain
+// are configured with options, the address prefix being required. The other options, e.g. the rate
+// limits, are configured for the faucet and apply to all chains.
+func AdditionalChain(client ChainClient, options ...Option) Option {
+	return func(f *Faucet) {
+		f.additionalChains = append(f.additionalChains, chainOptions{client, options})
+	}
+}
+
+// AddressPrefix sets the bech32 prefix of the account addresses of an additional chain.
+func AddressPrefix(prefix string) Option {
+	return func(f *Faucet) {
+		f.addressPrefix = prefix
+	}
+}
+
+// DenomAlias allows to request and configure the coins of denom by alias,
+// e.g. IBC denoms (ibc/<hash>) by the name of their tokens.
+// IBC denoms can also be requested by their trace, e.g. transfer/channel-0/uatom.
+func DenomAlias(alias, denom string) Option {
+	return func(f *Faucet) {
+		f.denomAliases[alias] = denom
+	}
+}
+
+// newAdditionalChain creates the faucet of an additional chain that client connects to.
+// the faucet shares the metrics of f.
+func (f Faucet) newAdditionalChain(ctx context.Context, client ChainClient, options ...Option) (*Faucet, error) {
+	c := newFaucet(options...)
+	c.metrics = f.metrics
+
+	if err := c.useClientAccount(client); err != nil {
+		return nil, err
+	}
+
+	if err := c.init(ctx, client); err != nil {
+		return nil, errors.Wrapf(err, "chain %s", c.chainID)
+	}
+
+	return &c, nil
+}
+
+// useClientAccount sets the chain and the account of the faucet from client, the account is imported
+// in the keyring of the client when its mnemonic is provided.
+func (f *Faucet) useClientAccount(client ChainClient) error {
+	clientCtx := client.Context()
+
+	if f.chainID == "" {
+		f.chainID = clientCtx.ChainID
+	}
+
+	if f.addressPrefix == "" {
+		return fmt.Errorf("address prefix of chain %s is required", f.chainID)
+	}
+
+	info, err := clientCtx.Keyring.Key(f.accountName)
+	if errors.Is(err, sdkerrors.ErrKeyNotFound) && f.accountMnemonic != "" {
+		coinType, err := parseCoinType(f.coinType)
+		if err != nil {
+			return err
+		}
+
+		hdPath := hd.CreateHDPath(coinType, 0, 0).String()
+		info, err = clientCtx.Keyring.NewAccount(f.accountName, f.accountMnemonic, "", hdPath, hd.Secp256k1)
+		if err != nil {
+			return err
+		}
+	} else if errors.Is(err, sdkerrors.ErrKeyNotFound) {
+		return fmt.Errorf("account %s of chain %s doesn't exist", f.accountName, f.chainID)
+	} else if err != nil {
+		return err
+	}
+
+	f.accountAddress, err = bech32.ConvertAndEncode(f.addressPrefix, info.GetAddress())
+	return err
+}
+
+// chain returns the faucet of the chain with chainID, the faucet of the default chain is returned
+// when chainID is empty.
+func (f *Faucet) chain(chainID string) (*Faucet, error) {
+	if chainID == "" || chainID == f.chainID {
+		return f, nil
+	}
+
+	if c, ok := f.chains[chainID]; ok {
+		return c, nil
+	}
+
+	return nil, errors.Wrap(ErrUnknownChain, chainID)
+}
+
+// allChains returns the faucets of all chains, starting with the default chain.
+func (f *Faucet) allChains() []*Faucet {
+	chains := []*Faucet{f}
+
+	var ids []string
+	for id := range f.chains {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		chains = append(chains, f.chains[id])
+	}
+
+	return chains
+}
+
+// resolveDenom returns the denom of an alias or of an IBC denom trace, aliases can also be
+// set to traces. denom is returned as it is when it isn't an alias.
+func (f Faucet) resolveDenom(denom string) string {
+	if resolved, ok := f.denomAliases[denom]; ok {
+		denom = resolved
+	}
+
+	if isIBCDenomTrace(denom) {
+		return ibctransfertypes.ParseDenomTrace(denom).IBCDenom()
+	}
+
+	return denom
+}
+
+// resolveCoinDenoms resolves the aliases in the denoms of the coins configured for the faucet.
+// IBC denom traces are kept as aliases to be listed in the faucet info.
+func (f *Faucet) resolveCoinDenoms() {
+	coinsMax := make(map[string]uint64, len(f.coinsMax))
+
+	for denom, amount := range f.coinsMax {
+		coinsMax[f.resolveDenom(denom)] = amount
+	}
+
+	for i, c := range f.coins {
+		resolved := f.resolveDenom(c.Denom)
+		if isIBCDenomTrace(c.Denom) {
+			f.denomAliases[c.Denom] = resolved
+		}
+		f.coins[i].Denom = resolved
+	}
+
+	f.coinsMax = coinsMax
+}
+
+// isIBCDenomTrace checks if denom is the trace of an IBC denom, e.g. transfer/channel-0/uatom.
+func isIBCDenomTrace(denom string) bool {
+	parts := strings.Split(denom, "/")
+	if len(parts) < 3 || len(parts)%2 == 0 {
+		return false
+	}
+
+	for i := 1; i < len(parts)-1; i += 2 {
+		if !strings.HasPrefix(parts[i], "channel-") {
+			return false
+		}
+	}
+
+	return ibctransfertypes.ValidatePrefixedDenom(denom) == nil
+}
+
+// ChainInfo is the info of a chain served by the faucet.
+type ChainInfo struct {
+	// ChainID is the id of the chain.
+	ChainID string `json:"chain_id"`
+
+	// Coins are the coins distributed on the chain.
+	Coins []CoinInfo `json:"coins"`
+}
+
+// CoinInfo is the info of a coin distributed by the faucet.
+type CoinInfo struct {
+	// Denom is the denom of the coin.
+	Denom string `json:"denom"`
+
+	// Aliases are the names that the coin can be requested by.
+	Aliases []string `json:"aliases,omitempty"`
+
+	// Amount is the amount sent per request by default.
+	Amount sdk.Int `json:"amount"`
+
+	// MaxAmount is the max. amount that can be sent to a single account, there is no limit when it's 0.
+	MaxAmount uint64 `json:"max_amount"`
+}
+
+// chainInfo returns the info of the chain of the faucet.
+func (f Faucet) chainInfo() ChainInfo {
+	info := ChainInfo{ChainID: f.chainID}
+
+	for _, c := range f.coins {
+		coin := CoinInfo{
+			Denom:     c.Denom,
+			Amount:    c.Amount,
+			MaxAmount: f.coinsMax[c.Denom],
+		}
+
+		for alias := range f.denomAliases {
+			if f.resolveDenom(alias) == c.Denom {
+				coin.Aliases = append(coin.Aliases, alias)
+			}
+		}
+		sort.Strings(coin.Aliases)
+
+		info.Coins = append(info.Coins, coin)
+	}
+
+	return info
+}
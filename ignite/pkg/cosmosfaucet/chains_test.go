@@ -0,0 +1,174 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/cosmos/go-bip39"
+	ibctransfertypes "github.com/cosmos/ibc-go/v3/modules/apps/transfer/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsIBCDenomTrace(t *testing.T) {
+	require.True(t, isIBCDenomTrace("transfer/channel-0/uatom"))
+	require.True(t, isIBCDenomTrace("transfer/channel-0/transfer/channel-12/uatom"))
+	require.False(t, isIBCDenomTrace("uatom"))
+	require.False(t, isIBCDenomTrace("ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"))
+	require.False(t, isIBCDenomTrace("factory/cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj/token"))
+	require.False(t, isIBCDenomTrace("transfer/channel-0"))
+}
+
+func TestResolveDenoms(t *testing.T) {
+	var (
+		trace    = "transfer/channel-0/uatom"
+		ibcDenom = ibctransfertypes.ParseDenomTrace(trace).IBCDenom()
+	)
+
+	f := newFaucet(
+		DenomAlias("atom", ibcDenom),
+		DenomAlias("atom-trace", trace),
+		Coin(10, 100, "token"),
+		Coin(5, 50, trace),
+	)
+
+	require.Equal(t, ibcDenom, f.resolveDenom("atom"))
+	require.Equal(t, ibcDenom, f.resolveDenom(trace))
+	require.Equal(t, ibcDenom, f.resolveDenom("atom-trace"))
+	require.Equal(t, "token", f.resolveDenom("token"))
+
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("token", 10), sdk.NewInt64Coin(ibcDenom, 5)), append(sdk.Coins{}, f.coins...).Sort())
+	require.Equal(t, map[string]uint64{"token": 100, ibcDenom: 50}, f.coinsMax)
+
+	coins, err := f.coinsFromRequest(TransferRequest{Coins: []string{"1atom", "2" + trace, "3token"}})
+	require.NoError(t, err)
+	require.Equal(t, sdk.Coins{
+		sdk.NewInt64Coin(ibcDenom, 1),
+		sdk.NewInt64Coin(ibcDenom, 2),
+		sdk.NewInt64Coin("token", 3),
+	}, coins)
+
+	info := f.chainInfo()
+	require.Len(t, info.Coins, 2)
+	require.Equal(t, ibcDenom, info.Coins[1].Denom)
+	require.Equal(t, []string{"atom", "atom-trace", trace}, info.Coins[1].Aliases)
+	require.Equal(t, uint64(50), info.Coins[1].MaxAmount)
+}
+
+func TestChainRouting(t *testing.T) {
+	f := newFaucet(ChainID("a"))
+	b := newFaucet(ChainID("b"))
+	c := newFaucet(ChainID("c"))
+	f.chains["c"] = &c
+	f.chains["b"] = &b
+
+	chain, err := f.chain("")
+	require.NoError(t, err)
+	require.Equal(t, "a", chain.chainID)
+
+	chain, err = f.chain("b")
+	require.NoError(t, err)
+	require.Equal(t, "b", chain.chainID)
+
+	_, err = f.chain("d")
+	require.ErrorIs(t, err, ErrUnknownChain)
+
+	var ids []string
+	for _, chain := range f.allChains() {
+		ids = append(ids, chain.chainID)
+	}
+	require.Equal(t, []string{"a", "b", "c"}, ids)
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"address":"`+testAddress+`","chain_id":"d"}`))
+	w := httptest.NewRecorder()
+	f.faucetHandler(w, r)
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), ErrUnknownChain.Error())
+}
+
+type testChainClient struct {
+	ctx client.Context
+
+	// sendErr and awaitErr are returned for all the txs.
+	sendErr, awaitErr error
+
+	mu   sync.Mutex
+	sent []sdk.Msg
+}
+
+func (c *testChainClient) Context() client.Context {
+	return c.ctx
+}
+
+func (c *testChainClient) SendTx(_ string, msgs ...sdk.Msg) (string, error) {
+	if c.sendErr != nil {
+		return "", c.sendErr
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.sent = append(c.sent, msgs...)
+	return fmt.Sprintf("%X", len(c.sent)), nil
+}
+
+func (c *testChainClient) AwaitTx(context.Context, string) error {
+	return c.awaitErr
+}
+
+func TestUseClientAccount(t *testing.T) {
+	entropy, err := bip39.NewEntropy(256)
+	require.NoError(t, err)
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	require.NoError(t, err)
+
+	kr := keyring.NewInMemory()
+	chainClient := &testChainClient{ctx: client.Context{}.WithChainID("mars").WithKeyring(kr)}
+
+	t.Run("without address prefix", func(t *testing.T) {
+		f := newFaucet(Account("faucet", mnemonic, ""))
+		require.Error(t, f.useClientAccount(chainClient))
+	})
+
+	t.Run("without account", func(t *testing.T) {
+		f := newFaucet(AddressPrefix("mars"))
+		require.Error(t, f.useClientAccount(chainClient))
+	})
+
+	t.Run("with mnemonic", func(t *testing.T) {
+		f := newFaucet(Account("faucet", mnemonic, ""), AddressPrefix("mars"))
+		require.NoError(t, f.useClientAccount(chainClient))
+		require.Equal(t, "mars", f.chainID)
+		require.True(t, strings.HasPrefix(f.accountAddress, "mars1"))
+
+		info, err := kr.Key("faucet")
+		require.NoError(t, err)
+		require.Equal(t, sdk.AccAddress(info.GetAddress()).String(), mustBech32("cosmos", f.accountAddress))
+
+		// the account is used once imported.
+		again := newFaucet(AddressPrefix("mars"), ChainID("venus"))
+		require.NoError(t, again.useClientAccount(chainClient))
+		require.Equal(t, "venus", again.chainID)
+		require.Equal(t, f.accountAddress, again.accountAddress)
+	})
+}
+
+func mustBech32(prefix, address string) string {
+	_, bz, err := bech32.DecodeAndConvert(address)
+	if err != nil {
+		panic(err)
+	}
+	converted, err := bech32.ConvertAndEncode(prefix, bz)
+	if err != nil {
+		panic(err)
+	}
+	return converted
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"net/url"
 )
 
 // ErrTransferRequest is a error that occurs when a transfer request fails
@@ -20,12 +21,19 @@ func (err ErrTransferRequest) Error() string {
 
 // HTTPClient is a faucet client.
 type HTTPClient struct {
-	addr string
+	addr   string
+	apiKey string
 }
 
 // NewClient returns a new faucet client.
 func NewClient(addr string) HTTPClient {
-	return HTTPClient{addr}
+	return HTTPClient{addr: addr}
+}
+
+// WithAPIKey returns a copy of the client that authenticates its transfer requests with key.
+func (c HTTPClient) WithAPIKey(key string) HTTPClient {
+	c.apiKey = key
+	return c
 }
 
 // Transfer requests tokens from the faucet with req.
@@ -40,6 +48,10 @@ func (c HTTPClient) Transfer(ctx context.Context, req TransferRequest) (Transfer
 		return TransferResponse{}, err
 	}
 
+	if c.apiKey != "" {
+		hreq.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
 	hres, err := http.DefaultClient.Do(hreq)
 	if err != nil {
 		return TransferResponse{}, err
@@ -77,3 +89,49 @@ func (c HTTPClient) FaucetInfo(ctx context.Context) (FaucetInfoResponse, error)
 	err = json.NewDecoder(hres.Body).Decode(&res)
 	return res, err
 }
+
+// Challenge fetches a challenge to solve before requesting a transfer.
+func (c HTTPClient) Challenge(ctx context.Context) (Challenge, error) {
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.addr+"/challenge", nil)
+	if err != nil {
+		return Challenge{}, err
+	}
+
+	hres, err := http.DefaultClient.Do(hreq)
+	if err != nil {
+		return Challenge{}, err
+	}
+	defer hres.Body.Close()
+
+	if hres.StatusCode != http.StatusOK {
+		return Challenge{}, errors.New(http.StatusText(hres.StatusCode))
+	}
+
+	var res Challenge
+	err = json.NewDecoder(hres.Body).Decode(&res)
+	return res, err
+}
+
+// History fetches the transfers made by the faucet to address.
+func (c HTTPClient) History(ctx context.Context, address string) (HistoryResponse, error) {
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodGet, c.addr+"/history", nil)
+	if err != nil {
+		return HistoryResponse{}, err
+	}
+
+	hreq.URL.RawQuery = url.Values{"address": {address}}.Encode()
+
+	hres, err := http.DefaultClient.Do(hreq)
+	if err != nil {
+		return HistoryResponse{}, err
+	}
+	defer hres.Body.Close()
+
+	if hres.StatusCode != http.StatusOK {
+		return HistoryResponse{}, errors.New(http.StatusText(hres.StatusCode))
+	}
+
+	var res HistoryResponse
+	err = json.NewDecoder(hres.Body).Decode(&res)
+	return res, err
+}
@@ -0,0 +1,133 @@
+package cosmosfaucet_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/go-bip39"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmosfaucet"
+	cosmostestutilnode "github.com/ignite/cli/ignite/pkg/cosmostestutil/node"
+)
+
+// newChainClient creates a client to node for the chain with chainID and prefix, as the faucet commands do.
+func newChainClient(t *testing.T, node *cosmostestutilnode.Node, chainID, prefix string) cosmosclient.Client {
+	c, err := cosmosclient.New(
+		context.Background(),
+		cosmosclient.WithNodeAddress(node.URL),
+		cosmosclient.WithChainID(chainID),
+		cosmosclient.WithAddressPrefix(prefix),
+		cosmosclient.WithKeyringBackend(cosmosaccount.KeyringMemory),
+		cosmosclient.WithHome(t.TempDir()),
+		cosmosclient.WithAutoGasPrices(),
+	)
+	require.NoError(t, err)
+	return c
+}
+
+func newMnemonic(t *testing.T) string {
+	entropy, err := bip39.NewEntropy(256)
+	require.NoError(t, err)
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	require.NoError(t, err)
+	return mnemonic
+}
+
+// requireSent checks that a single tx that sends coins to address is broadcasted to node.
+func requireSent(t *testing.T, node *cosmostestutilnode.Node, client cosmosclient.Client, address string, coins sdk.Coins) {
+	broadcasted := node.Broadcasted()
+	require.Len(t, broadcasted, 1)
+
+	tx, err := client.Context().TxConfig.TxDecoder()(broadcasted[0])
+	require.NoError(t, err)
+	require.Len(t, tx.GetMsgs(), 1)
+
+	msg := tx.GetMsgs()[0].(*banktypes.MsgSend)
+	require.Equal(t, address, msg.ToAddress)
+	require.Equal(t, coins, msg.Amount)
+}
+
+func TestAdditionalChainWithAddressPrefix(t *testing.T) {
+	var (
+		ctx         = context.Background()
+		earthNode   = cosmostestutilnode.New(t)
+		marsNode    = cosmostestutilnode.New(t)
+		earthClient = newChainClient(t, earthNode, "earth-1", "earth")
+		marsClient  = newChainClient(t, marsNode, "mars-1", "mars")
+		dir         = t.TempDir()
+	)
+
+	f, err := cosmosfaucet.NewWithClient(
+		ctx,
+		earthClient.FaucetClient(),
+		cosmosfaucet.Account("faucet", newMnemonic(t), ""),
+		cosmosfaucet.AddressPrefix("earth"),
+		cosmosfaucet.Coin(10, 100, "token"),
+		cosmosfaucet.LedgerPath(filepath.Join(dir, "earth.db")),
+		cosmosfaucet.AdditionalChain(
+			marsClient.FaucetClient(),
+			cosmosfaucet.Account("faucet", newMnemonic(t), ""),
+			cosmosfaucet.AddressPrefix("mars"),
+			cosmosfaucet.Coin(5, 50, "umars"),
+			cosmosfaucet.LedgerPath(filepath.Join(dir, "mars.db")),
+		),
+	)
+	require.NoError(t, err)
+	defer f.Close()
+
+	address, err := bech32.ConvertAndEncode("mars", []byte("mars-account-address"))
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"address":"`+address+`","chain_id":"mars-1"}`))
+	w := httptest.NewRecorder()
+	f.ServeHTTP(w, r)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+
+	requireSent(t, marsNode, marsClient, address, sdk.NewCoins(sdk.NewInt64Coin("umars", 5)))
+	require.Empty(t, earthNode.Broadcasted())
+}
+
+func TestNewWithClientAddressPrefix(t *testing.T) {
+	var (
+		ctx    = context.Background()
+		node   = cosmostestutilnode.New(t)
+		client = newChainClient(t, node, "mars-1", "mars")
+	)
+
+	f, err := cosmosfaucet.NewWithClient(
+		ctx,
+		client.FaucetClient(),
+		cosmosfaucet.Account("faucet", newMnemonic(t), ""),
+		cosmosfaucet.AddressPrefix("mars"),
+		cosmosfaucet.Coin(10, 100, "umars"),
+		cosmosfaucet.LedgerPath(filepath.Join(t.TempDir(), "mars.db")),
+	)
+	require.NoError(t, err)
+	defer f.Close()
+
+	address, err := bech32.ConvertAndEncode("mars", []byte("mars-account-address"))
+	require.NoError(t, err)
+
+	coins := sdk.NewCoins(sdk.NewInt64Coin("umars", 10))
+	require.NoError(t, f.Transfer(ctx, address, coins))
+
+	requireSent(t, node, client, address, coins)
+
+	// the transfers are recorded by the normalized address.
+	transferred, err := f.TotalTransferredAmount(strings.ToUpper(address), "umars")
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), transferred)
+
+	err = f.Transfer(ctx, strings.ToUpper(address), sdk.NewCoins(sdk.NewInt64Coin("umars", 100)))
+	require.ErrorContains(t, err, "account is reaching to the limit")
+}
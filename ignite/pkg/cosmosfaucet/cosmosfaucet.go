@@ -3,11 +3,18 @@ package cosmosfaucet
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pkg/errors"
 
+	"github.com/ignite/cli/ignite/chainconfig"
 	chaincmdrunner "github.com/ignite/cli/ignite/pkg/chaincmd/runner"
+	"github.com/ignite/cli/ignite/pkg/cosmosutil"
+	"github.com/ignite/cli/ignite/pkg/xfilepath"
 )
 
 const (
@@ -30,11 +37,37 @@ const (
 	DefaultRefreshWindow = time.Hour * 24 * 365
 )
 
+// defaultLedgerDir is the directory of the ledgers of the faucets that no ledger path is set for.
+var defaultLedgerDir = xfilepath.Join(
+	chainconfig.ConfigDirPath,
+	xfilepath.Path("faucet"),
+)
+
 // Faucet represents a faucet.
 type Faucet struct {
-	// runner used to intereact with blockchain's binary to transfer tokens.
+	// runner used to intereact with blockchain's binary.
 	runner chaincmdrunner.Runner
 
+	// pool holds the accounts that sign and broadcast the transfers in-process.
+	pool *pool
+
+	// poolMnemonic and poolSize configure the pool of accounts that the transfers are sent from.
+	poolMnemonic string
+	poolSize     int
+
+	// refillThreshold and refillAmount configure the refills of the pool accounts from the faucet account.
+	refillThreshold sdk.Coins
+	refillAmount    sdk.Coins
+
+	// pending keeps the transfers that are not completed yet.
+	pending *pendingTransfers
+
+	// ledger records the transfers made by the faucet.
+	ledger Ledger
+
+	// ledgerPath is the path of the ledger's database file.
+	ledgerPath string
+
 	// chainID is the chain id of the chain that faucet is operating for.
 	chainID string
 
@@ -44,6 +77,12 @@ type Faucet struct {
 	// accountMnemonic is the mnemonic of the account.
 	accountMnemonic string
 
+	// accountAddress is the address of the account.
+	accountAddress string
+
+	// addressPrefix is the bech32 prefix of the chain's account addresses.
+	addressPrefix string
+
 	// coinType registered coin type number for HD derivation (BIP-0044).
 	coinType string
 
@@ -56,6 +95,45 @@ type Faucet struct {
 
 	limitRefreshWindow time.Duration
 
+	// ipLimiter and globalLimiter limit the transfer requests by client IPs and in total.
+	ipLimiter     *rateLimiter
+	globalLimiter *rateLimiter
+
+	// rateLimitWindow is the duration that the request counts are kept for.
+	rateLimitWindow time.Duration
+
+	// ipHeader is the header to read the client IPs from.
+	ipHeader string
+
+	// trustedProxies is the number of proxies in front of the faucet that add the client IPs to ipHeader.
+	trustedProxies int
+
+	// verifier verifies the transfer requests that aren't made with an API key.
+	verifier Verifier
+
+	// tiers are the tiers of quotas granted by API keys.
+	tiers []apiKeyTier
+
+	// allowedAddresses and deniedAddresses are the allow and deny lists of addresses.
+	allowedAddresses map[string]bool
+	deniedAddresses  map[string]bool
+
+	// metrics are the Prometheus metrics of the faucet.
+	metrics *metrics
+
+	// requestLog logs the transfer requests when requestLogPath is set.
+	requestLog     *requestLog
+	requestLogPath string
+
+	// denomAliases maps the aliases of denoms, e.g. of IBC denoms, to their denoms.
+	denomAliases map[string]string
+
+	// additionalChains are the options of the other chains served by the faucet.
+	additionalChains []chainOptions
+
+	// chains are the other chains served by the faucet by chain ids.
+	chains map[string]*Faucet
+
 	// openAPIData holds template data customizations for serving OpenAPI page & spec.
 	openAPIData openAPIData
 }
@@ -86,6 +164,27 @@ func Coin(amount, maxAmount uint64, denom string) Option {
 	}
 }
 
+// AccountPool sends the transfers from a pool of size accounts derived from mnemonic, instead of
+// the faucet account, to not depend on the sequence and the balance of a single account.
+// the accounts are derived at the address indexes from 1 to size of the HD path and imported in
+// the keyring as <account name>-<index>. the mnemonic of the faucet account is used when mnemonic
+// is empty. the faucet account is the treasury that refills the pool accounts, see Refill().
+func AccountPool(mnemonic string, size int) Option {
+	return func(f *Faucet) {
+		f.poolMnemonic = mnemonic
+		f.poolSize = size
+	}
+}
+
+// Refill sends amount from the faucet account to the pool accounts whose balance of any coin
+// drops under threshold.
+func Refill(threshold, amount sdk.Coins) Option {
+	return func(f *Faucet) {
+		f.refillThreshold = threshold
+		f.refillAmount = amount
+	}
+}
+
 // RefreshWindow adds the duration to refresh the transfer limit to the faucet
 func RefreshWindow(refreshWindow time.Duration) Option {
 	return func(f *Faucet) {
@@ -100,33 +199,98 @@ func ChainID(id string) Option {
 	}
 }
 
-// OpenAPI configures how to serve Open API page and and spec.
-func OpenAPI(apiAddress string) Option {
+// RateLimit limits the number of transfer requests that a single IP (perIP) and all clients (global)
+// can make within window. There is no limit when perIP or global is 0.
+func RateLimit(perIP, global int, window time.Duration) Option {
 	return func(f *Faucet) {
-		f.openAPIData.APIAddress = apiAddress
+		f.ipLimiter = newRateLimiter(perIP, window)
+		f.globalLimiter = newRateLimiter(global, window)
+		f.rateLimitWindow = window
 	}
 }
 
-// New creates a new faucet with ccr (to access and use blockchain's CLI) and given options.
-func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Faucet, error) {
-	f := Faucet{
-		runner:      ccr,
-		accountName: DefaultAccountName,
-		coinsMax:    make(map[string]uint64),
-		openAPIData: openAPIData{"Blockchain", "http://localhost:1317"},
+// ClientIPHeader sets the header to read the client IPs from when the faucet is behind a proxy,
+// e.g. X-Forwarded-For. The IP is the right-most one of the header, the one added by the proxy,
+// see TrustedProxies() when there are several proxies. Without header, the IP of the connection is used.
+func ClientIPHeader(header string) Option {
+	return func(f *Faucet) {
+		f.ipHeader = header
 	}
+}
 
-	for _, apply := range options {
-		apply(&f)
+// TrustedProxies sets the number of proxies in front of the faucet that add the client IPs to
+// the header set with ClientIPHeader(). The IP added by the first of them is used, 1 by default.
+func TrustedProxies(count int) Option {
+	return func(f *Faucet) {
+		f.trustedProxies = count
 	}
+}
 
-	if len(f.coins) == 0 {
-		Coin(DefaultAmount, DefaultMaxAmount, DefaultDenom)(&f)
+// RequestVerifier sets the verifier to verify the transfer requests that aren't made with an API key.
+func RequestVerifier(v Verifier) Option {
+	return func(f *Faucet) {
+		f.verifier = v
 	}
+}
 
-	if f.limitRefreshWindow == 0 {
-		RefreshWindow(DefaultRefreshWindow)(&f)
+// APIKey grants the quotas of tier to the clients that send key as a bearer token in the
+// Authorization header. Requests made with an API key aren't verified and only the rate
+// limit of the tier applies to them.
+func APIKey(key string, tier Tier) Option {
+	return func(f *Faucet) {
+		f.tiers = append(f.tiers, apiKeyTier{key, &tier})
+	}
+}
+
+// AllowAddresses restricts the addresses that can receive tokens to addresses.
+func AllowAddresses(addresses ...string) Option {
+	return func(f *Faucet) {
+		for _, address := range addresses {
+			f.allowedAddresses[strings.ToLower(address)] = true
+		}
+	}
+}
+
+// DenyAddresses prevents addresses from receiving tokens.
+func DenyAddresses(addresses ...string) Option {
+	return func(f *Faucet) {
+		for _, address := range addresses {
+			f.deniedAddresses[strings.ToLower(address)] = true
+		}
 	}
+}
+
+// LedgerPath sets the path of the database file to record the transfers in.
+// by default, transfers are recorded in the faucet directory of Ignite's config directory, as <chain-id>.db.
+// the ledger is initialized with the past transfers of the faucet account
+// found on the chain when the database doesn't exist.
+func LedgerPath(path string) Option {
+	return func(f *Faucet) {
+		f.ledgerPath = path
+	}
+}
+
+// RequestLogPath sets the path of a file to log the transfer requests in as JSON lines.
+// requests aren't logged by default.
+func RequestLogPath(path string) Option {
+	return func(f *Faucet) {
+		f.requestLogPath = path
+	}
+}
+
+// OpenAPI configures how to serve Open API page and and spec.
+func OpenAPI(apiAddress string) Option {
+	return func(f *Faucet) {
+		f.openAPIData.APIAddress = apiAddress
+	}
+}
+
+// New creates a new faucet with ccr (to access and use blockchain's CLI), client (to sign and
+// broadcast transfers) and given options.
+// the faucet account must be in the keyring of the client.
+func New(ctx context.Context, ccr chaincmdrunner.Runner, client ChainClient, options ...Option) (Faucet, error) {
+	f := newFaucet(options...)
+	f.runner = ccr
 
 	// import the account if mnemonic is provided.
 	if f.accountMnemonic != "" {
@@ -143,8 +307,182 @@ func New(ctx context.Context, ccr chaincmdrunner.Runner, options ...Option) (Fau
 		}
 
 		f.chainID = status.ChainID
-		f.openAPIData.ChainID = status.ChainID
+	}
+
+	account, err := f.runner.ShowAccount(ctx, f.accountName)
+	if err != nil {
+		return Faucet{}, err
+	}
+
+	f.accountAddress = account.Address
+
+	if f.addressPrefix, err = cosmosutil.GetAddressPrefix(account.Address); err != nil {
+		return Faucet{}, err
+	}
+
+	return f.start(ctx, client)
+}
+
+// NewWithClient creates a new faucet for the chain that client connects to, without the chain's binary.
+// the faucet account is imported in the keyring of the client when its mnemonic is provided,
+// otherwise it must be in the keyring. The address prefix of the chain is required, see AddressPrefix().
+func NewWithClient(ctx context.Context, client ChainClient, options ...Option) (Faucet, error) {
+	f := newFaucet(options...)
+
+	if err := f.useClientAccount(client); err != nil {
+		return Faucet{}, err
+	}
+
+	return f.start(ctx, client)
+}
+
+// start sets up the faucet and the additional chains once the chain and the account of the faucet are known.
+func (f Faucet) start(ctx context.Context, client ChainClient) (Faucet, error) {
+	f.metrics = newMetrics()
+	f.openAPIData.ChainID = f.chainID
+
+	for _, t := range f.tiers {
+		t.tier.limiter = newRateLimiter(t.tier.RateLimit, f.rateLimitWindow)
+	}
+
+	if f.requestLogPath != "" {
+		var err error
+		if f.requestLog, err = newRequestLog(f.requestLogPath); err != nil {
+			return Faucet{}, err
+		}
+	}
+
+	if err := f.init(ctx, client); err != nil {
+		return Faucet{}, err
+	}
+
+	for _, c := range f.additionalChains {
+		chain, err := f.newAdditionalChain(ctx, c.client, c.options...)
+		if err != nil {
+			f.Close()
+			return Faucet{}, err
+		}
+
+		if _, ok := f.chains[chain.chainID]; ok || chain.chainID == f.chainID {
+			chain.Close()
+			f.Close()
+			return Faucet{}, fmt.Errorf("chain %s is served more than once", chain.chainID)
+		}
+
+		f.chains[chain.chainID] = chain
 	}
 
 	return f, nil
 }
+
+// Close closes the ledgers of the faucet and of its additional chains.
+func (f Faucet) Close() error {
+	err := f.ledger.Close()
+
+	for _, chain := range f.chains {
+		if cerr := chain.Close(); err == nil {
+			err = cerr
+		}
+	}
+
+	return err
+}
+
+// newFaucet creates a faucet with the default values and applies options.
+func newFaucet(options ...Option) Faucet {
+	f := Faucet{
+		pending:          newPendingTransfers(),
+		accountName:      DefaultAccountName,
+		coinsMax:         make(map[string]uint64),
+		rateLimitWindow:  DefaultRateLimitWindow,
+		allowedAddresses: make(map[string]bool),
+		deniedAddresses:  make(map[string]bool),
+		denomAliases:     make(map[string]string),
+		chains:           make(map[string]*Faucet),
+		openAPIData:      openAPIData{"Blockchain", "http://localhost:1317"},
+	}
+
+	for _, apply := range options {
+		apply(&f)
+	}
+
+	if len(f.coins) == 0 {
+		Coin(DefaultAmount, DefaultMaxAmount, DefaultDenom)(&f)
+	}
+
+	if f.limitRefreshWindow == 0 {
+		RefreshWindow(DefaultRefreshWindow)(&f)
+	}
+
+	f.resolveCoinDenoms()
+
+	return f
+}
+
+// init sets up the accounts and the ledger of the faucet once its chain and account are known.
+// the ledger is closed when the faucet cannot be set up.
+func (f *Faucet) init(ctx context.Context, client ChainClient) (err error) {
+	if f.ledgerPath == "" {
+		dir, err := defaultLedgerDir()
+		if err != nil {
+			return err
+		}
+		f.ledgerPath = filepath.Join(dir, f.chainID+".db")
+	}
+
+	if f.ledger, err = NewLedger(f.ledgerPath); err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f.ledger.Close()
+		}
+	}()
+
+	if f.pool, err = f.newPool(client); err != nil {
+		return err
+	}
+
+	f.metrics.registerBalances(f.pool.accounts()...)
+
+	if err := f.initLedger(ctx, client); err != nil {
+		return err
+	}
+
+	// pool accounts are refilled at start in case they were emptied while the faucet was down.
+	for _, s := range f.pool.senders {
+		f.pool.refill(s)
+	}
+
+	return nil
+}
+
+// newPool creates the pool of accounts to send the transfers from.
+func (f Faucet) newPool(client ChainClient) (*pool, error) {
+	faucetSender := newSender(client, f.accountName, f.accountAddress, f.chainID, f.metrics)
+
+	if f.poolSize == 0 {
+		return newPool(nil, []*sender{faucetSender}, nil, nil, f.ledger), nil
+	}
+
+	mnemonic := f.poolMnemonic
+	if mnemonic == "" {
+		mnemonic = f.accountMnemonic
+	}
+	if mnemonic == "" {
+		return nil, errors.New("a mnemonic is required to derive the pool accounts")
+	}
+
+	accounts, err := importPoolAccounts(client.Context().Keyring, f.accountName, mnemonic, f.coinType, f.addressPrefix, f.poolSize)
+	if err != nil {
+		return nil, err
+	}
+
+	var senders []*sender
+	for _, account := range accounts {
+		senders = append(senders, newSender(client, account.name, account.address, f.chainID, f.metrics))
+	}
+
+	return newPool(faucetSender, senders, f.refillThreshold, f.refillAmount, f.ledger), nil
+}
@@ -20,6 +20,15 @@ func (f Faucet) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	router.Handle("/info", cors.Default().Handler(http.HandlerFunc(f.faucetInfoHandler))).
 		Methods(http.MethodGet)
 
+	router.Handle("/challenge", cors.Default().Handler(http.HandlerFunc(f.challengeHandler))).
+		Methods(http.MethodGet)
+
+	router.Handle("/history", cors.Default().Handler(http.HandlerFunc(f.historyHandler))).
+		Methods(http.MethodGet)
+
+	router.Handle("/metrics", f.metrics.handler()).
+		Methods(http.MethodGet)
+
 	router.HandleFunc("/", openapiconsole.Handler("Faucet", "openapi.yml")).
 		Methods(http.MethodGet)
 
@@ -3,7 +3,12 @@ package cosmosfaucet
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
@@ -17,6 +22,14 @@ type TransferRequest struct {
 	// Coins that are requested.
 	// default ones used when this one isn't provided.
 	Coins []string `json:"coins"`
+
+	// ChainID is the id of the chain to request coins on.
+	// the default chain of the faucet is used when it isn't provided.
+	ChainID string `json:"chain_id,omitempty"`
+
+	// Verification is the solution of the faucet's challenge.
+	// it is required when the faucet verifies requests and no API key is used.
+	Verification *Verification `json:"verification,omitempty"`
 }
 
 func NewTransferRequest(accountAddress string, coins []string) TransferRequest {
@@ -31,30 +44,142 @@ type TransferResponse struct {
 }
 
 func (f Faucet) faucetHandler(w http.ResponseWriter, r *http.Request) {
+	entry := RequestLogEntry{
+		Time: time.Now(),
+		IP:   clientIP(r, f.ipHeader, f.trustedProxies),
+	}
+
+	code, err := f.handleTransfer(r, &entry)
+
+	// nothing is sent when the client is gone.
+	switch {
+	case code == 0:
+	case code == http.StatusTooManyRequests:
+		responseRateLimited(w, err)
+	case err != nil:
+		responseError(w, code, err)
+	default:
+		responseSuccess(w)
+	}
+
+	entry.Status = code
+	entry.Outcome = outcome(code)
+	entry.DurationMS = time.Since(entry.Time).Milliseconds()
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	f.metrics.request(entry.Outcome)
+
+	if err := f.requestLog.write(entry); err != nil {
+		log.Printf("cannot log faucet request: %s", err)
+	}
+}
+
+// handleTransfer performs the transfer request r and returns the status code of the response
+// with the error to send. the status code is 0 when the request is canceled.
+// the request details are set to entry.
+func (f Faucet) handleTransfer(r *http.Request, entry *RequestLogEntry) (code int, err error) {
 	var req TransferRequest
 
 	// decode request into req.
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		responseError(w, http.StatusBadRequest, err)
-		return
+		return http.StatusBadRequest, err
+	}
+
+	entry.Address = req.AccountAddress
+	entry.Coins = req.Coins
+
+	chain, err := f.chain(req.ChainID)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	address, err := chain.validateAddress(req.AccountAddress)
+	if err != nil {
+		return http.StatusBadRequest, err
+	}
+	entry.Address = address
+
+	if !f.isAddressAllowed(address) {
+		f.metrics.rejected(limitAddress)
+		return http.StatusForbidden, ErrAddressNotAllowed
+	}
+
+	// requests made with an API key only have the limits of their tier.
+	tier, err := f.tier(r)
+	if err != nil {
+		return http.StatusUnauthorized, err
+	}
+
+	coinsMax := chain.coinsMax
+
+	if tier != nil {
+		entry.Tier = tier.Name
+
+		if err := tier.limiter.allow(tier.Name); err != nil {
+			f.metrics.rejected(limitAPIKey)
+			return http.StatusTooManyRequests, err
+		}
+
+		coinsMax = tier.coinsMax(chain.coinsMax)
+	} else {
+		if err := f.ipLimiter.allow(entry.IP); err != nil {
+			f.metrics.rejected(limitIP)
+			return http.StatusTooManyRequests, err
+		}
+
+		if err := f.globalLimiter.allow(""); err != nil {
+			f.metrics.rejected(limitGlobal)
+			return http.StatusTooManyRequests, err
+		}
+
+		if f.verifier != nil {
+			var v Verification
+			if req.Verification != nil {
+				v = *req.Verification
+			}
+
+			if err := f.verifier.Verify(r.Context(), address, entry.IP, v); err != nil {
+				if errors.Is(err, ErrVerificationFailed) {
+					f.metrics.rejected(limitVerification)
+					return http.StatusForbidden, err
+				}
+				return http.StatusInternalServerError, err
+			}
+		}
 	}
 
 	// determine coins to transfer.
-	coins, err := f.coinsFromRequest(req)
+	coins, err := chain.coinsFromRequest(req)
 	if err != nil {
-		responseError(w, http.StatusBadRequest, err)
-		return
+		return http.StatusBadRequest, err
 	}
 
 	// try performing the transfer
-	if err := f.Transfer(r.Context(), req.AccountAddress, coins); err != nil {
+	if err := chain.transfer(r.Context(), address, coins, coinsMax); err != nil {
 		if err == context.Canceled {
-			return
+			return 0, err
 		}
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+func (f Faucet) challengeHandler(w http.ResponseWriter, _ *http.Request) {
+	if f.verifier == nil {
+		responseError(w, http.StatusNotFound, errors.New("faucet doesn't verify requests"))
+		return
+	}
+
+	challenge, err := f.verifier.Challenge()
+	if err != nil {
 		responseError(w, http.StatusInternalServerError, err)
-	} else {
-		responseSuccess(w)
+		return
 	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, challenge)
 }
 
 // FaucetInfoResponse is the faucet info payload.
@@ -63,15 +188,44 @@ type FaucetInfoResponse struct {
 	// useful for auto discoveries.
 	IsAFaucet bool `json:"is_a_faucet"`
 
-	// ChainID is chain id of the chain that faucet is running for.
+	// ChainID is chain id of the default chain that faucet is running for.
 	ChainID string `json:"chain_id"`
+
+	// Chains are the chains served by the faucet with their coins.
+	Chains []ChainInfo `json:"chains"`
+
+	// Verifier is the type of the verifier when the faucet verifies requests.
+	// challenges are served by the /challenge endpoint.
+	Verifier string `json:"verifier,omitempty"`
+
+	// Accounts are the accounts of the faucet with their health.
+	Accounts []AccountInfo `json:"accounts"`
 }
 
 func (f Faucet) faucetInfoHandler(w http.ResponseWriter, r *http.Request) {
-	xhttp.ResponseJSON(w, http.StatusOK, FaucetInfoResponse{
+	info := FaucetInfoResponse{
 		IsAFaucet: true,
 		ChainID:   f.chainID,
-	})
+	}
+
+	if f.verifier != nil {
+		challenge, err := f.verifier.Challenge()
+		if err != nil {
+			responseError(w, http.StatusInternalServerError, err)
+			return
+		}
+		info.Verifier = challenge.Type
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), queryTimeout)
+	defer cancel()
+
+	for _, chain := range f.allChains() {
+		info.Chains = append(info.Chains, chain.chainInfo())
+		info.Accounts = append(info.Accounts, chain.accountsInfo(ctx)...)
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, info)
 }
 
 // coinsFromRequest determines tokens to transfer from transfer request.
@@ -86,6 +240,7 @@ func (f Faucet) coinsFromRequest(req TransferRequest) (sdk.Coins, error) {
 		if err != nil {
 			return nil, err
 		}
+		coin.Denom = f.resolveDenom(coin.Denom)
 		coins = append(coins, coin)
 	}
 
@@ -96,6 +251,14 @@ func responseSuccess(w http.ResponseWriter) {
 	xhttp.ResponseJSON(w, http.StatusOK, TransferResponse{})
 }
 
+func responseRateLimited(w http.ResponseWriter, err error) {
+	var rateErr ErrRateLimited
+	if errors.As(err, &rateErr) {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(rateErr.RetryAfter.Seconds()))))
+	}
+	responseError(w, http.StatusTooManyRequests, err)
+}
+
 func responseError(w http.ResponseWriter, code int, err error) {
 	xhttp.ResponseJSON(w, code, TransferResponse{
 		Error: err.Error(),
@@ -0,0 +1,91 @@
+package cosmosfaucet
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFaucetHandlerAccess(t *testing.T) {
+	newFaucet := func(options ...Option) Faucet {
+		f := Faucet{
+			addressPrefix:    "cosmos",
+			allowedAddresses: make(map[string]bool),
+			deniedAddresses:  make(map[string]bool),
+			rateLimitWindow:  time.Hour,
+		}
+		for _, apply := range options {
+			apply(&f)
+		}
+		for _, t := range f.tiers {
+			t.tier.limiter = newRateLimiter(t.tier.RateLimit, f.rateLimitWindow)
+		}
+		return f
+	}
+
+	request := func(f Faucet, body, apiKey string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		r.RemoteAddr = "1.2.3.4:1234"
+		if apiKey != "" {
+			r.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+		w := httptest.NewRecorder()
+		f.faucetHandler(w, r)
+		return w
+	}
+
+	body := `{"address":"` + testAddress + `","coins":["invalid"]}`
+
+	t.Run("denied address", func(t *testing.T) {
+		f := newFaucet(DenyAddresses(testAddress))
+		require.Equal(t, http.StatusForbidden, request(f, body, "").Code)
+	})
+
+	t.Run("denied address in uppercase", func(t *testing.T) {
+		f := newFaucet(DenyAddresses(testAddress))
+		body := `{"address":"` + strings.ToUpper(testAddress) + `","coins":["invalid"]}`
+		require.Equal(t, http.StatusForbidden, request(f, body, "").Code)
+	})
+
+	t.Run("address not in the allow list", func(t *testing.T) {
+		f := newFaucet(AllowAddresses("cosmos1mmlqwyqk7neqegffp99q86eckpm4pjah3ytlpa"))
+		require.Equal(t, http.StatusForbidden, request(f, body, "").Code)
+	})
+
+	t.Run("invalid api key", func(t *testing.T) {
+		f := newFaucet(APIKey("key", Tier{Name: "ci"}))
+		require.Equal(t, http.StatusUnauthorized, request(f, body, "other").Code)
+	})
+
+	t.Run("rate limited by ip", func(t *testing.T) {
+		f := newFaucet(RateLimit(1, 0, time.Hour))
+		require.Equal(t, http.StatusBadRequest, request(f, body, "").Code)
+
+		w := request(f, body, "")
+		require.Equal(t, http.StatusTooManyRequests, w.Code)
+		require.Equal(t, "3600", w.Header().Get("Retry-After"))
+	})
+
+	t.Run("api key tier skips the public limits", func(t *testing.T) {
+		f := newFaucet(
+			RateLimit(0, 1, time.Hour),
+			RequestVerifier(NewTestVerifier("token")),
+			APIKey("key", Tier{Name: "ci", RateLimit: 2}),
+		)
+		require.Equal(t, http.StatusBadRequest, request(f, body, "key").Code)
+		require.Equal(t, http.StatusBadRequest, request(f, body, "key").Code)
+		require.Equal(t, http.StatusTooManyRequests, request(f, body, "key").Code)
+	})
+
+	t.Run("verification", func(t *testing.T) {
+		f := newFaucet(RequestVerifier(NewTestVerifier("token")))
+		require.Equal(t, http.StatusForbidden, request(f, body, "").Code)
+
+		verified := `{"address":"` + testAddress + `","coins":["invalid"],"verification":{"solution":"token"}}`
+		require.Equal(t, http.StatusBadRequest, request(f, verified, "").Code)
+	})
+}
@@ -0,0 +1,68 @@
+package cosmosfaucet
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ignite/cli/ignite/pkg/xhttp"
+)
+
+// HistoryResponse is the payload of the transfers made to an account.
+type HistoryResponse struct {
+	// Address is the address of the account.
+	Address string `json:"address"`
+
+	// Transfers are the transfers made to the account ordered by their time.
+	Transfers []LedgerEntry `json:"transfers"`
+
+	// Total is the total amount transferred to the account within the limit refresh window.
+	Total sdk.Coins `json:"total"`
+
+	// RefreshWindow is the duration after which the transferred amounts stop counting towards the limits.
+	RefreshWindow string `json:"refresh_window"`
+}
+
+func (f Faucet) historyHandler(w http.ResponseWriter, r *http.Request) {
+	address := r.URL.Query().Get("address")
+	if address == "" {
+		responseError(w, http.StatusBadRequest, errors.New("address is required"))
+		return
+	}
+
+	chain, err := f.chain(r.URL.Query().Get("chain_id"))
+	if err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if address, err = chain.validateAddress(address); err != nil {
+		responseError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	transfers, err := chain.ledger.History(address)
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	total, err := chain.ledger.Transferred(address, time.Now().Add(-chain.limitRefreshWindow))
+	if err != nil {
+		responseError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	if transfers == nil {
+		transfers = []LedgerEntry{}
+	}
+
+	xhttp.ResponseJSON(w, http.StatusOK, HistoryResponse{
+		Address:       address,
+		Transfers:     transfers,
+		Total:         total,
+		RefreshWindow: chain.limitRefreshWindow.String(),
+	})
+}
@@ -0,0 +1,375 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/pkg/errors"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	bolt "go.etcd.io/bbolt"
+)
+
+// ledgerLockTimeout is the max. duration to wait for the lock of the ledger's database
+// when it is opened by another faucet.
+const ledgerLockTimeout = time.Second
+
+var (
+	// ErrLedgerInUse is returned when the ledger is opened by another faucet.
+	ErrLedgerInUse = errors.New("ledger is used by another faucet")
+
+	// ledgerTransfersBucket holds a nested bucket of transfers for each account address.
+	ledgerTransfersBucket = []byte("transfers")
+
+	// ledgerRefillsBucket holds a nested bucket of refills from the treasury for each account address
+	// of the pool, they are kept apart from the transfers to not count against the max. amounts.
+	ledgerRefillsBucket = []byte("refills")
+
+	// ledgerMetaBucket holds the state of the ledger.
+	ledgerMetaBucket = []byte("meta")
+
+	// ledgerInitializedKey is set once the ledger is initialized with the past transfers.
+	ledgerInitializedKey = []byte("initialized")
+)
+
+// LedgerEntry is a transfer recorded in the ledger.
+type LedgerEntry struct {
+	// Address is the address of the account that received the coins.
+	Address string `json:"address"`
+
+	// Coins are the transferred coins.
+	Coins sdk.Coins `json:"coins"`
+
+	// TxHash is the hash of the tx that transferred the coins.
+	TxHash string `json:"tx_hash"`
+
+	// Time is the time of the transfer.
+	Time time.Time `json:"time"`
+}
+
+// Ledger is a persistent record of the transfers made by the faucet, stored in a bbolt database.
+// The database is locked by the ledger until it is closed. The transfers are recorded by the account
+// addresses in lowercase, as bech32 addresses are case insensitive.
+type Ledger struct {
+	db *bolt.DB
+}
+
+// NewLedger opens the ledger that is stored in the database file at path.
+// ErrLedgerInUse is returned when the ledger is opened by another faucet.
+func NewLedger(path string) (Ledger, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return Ledger{}, err
+	}
+
+	db, err := bolt.Open(path, 0o640, &bolt.Options{Timeout: ledgerLockTimeout})
+	if err == bolt.ErrTimeout {
+		return Ledger{}, errors.Wrap(ErrLedgerInUse, path)
+	}
+	if err != nil {
+		return Ledger{}, err
+	}
+
+	return Ledger{db}, nil
+}
+
+// Close closes the database of the ledger.
+func (l Ledger) Close() error {
+	if l.db == nil {
+		return nil
+	}
+	return l.db.Close()
+}
+
+// IsInitialized checks if the ledger is initialized with the past transfers.
+func (l Ledger) IsInitialized() (initialized bool, err error) {
+	err = l.db.View(func(tx *bolt.Tx) error {
+		if b := tx.Bucket(ledgerMetaBucket); b != nil {
+			initialized = b.Get(ledgerInitializedKey) != nil
+		}
+		return nil
+	})
+
+	return initialized, err
+}
+
+// Initialize records the past transfers and refills, and marks the ledger as initialized.
+func (l Ledger) Initialize(transfers, refills []LedgerEntry) error {
+	return l.db.Update(func(tx *bolt.Tx) error {
+		if err := putLedgerEntries(tx, ledgerTransfersBucket, transfers); err != nil {
+			return err
+		}
+
+		if err := putLedgerEntries(tx, ledgerRefillsBucket, refills); err != nil {
+			return err
+		}
+
+		b, err := tx.CreateBucketIfNotExists(ledgerMetaBucket)
+		if err != nil {
+			return err
+		}
+
+		return b.Put(ledgerInitializedKey, []byte{1})
+	})
+}
+
+// Add records transfers.
+func (l Ledger) Add(entries ...LedgerEntry) error {
+	return l.db.Update(func(tx *bolt.Tx) error {
+		return putLedgerEntries(tx, ledgerTransfersBucket, entries)
+	})
+}
+
+// AddRefills records refills of the pool accounts from the treasury.
+func (l Ledger) AddRefills(entries ...LedgerEntry) error {
+	return l.db.Update(func(tx *bolt.Tx) error {
+		return putLedgerEntries(tx, ledgerRefillsBucket, entries)
+	})
+}
+
+// History returns the transfers to address ordered by their time.
+func (l Ledger) History(address string) ([]LedgerEntry, error) {
+	return l.entries(ledgerTransfersBucket, address, time.Time{})
+}
+
+// Refills returns the refills of the pool account with address ordered by their time.
+func (l Ledger) Refills(address string) ([]LedgerEntry, error) {
+	return l.entries(ledgerRefillsBucket, address, time.Time{})
+}
+
+// Transferred returns the total amount of coins transferred to address since the given time.
+func (l Ledger) Transferred(address string, since time.Time) (sdk.Coins, error) {
+	entries, err := l.entries(ledgerTransfersBucket, address, since)
+	if err != nil {
+		return nil, err
+	}
+
+	var total sdk.Coins
+	for _, e := range entries {
+		total = total.Add(e.Coins...)
+	}
+
+	return total, nil
+}
+
+// entries returns the entries of bucket to address since the given time ordered by their time.
+func (l Ledger) entries(bucket []byte, address string, since time.Time) ([]LedgerEntry, error) {
+	var entries []LedgerEntry
+
+	err := l.db.View(func(tx *bolt.Tx) error {
+		addresses := tx.Bucket(bucket)
+		if addresses == nil {
+			return nil
+		}
+
+		b := addresses.Bucket(ledgerAddressKey(address))
+		if b == nil {
+			return nil
+		}
+
+		// keys start with the time of the transfers, so the ones before since are skipped.
+		c := b.Cursor()
+		for k, v := c.Seek(ledgerTimeKey(since)); k != nil; k, v = c.Next() {
+			var e LedgerEntry
+			if err := json.Unmarshal(v, &e); err != nil {
+				return err
+			}
+			entries = append(entries, e)
+		}
+
+		return nil
+	})
+
+	return entries, err
+}
+
+func putLedgerEntries(tx *bolt.Tx, bucket []byte, entries []LedgerEntry) error {
+	addresses, err := tx.CreateBucketIfNotExists(bucket)
+	if err != nil {
+		return err
+	}
+
+	for _, e := range entries {
+		b, err := addresses.CreateBucketIfNotExists(ledgerAddressKey(e.Address))
+		if err != nil {
+			return err
+		}
+
+		value, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+
+		// the sequence makes the keys of the transfers made at the same time unique.
+		seq, err := b.NextSequence()
+		if err != nil {
+			return err
+		}
+
+		key := make([]byte, 16)
+		copy(key, ledgerTimeKey(e.Time))
+		binary.BigEndian.PutUint64(key[8:], seq)
+
+		if err := b.Put(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ledgerAddressKey returns the key of the bucket of the transfers to address.
+func ledgerAddressKey(address string) []byte {
+	return []byte(strings.ToLower(address))
+}
+
+// ledgerTimeKey returns a key that sorts the transfers by time.
+func ledgerTimeKey(t time.Time) []byte {
+	var nanos uint64
+	if !t.IsZero() && t.UnixNano() > 0 {
+		nanos = uint64(t.UnixNano())
+	}
+
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, nanos)
+
+	return key
+}
+
+// initLedger initializes the ledger with the past transfers of the faucet accounts
+// when it isn't initialized yet, e.g. when the faucet is started for the first time.
+// the transfers between the faucet accounts are the refills of the pool accounts.
+func (f Faucet) initLedger(ctx context.Context, client ChainClient) error {
+	initialized, err := f.ledger.IsInitialized()
+	if err != nil || initialized {
+		return err
+	}
+
+	node, err := client.Context().GetNode()
+	if err != nil {
+		return err
+	}
+
+	var (
+		transfers, refills []LedgerEntry
+		blockTimes         = make(map[int64]time.Time)
+		accounts           = make(map[string]bool)
+	)
+
+	for _, account := range f.pool.accounts() {
+		accounts[account.address] = true
+	}
+
+	for _, account := range f.pool.accounts() {
+		entries, err := pastTransfers(ctx, node, account.address, blockTimes)
+		if err != nil {
+			return err
+		}
+
+		for _, e := range entries {
+			if accounts[e.Address] {
+				refills = append(refills, e)
+			} else {
+				transfers = append(transfers, e)
+			}
+		}
+	}
+
+	return f.ledger.Initialize(transfers, refills)
+}
+
+// pastTransfers returns the transfers sent from address found on the chain.
+// blockTimes caches the times of the blocks.
+func pastTransfers(ctx context.Context, node rpcclient.Client, address string, blockTimes map[int64]time.Time) ([]LedgerEntry, error) {
+	var (
+		entries []LedgerEntry
+		query   = fmt.Sprintf("message.sender='%s'", address)
+		perPage = 100
+	)
+
+	for page := 1; ; page++ {
+		res, err := node.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot query the past transfers of the faucet")
+		}
+
+		for _, tx := range res.Txs {
+			if tx.TxResult.Code != 0 {
+				continue
+			}
+
+			blockTime, ok := blockTimes[tx.Height]
+			if !ok {
+				block, err := node.Block(ctx, &tx.Height)
+				if err != nil {
+					return nil, err
+				}
+				blockTime = block.Block.Time
+				blockTimes[tx.Height] = blockTime
+			}
+
+			logs, err := sdk.ParseABCILogs(tx.TxResult.Log)
+			if err != nil {
+				return nil, err
+			}
+
+			transfers, err := transfersFromLogs(logs)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, e := range transfers {
+				e.TxHash = tx.Hash.String()
+				e.Time = blockTime
+				entries = append(entries, e)
+			}
+		}
+
+		if page*perPage >= res.TotalCount {
+			return entries, nil
+		}
+	}
+}
+
+// transfersFromLogs returns the transfers found in the logs of a tx.
+func transfersFromLogs(logs sdk.ABCIMessageLogs) ([]LedgerEntry, error) {
+	var entries []LedgerEntry
+
+	for _, log := range logs {
+		for _, event := range log.Events {
+			if event.Type != banktypes.EventTypeTransfer {
+				continue
+			}
+
+			// transfers of a multi-send are listed in the same event, an amount
+			// belongs to the recipient that precedes it.
+			var recipient string
+
+			for _, attr := range event.Attributes {
+				switch attr.Key {
+				case banktypes.AttributeKeyRecipient:
+					recipient = attr.Value
+
+				case sdk.AttributeKeyAmount:
+					coins, err := sdk.ParseCoinsNormalized(attr.Value)
+					if err != nil {
+						return nil, err
+					}
+
+					entries = append(entries, LedgerEntry{
+						Address: recipient,
+						Coins:   coins,
+					})
+				}
+			}
+		}
+	}
+
+	return entries, nil
+}
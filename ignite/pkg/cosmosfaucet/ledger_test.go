@@ -0,0 +1,118 @@
+package cosmosfaucet
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLedger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "faucet", "ledger.db")
+
+	ledger, err := NewLedger(path)
+	require.NoError(t, err)
+	defer ledger.Close()
+
+	// the ledger is locked while it is open.
+	_, err = NewLedger(path)
+	require.ErrorIs(t, err, ErrLedgerInUse)
+
+	initialized, err := ledger.IsInitialized()
+	require.NoError(t, err)
+	require.False(t, initialized)
+
+	var (
+		now   = time.Now().UTC()
+		alice = "cosmos1alice"
+		old   = LedgerEntry{alice, sdk.NewCoins(sdk.NewInt64Coin("token", 10)), "A", now.Add(-time.Hour)}
+		last  = LedgerEntry{alice, sdk.NewCoins(sdk.NewInt64Coin("stake", 1)), "C", now}
+		bob   = LedgerEntry{"cosmos1bob", sdk.NewCoins(sdk.NewInt64Coin("token", 5)), "B", now}
+		same  = LedgerEntry{alice, sdk.NewCoins(sdk.NewInt64Coin("token", 3)), "C", now}
+	)
+
+	require.NoError(t, ledger.Initialize([]LedgerEntry{old}, nil))
+
+	initialized, err = ledger.IsInitialized()
+	require.NoError(t, err)
+	require.True(t, initialized)
+
+	require.NoError(t, ledger.Add(last, bob, same))
+
+	history, err := ledger.History(alice)
+	require.NoError(t, err)
+	require.Equal(t, []LedgerEntry{old, last, same}, history)
+
+	total, err := ledger.Transferred(alice, now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 1), sdk.NewInt64Coin("token", 3)), total)
+
+	total, err = ledger.Transferred(alice, time.Time{})
+	require.NoError(t, err)
+	require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin("stake", 1), sdk.NewInt64Coin("token", 13)), total)
+
+	history, err = ledger.History("COSMOS1ALICE")
+	require.NoError(t, err)
+	require.Equal(t, []LedgerEntry{old, last, same}, history, "addresses are case insensitive")
+
+	history, err = ledger.History("cosmos1unknown")
+	require.NoError(t, err)
+	require.Empty(t, history)
+}
+
+func TestLedgerRefills(t *testing.T) {
+	ledger, err := NewLedger(filepath.Join(t.TempDir(), "ledger.db"))
+	require.NoError(t, err)
+	defer ledger.Close()
+
+	var (
+		now    = time.Now().UTC()
+		sender = "cosmos1sender"
+		old    = LedgerEntry{sender, sdk.NewCoins(sdk.NewInt64Coin("token", 100)), "A", now.Add(-time.Hour)}
+		refill = LedgerEntry{sender, sdk.NewCoins(sdk.NewInt64Coin("token", 100)), "B", now}
+	)
+
+	require.NoError(t, ledger.Initialize(nil, []LedgerEntry{old}))
+	require.NoError(t, ledger.AddRefills(refill))
+
+	refills, err := ledger.Refills(sender)
+	require.NoError(t, err)
+	require.Equal(t, []LedgerEntry{old, refill}, refills)
+
+	// refills don't count as transfers to the pool accounts.
+	total, err := ledger.Transferred(sender, time.Time{})
+	require.NoError(t, err)
+	require.True(t, total.IsZero())
+}
+
+func TestTransfersFromLogs(t *testing.T) {
+	logs, err := sdk.ParseABCILogs(`[
+		{"msg_index":0,"events":[
+			{"type":"message","attributes":[{"key":"sender","value":"cosmos1faucet"}]},
+			{"type":"transfer","attributes":[
+				{"key":"recipient","value":"cosmos1alice"},
+				{"key":"amount","value":"10token"},
+				{"key":"recipient","value":"cosmos1bob"},
+				{"key":"amount","value":"1stake,5token"}
+			]}
+		]},
+		{"msg_index":1,"events":[
+			{"type":"transfer","attributes":[
+				{"key":"recipient","value":"cosmos1alice"},
+				{"key":"sender","value":"cosmos1faucet"},
+				{"key":"amount","value":"3token"}
+			]}
+		]}
+	]`)
+	require.NoError(t, err)
+
+	entries, err := transfersFromLogs(logs)
+	require.NoError(t, err)
+	require.Equal(t, []LedgerEntry{
+		{Address: "cosmos1alice", Coins: sdk.NewCoins(sdk.NewInt64Coin("token", 10))},
+		{Address: "cosmos1bob", Coins: sdk.NewCoins(sdk.NewInt64Coin("stake", 1), sdk.NewInt64Coin("token", 5))},
+		{Address: "cosmos1alice", Coins: sdk.NewCoins(sdk.NewInt64Coin("token", 3))},
+	}, entries)
+}
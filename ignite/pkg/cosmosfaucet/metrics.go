@@ -0,0 +1,187 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const metricsNamespace = "faucet"
+
+// outcomes of the transfer requests.
+const (
+	outcomeSuccess      = "success"
+	outcomeInvalid      = "invalid"
+	outcomeUnauthorized = "unauthorized"
+	outcomeForbidden    = "forbidden"
+	outcomeRateLimited  = "rate_limited"
+	outcomeFailed       = "failed"
+	outcomeCanceled     = "canceled"
+)
+
+// limits that can reject the transfer requests.
+const (
+	limitIP           = "ip"
+	limitGlobal       = "global"
+	limitAPIKey       = "api_key"
+	limitVerification = "verification"
+	limitAddress      = "address"
+	limitCoinsMax     = "coins_max"
+)
+
+// metrics are the Prometheus metrics of the faucet.
+// a nil metrics doesn't record anything.
+type metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	distributed     *prometheus.CounterVec
+	limitRejections *prometheus.CounterVec
+	txDuration      prometheus.Histogram
+	balances        *balanceCollector
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "requests_total",
+			Help:      "Number of transfer requests by outcome.",
+		}, []string{"outcome"}),
+		distributed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "distributed_amount_total",
+			Help:      "Amount of coins distributed by denom.",
+		}, []string{"chain_id", "denom"}),
+		limitRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "limit_rejections_total",
+			Help:      "Number of transfer requests rejected by limit.",
+		}, []string{"limit"}),
+		txDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "tx_duration_seconds",
+			Help:      "Duration from broadcasting a transfer tx until it is included in a block.",
+			Buckets:   []float64{0.5, 1, 2, 5, 10, 20, 30},
+		}),
+		balances: &balanceCollector{},
+	}
+
+	m.registry.MustRegister(m.requests, m.distributed, m.limitRejections, m.txDuration, m.balances)
+
+	return m
+}
+
+func (m *metrics) request(outcome string) {
+	if m != nil {
+		m.requests.WithLabelValues(outcome).Inc()
+	}
+}
+
+func (m *metrics) rejected(limit string) {
+	if m != nil {
+		m.limitRejections.WithLabelValues(limit).Inc()
+	}
+}
+
+func (m *metrics) transferred(chainID string, coins sdk.Coins) {
+	if m == nil {
+		return
+	}
+	for _, c := range coins {
+		amount, _ := c.Amount.ToDec().Float64()
+		m.distributed.WithLabelValues(chainID, c.Denom).Add(amount)
+	}
+}
+
+func (m *metrics) txIncluded(d time.Duration) {
+	if m != nil {
+		m.txDuration.Observe(d.Seconds())
+	}
+}
+
+// registerBalances reports the balances of accounts when the metrics are collected.
+func (m *metrics) registerBalances(accounts ...*sender) {
+	if m != nil {
+		m.balances.add(accounts...)
+	}
+}
+
+func (m *metrics) handler() http.Handler {
+	if m == nil {
+		return http.NotFoundHandler()
+	}
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// outcome returns the outcome of a transfer request from its response status code.
+func outcome(code int) string {
+	switch {
+	case code == 0:
+		return outcomeCanceled
+	case code == http.StatusOK:
+		return outcomeSuccess
+	case code == http.StatusUnauthorized:
+		return outcomeUnauthorized
+	case code == http.StatusForbidden:
+		return outcomeForbidden
+	case code == http.StatusTooManyRequests:
+		return outcomeRateLimited
+	case code < http.StatusInternalServerError:
+		return outcomeInvalid
+	default:
+		return outcomeFailed
+	}
+}
+
+var balanceDesc = prometheus.NewDesc(
+	prometheus.BuildFQName(metricsNamespace, "", "balance"),
+	"Balance of the faucet accounts by denom.",
+	[]string{"chain_id", "address", "denom"},
+	nil,
+)
+
+// balanceCollector collects the balances of the faucet accounts by querying the chain.
+type balanceCollector struct {
+	mu       sync.Mutex
+	accounts []*sender
+}
+
+func (c *balanceCollector) add(accounts ...*sender) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.accounts = append(c.accounts, accounts...)
+}
+
+// Describe implements prometheus.Collector.
+func (c *balanceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- balanceDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *balanceCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), queryTimeout)
+	defer cancel()
+
+	c.mu.Lock()
+	accounts := c.accounts
+	c.mu.Unlock()
+
+	for _, account := range accounts {
+		balances, err := account.balances(ctx)
+		if err != nil {
+			ch <- prometheus.NewInvalidMetric(balanceDesc, err)
+			return
+		}
+
+		for _, balance := range balances {
+			amount, _ := balance.Amount.ToDec().Float64()
+			ch <- prometheus.MustNewConstMetric(balanceDesc, prometheus.GaugeValue, amount, account.chainID, account.address, balance.Denom)
+		}
+	}
+}
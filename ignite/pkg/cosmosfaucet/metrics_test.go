@@ -0,0 +1,72 @@
+package cosmosfaucet
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetrics(t *testing.T) {
+	f := Faucet{
+		addressPrefix:    "cosmos",
+		allowedAddresses: make(map[string]bool),
+		deniedAddresses:  make(map[string]bool),
+		metrics:          newMetrics(),
+	}
+	DenyAddresses("cosmos1mmlqwyqk7neqegffp99q86eckpm4pjah3ytlpa")(&f)
+	RateLimit(1, 0, time.Hour)(&f)
+
+	for _, body := range []string{
+		`{"address":"` + testAddress + `","coins":["invalid"]}`,
+		`{"address":"` + testAddress + `","coins":["invalid"]}`,
+		`{"address":"cosmos1mmlqwyqk7neqegffp99q86eckpm4pjah3ytlpa"}`,
+	} {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		f.faucetHandler(httptest.NewRecorder(), r)
+	}
+
+	f.metrics.transferred("test", sdk.NewCoins(sdk.NewInt64Coin("stake", 10), sdk.NewInt64Coin("token", 5)))
+	f.metrics.transferred("test", sdk.NewCoins(sdk.NewInt64Coin("stake", 10)))
+	f.metrics.txIncluded(time.Second)
+
+	w := httptest.NewRecorder()
+	f.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	out, err := io.ReadAll(w.Body)
+	require.NoError(t, err)
+
+	for _, line := range []string{
+		`faucet_requests_total{outcome="invalid"} 1`,
+		`faucet_requests_total{outcome="rate_limited"} 1`,
+		`faucet_requests_total{outcome="forbidden"} 1`,
+		`faucet_limit_rejections_total{limit="ip"} 1`,
+		`faucet_limit_rejections_total{limit="address"} 1`,
+		`faucet_distributed_amount_total{chain_id="test",denom="stake"} 20`,
+		`faucet_distributed_amount_total{chain_id="test",denom="token"} 5`,
+		`faucet_tx_duration_seconds_count 1`,
+	} {
+		require.Contains(t, string(out), line)
+	}
+}
+
+func TestOutcome(t *testing.T) {
+	cases := map[int]string{
+		0:                              outcomeCanceled,
+		http.StatusOK:                  outcomeSuccess,
+		http.StatusBadRequest:          outcomeInvalid,
+		http.StatusUnauthorized:        outcomeUnauthorized,
+		http.StatusForbidden:           outcomeForbidden,
+		http.StatusTooManyRequests:     outcomeRateLimited,
+		http.StatusInternalServerError: outcomeFailed,
+	}
+	for code, want := range cases {
+		require.Equal(t, want, outcome(code), code)
+	}
+}
@@ -0,0 +1,301 @@
+package cosmosfaucet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/pkg/errors"
+)
+
+const (
+	// senderCooldown is the duration that a sender is skipped for after its tx has failed.
+	senderCooldown = time.Minute
+
+	// refillTimeout is the max. duration to refill a sender from the treasury.
+	refillTimeout = time.Minute
+)
+
+// pool is a pool of sender accounts that the transfers are rotated between.
+// the pool has a single sender when no pool of accounts is configured,
+// otherwise the faucet account is the treasury that refills the senders.
+type pool struct {
+	// treasury refills the senders, it is nil when there is no pool of accounts.
+	treasury *sender
+	senders  []*sender
+
+	// threshold and amount are the balance under which senders are refilled and the refill amount.
+	threshold sdk.Coins
+	amount    sdk.Coins
+
+	// ledger records the refills apart from the transfers of the faucet.
+	ledger Ledger
+
+	mu         sync.Mutex
+	next       int
+	refilling  map[*sender]bool
+	refillErrs map[*sender]error
+}
+
+func newPool(treasury *sender, senders []*sender, threshold, amount sdk.Coins, ledger Ledger) *pool {
+	return &pool{
+		treasury:   treasury,
+		senders:    senders,
+		threshold:  threshold,
+		amount:     amount,
+		ledger:     ledger,
+		refilling:  make(map[*sender]bool),
+		refillErrs: make(map[*sender]error),
+	}
+}
+
+// accounts returns the treasury, when there is one, and the senders.
+func (p *pool) accounts() []*sender {
+	if p.treasury == nil {
+		return p.senders
+	}
+	return append([]*sender{p.treasury}, p.senders...)
+}
+
+// pick returns the next sender in rotation that is not excluded. senders whose last tx
+// has failed are skipped within the cooldown unless all the others are excluded too.
+// nil is returned when all senders are excluded.
+func (p *pool) pick(exclude map[*sender]bool) *sender {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var fallback *sender
+
+	for i := range p.senders {
+		s := p.senders[(p.next+i)%len(p.senders)]
+		if exclude[s] {
+			continue
+		}
+
+		if s.err(senderCooldown) != nil {
+			if fallback == nil {
+				fallback = s
+			}
+			continue
+		}
+
+		p.next = (p.next + i + 1) % len(p.senders)
+		return s
+	}
+
+	return fallback
+}
+
+// refill tops up s from the treasury in the background when its balance is under the threshold.
+func (p *pool) refill(s *sender) {
+	if p.treasury == nil || p.threshold.Empty() {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.refilling[s] {
+		return
+	}
+	p.refilling[s] = true
+
+	go func() {
+		err := p.topUp(s)
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		delete(p.refilling, s)
+		p.refillErrs[s] = err
+	}()
+}
+
+// topUp sends the refill amount from the treasury to s when its balance is under the threshold.
+func (p *pool) topUp(s *sender) error {
+	ctx, cancel := context.WithTimeout(context.Background(), refillTimeout)
+	defer cancel()
+
+	balances, err := s.balances(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !isUnder(balances, p.threshold) {
+		return nil
+	}
+
+	t := p.treasury.send(ctx, s.address, p.amount)
+	<-t.done
+
+	if t.err != nil {
+		return errors.Wrapf(t.err, "cannot refill %s", s.address)
+	}
+
+	err = p.ledger.AddRefills(LedgerEntry{
+		Address: s.address,
+		Coins:   p.amount,
+		TxHash:  t.txHash,
+		Time:    time.Now(),
+	})
+	return errors.Wrapf(err, "%s is refilled but the refill cannot be recorded", s.address)
+}
+
+// refillErr returns the error of the last refill of s.
+func (p *pool) refillErr(s *sender) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.refillErrs[s]
+}
+
+// AccountInfo is the health of an account of the faucet.
+type AccountInfo struct {
+	// ChainID is the id of the chain of the account.
+	ChainID string `json:"chain_id"`
+
+	// Name is the name of the account in the keyring.
+	Name string `json:"name"`
+
+	// Address is the address of the account.
+	Address string `json:"address"`
+
+	// Treasury indicates that the account refills the other accounts instead of sending transfers.
+	Treasury bool `json:"treasury,omitempty"`
+
+	// Balances are the balances of the account.
+	Balances sdk.Coins `json:"balances"`
+
+	// Healthy indicates that the account can send transfers, or refills for the treasury.
+	Healthy bool `json:"healthy"`
+
+	// Error explains why the account isn't healthy.
+	Error string `json:"error,omitempty"`
+}
+
+// accountsInfo returns the health of the faucet accounts.
+func (f Faucet) accountsInfo(ctx context.Context) []AccountInfo {
+	var infos []AccountInfo
+
+	for _, s := range f.pool.accounts() {
+		info := AccountInfo{
+			ChainID:  f.chainID,
+			Name:     s.accountName,
+			Address:  s.address,
+			Treasury: s == f.pool.treasury,
+		}
+
+		// senders must be able to send the default coins and the treasury to refill the senders.
+		required := f.coins
+		if info.Treasury {
+			required = f.pool.amount
+		}
+
+		balances, err := s.balances(ctx)
+
+		switch {
+		case err != nil:
+			info.Error = errors.Wrap(err, "cannot query balances").Error()
+		case s.err(senderCooldown) != nil:
+			info.Error = errors.Wrap(s.err(senderCooldown), "last tx failed").Error()
+		case !balances.IsAllGTE(required):
+			info.Error = fmt.Sprintf("insufficient balance, %s is required", required)
+		case f.pool.refillErr(s) != nil:
+			info.Error = f.pool.refillErr(s).Error()
+		default:
+			info.Healthy = true
+		}
+
+		info.Balances = balances
+		infos = append(infos, info)
+	}
+
+	return infos
+}
+
+// isUnder checks if any of the amounts in balances is under its threshold.
+func isUnder(balances, threshold sdk.Coins) bool {
+	for _, c := range threshold {
+		if balances.AmountOf(c.Denom).LT(c.Amount) {
+			return true
+		}
+	}
+	return false
+}
+
+// poolAccount is an account of the pool in the keyring.
+type poolAccount struct {
+	name    string
+	address string
+}
+
+// importPoolAccounts imports size accounts derived from mnemonic into kr. the accounts are derived
+// at the address indexes from 1 to size of the BIP-0044 path of coinType, the index 0 being
+// the default account of the mnemonic. keys are named as <name>-<index>.
+func importPoolAccounts(kr keyring.Keyring, name, mnemonic, coinType, addressPrefix string, size int) ([]poolAccount, error) {
+	coinTypeNumber, err := parseCoinType(coinType)
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []poolAccount
+
+	for index := 1; index <= size; index++ {
+		var (
+			keyName = fmt.Sprintf("%s-%d", name, index)
+			hdPath  = hd.CreateHDPath(coinTypeNumber, 0, uint32(index)).String()
+		)
+
+		derived, err := hd.Secp256k1.Derive()(mnemonic, "", hdPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot derive the pool accounts")
+		}
+
+		address := hd.Secp256k1.Generate()(derived).PubKey().Address()
+
+		info, err := kr.Key(keyName)
+		switch {
+		case err == nil:
+			if !bytes.Equal(info.GetAddress(), address) {
+				return nil, fmt.Errorf("key %s already exists with another mnemonic", keyName)
+			}
+		case errors.Is(err, sdkerrors.ErrKeyNotFound):
+			if _, err := kr.NewAccount(keyName, mnemonic, "", hdPath, hd.Secp256k1); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, err
+		}
+
+		// the address is encoded without the sdk config because the prefix of the chain isn't set globally.
+		bech32Address, err := bech32.ConvertAndEncode(addressPrefix, address)
+		if err != nil {
+			return nil, err
+		}
+
+		accounts = append(accounts, poolAccount{keyName, bech32Address})
+	}
+
+	return accounts, nil
+}
+
+// parseCoinType parses the BIP-0044 coin type number, the coin type of the sdk is returned when it's empty.
+func parseCoinType(coinType string) (uint32, error) {
+	if coinType == "" {
+		return sdk.CoinType, nil
+	}
+
+	n, err := strconv.ParseUint(coinType, 10, 32)
+	if err != nil {
+		return 0, errors.Wrapf(err, "invalid coin type %s", coinType)
+	}
+
+	return uint32(n), nil
+}
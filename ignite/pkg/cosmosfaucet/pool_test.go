@@ -0,0 +1,93 @@
+package cosmosfaucet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/go-bip39"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolPick(t *testing.T) {
+	var (
+		s1 = &sender{address: "1"}
+		s2 = &sender{address: "2"}
+		s3 = &sender{address: "3"}
+		p  = newPool(nil, []*sender{s1, s2, s3}, nil, nil, Ledger{})
+	)
+
+	// senders are rotated.
+	require.Equal(t, s1, p.pick(nil))
+	require.Equal(t, s2, p.pick(nil))
+	require.Equal(t, s3, p.pick(nil))
+	require.Equal(t, s1, p.pick(nil))
+
+	// failed senders are skipped within the cooldown.
+	s2.setErr(errors.New("failed"))
+	require.Equal(t, s3, p.pick(nil))
+	require.Equal(t, s1, p.pick(nil))
+	require.Equal(t, s3, p.pick(nil))
+
+	s2.failedAt = time.Now().Add(-senderCooldown - time.Second)
+	require.Equal(t, s2, p.pick(map[*sender]bool{s1: true}))
+
+	// failed senders are used when the others are excluded.
+	s2.setErr(errors.New("failed"))
+	require.Equal(t, s2, p.pick(map[*sender]bool{s1: true, s3: true}))
+	require.Nil(t, p.pick(map[*sender]bool{s1: true, s2: true, s3: true}))
+}
+
+func TestIsUnder(t *testing.T) {
+	threshold := sdk.NewCoins(sdk.NewInt64Coin("stake", 10), sdk.NewInt64Coin("token", 5))
+
+	require.False(t, isUnder(sdk.NewCoins(sdk.NewInt64Coin("stake", 10), sdk.NewInt64Coin("token", 5)), threshold))
+	require.True(t, isUnder(sdk.NewCoins(sdk.NewInt64Coin("stake", 10), sdk.NewInt64Coin("token", 4)), threshold))
+	require.True(t, isUnder(sdk.NewCoins(sdk.NewInt64Coin("stake", 100)), threshold))
+	require.False(t, isUnder(nil, nil))
+}
+
+func TestImportPoolAccounts(t *testing.T) {
+	entropy, err := bip39.NewEntropy(256)
+	require.NoError(t, err)
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	require.NoError(t, err)
+
+	kr := keyring.NewInMemory()
+
+	treasury, err := kr.NewAccount("faucet", mnemonic, "", hd.CreateHDPath(sdk.CoinType, 0, 0).String(), hd.Secp256k1)
+	require.NoError(t, err)
+
+	accounts, err := importPoolAccounts(kr, "faucet", mnemonic, "", "cosmos", 3)
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+
+	addresses := map[string]bool{treasury.GetAddress().String(): true}
+	for i, account := range accounts {
+		require.Equal(t, []string{"faucet-1", "faucet-2", "faucet-3"}[i], account.name)
+
+		info, err := kr.Key(account.name)
+		require.NoError(t, err)
+		require.Equal(t, info.GetAddress().String(), account.address)
+
+		addresses[account.address] = true
+	}
+	require.Len(t, addresses, 4, "accounts must have distinct addresses")
+
+	// accounts are imported again from the same mnemonic.
+	again, err := importPoolAccounts(kr, "faucet", mnemonic, "", "cosmos", 3)
+	require.NoError(t, err)
+	require.Equal(t, accounts, again)
+
+	// existing keys of another mnemonic aren't overwritten.
+	entropy, err = bip39.NewEntropy(256)
+	require.NoError(t, err)
+	other, err := bip39.NewMnemonic(entropy)
+	require.NoError(t, err)
+
+	_, err = importPoolAccounts(kr, "faucet", other, "", "cosmos", 1)
+	require.Error(t, err)
+}
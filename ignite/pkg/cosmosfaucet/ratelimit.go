@@ -0,0 +1,117 @@
+package cosmosfaucet
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimitWindow is the default duration that the request counts are kept for.
+const DefaultRateLimitWindow = time.Hour
+
+// ErrRateLimited is returned when a client has made too many requests.
+type ErrRateLimited struct {
+	// RetryAfter is the duration after which the client can make requests again.
+	RetryAfter time.Duration
+}
+
+// Error implements error.
+func (err ErrRateLimited) Error() string {
+	return fmt.Sprintf("too many requests, retry after %s", err.RetryAfter.Round(time.Second))
+}
+
+// rateLimiter limits the number of requests made with each key within fixed windows.
+type rateLimiter struct {
+	limit  int
+	window time.Duration
+	now    func() time.Time
+
+	mu        sync.Mutex
+	counts    map[string]*requestCount
+	lastSweep time.Time
+}
+
+type requestCount struct {
+	start time.Time
+	count int
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:  limit,
+		window: window,
+		now:    time.Now,
+		counts: make(map[string]*requestCount),
+	}
+}
+
+// allow counts a request made with key and returns an error when the limit is exceeded.
+// there is no limit when limiter is nil or its limit is 0.
+func (l *rateLimiter) allow(key string) error {
+	if l == nil || l.limit == 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	// expired counts are deleted once in a window to not keep the keys of all clients.
+	if now.Sub(l.lastSweep) > l.window {
+		for k, c := range l.counts {
+			if now.Sub(c.start) >= l.window {
+				delete(l.counts, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	c, ok := l.counts[key]
+	if !ok || now.Sub(c.start) >= l.window {
+		c = &requestCount{start: now}
+		l.counts[key] = c
+	}
+
+	if c.count >= l.limit {
+		return ErrRateLimited{RetryAfter: c.start.Add(l.window).Sub(now)}
+	}
+
+	c.count++
+
+	return nil
+}
+
+// clientIP returns the IP of the client that made r. the IP is read from header when it's set,
+// the header being set by the trusted proxies in front of the faucet. each proxy appends the IP
+// of its client to the list, so the IP of the client is the one added by the first of the
+// trustedProxies. the other entries are set by the client and can be spoofed.
+func clientIP(r *http.Request, header string, trustedProxies int) string {
+	if header != "" {
+		if values := r.Header.Values(header); len(values) > 0 {
+			ips := strings.Split(strings.Join(values, ","), ",")
+
+			if trustedProxies < 1 {
+				trustedProxies = 1
+			}
+
+			// all the entries are added by trusted proxies when there are fewer of them.
+			i := len(ips) - trustedProxies
+			if i < 0 {
+				i = 0
+			}
+
+			return strings.TrimSpace(ips[i])
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,60 @@
+package cosmosfaucet
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	var (
+		now     = time.Now()
+		limiter = newRateLimiter(2, time.Minute)
+	)
+
+	limiter.now = func() time.Time { return now }
+
+	require.NoError(t, limiter.allow("a"))
+	require.NoError(t, limiter.allow("a"))
+	require.NoError(t, limiter.allow("b"))
+
+	err := limiter.allow("a")
+	require.Equal(t, ErrRateLimited{RetryAfter: time.Minute}, err)
+
+	// requests are counted again in the next window.
+	now = now.Add(time.Minute)
+	require.NoError(t, limiter.allow("a"))
+
+	// no limit.
+	var disabled *rateLimiter
+	require.NoError(t, disabled.allow("a"))
+	require.NoError(t, newRateLimiter(0, time.Minute).allow("a"))
+}
+
+func TestClientIP(t *testing.T) {
+	r := httptest.NewRequest("POST", "/", nil)
+	r.RemoteAddr = "10.0.0.1:4321"
+	r.Header.Set("X-Forwarded-For", "1.2.3.4, 10.0.0.2")
+
+	require.Equal(t, "10.0.0.1", clientIP(r, "", 0))
+	require.Equal(t, "10.0.0.2", clientIP(r, "X-Forwarded-For", 0))
+	require.Equal(t, "10.0.0.2", clientIP(r, "X-Forwarded-For", 1))
+	require.Equal(t, "1.2.3.4", clientIP(r, "X-Forwarded-For", 2))
+	require.Equal(t, "1.2.3.4", clientIP(r, "X-Forwarded-For", 3))
+	require.Equal(t, "10.0.0.1", clientIP(r, "X-Real-IP", 0))
+}
+
+func TestClientIPSpoofedHeader(t *testing.T) {
+	// the client sets its own X-Forwarded-For header that the proxy appends the real IP to.
+	r := httptest.NewRequest("POST", "/", nil)
+	r.RemoteAddr = "10.0.0.1:4321"
+	r.Header.Add("X-Forwarded-For", "6.6.6.6, 7.7.7.7")
+	r.Header.Add("X-Forwarded-For", "1.2.3.4")
+
+	require.Equal(t, "1.2.3.4", clientIP(r, "X-Forwarded-For", 1))
+
+	// the header isn't trusted without a proxy.
+	require.Equal(t, "10.0.0.1", clientIP(r, "", 0))
+}
@@ -0,0 +1,80 @@
+package cosmosfaucet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RequestLogEntry is a transfer request written to the request log.
+type RequestLogEntry struct {
+	// Time is the time the request is received at.
+	Time time.Time `json:"time"`
+
+	// IP is the IP of the client.
+	IP string `json:"ip"`
+
+	// Address is the address that the coins are requested for.
+	Address string `json:"address,omitempty"`
+
+	// Coins are the requested coins.
+	Coins []string `json:"coins,omitempty"`
+
+	// Tier is the name of the API key tier when the request is made with an API key.
+	Tier string `json:"tier,omitempty"`
+
+	// Status is the status code of the response.
+	Status int `json:"status"`
+
+	// Outcome is the outcome of the request.
+	Outcome string `json:"outcome"`
+
+	// Error is the error returned to the client.
+	Error string `json:"error,omitempty"`
+
+	// DurationMS is the duration of the request in milliseconds.
+	DurationMS int64 `json:"duration_ms"`
+}
+
+// requestLog writes the transfer requests to a file as JSON lines.
+// a nil log doesn't write anything.
+type requestLog struct {
+	path string
+	mu   sync.Mutex
+}
+
+func newRequestLog(path string) (*requestLog, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return &requestLog{path: path}, nil
+}
+
+// write appends entry to the log. the file is opened for each entry, so it can be rotated.
+func (l *requestLog) write(entry RequestLogEntry) error {
+	if l == nil {
+		return nil
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
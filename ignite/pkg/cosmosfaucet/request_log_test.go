@@ -0,0 +1,75 @@
+package cosmosfaucet
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "log", "requests.jsonl")
+
+	requestLog, err := newRequestLog(path)
+	require.NoError(t, err)
+
+	f := Faucet{
+		addressPrefix:    "cosmos",
+		allowedAddresses: make(map[string]bool),
+		deniedAddresses:  make(map[string]bool),
+		requestLog:       requestLog,
+	}
+	APIKey("key", Tier{Name: "ci"})(&f)
+	for _, t := range f.tiers {
+		t.tier.limiter = newRateLimiter(t.tier.RateLimit, time.Hour)
+	}
+
+	request := func(body, apiKey string) {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		r.RemoteAddr = "1.2.3.4:1234"
+		if apiKey != "" {
+			r.Header.Set("Authorization", "Bearer "+apiKey)
+		}
+		f.faucetHandler(httptest.NewRecorder(), r)
+	}
+
+	request(`{"address":"`+testAddress+`","coins":["invalid"]}`, "key")
+	request(`{`, "")
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var entries []RequestLogEntry
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry RequestLogEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, entries, 2)
+
+	require.Equal(t, "1.2.3.4", entries[0].IP)
+	require.Equal(t, testAddress, entries[0].Address)
+	require.Equal(t, []string{"invalid"}, entries[0].Coins)
+	require.Equal(t, "ci", entries[0].Tier)
+	require.Equal(t, http.StatusBadRequest, entries[0].Status)
+	require.Equal(t, outcomeInvalid, entries[0].Outcome)
+	require.NotEmpty(t, entries[0].Error)
+
+	require.Empty(t, entries[1].Address)
+	require.Equal(t, http.StatusBadRequest, entries[1].Status)
+}
+
+func TestRequestLogNil(t *testing.T) {
+	var l *requestLog
+	require.NoError(t, l.write(RequestLogEntry{}))
+}
@@ -0,0 +1,251 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxTransfersPerTx is the max. number of transfers that are batched into a single tx.
+	maxTransfersPerTx = 100
+
+	// txTimeout is the max. duration to wait for a tx to be included in a block.
+	txTimeout = time.Second * 30
+
+	// queryTimeout is the timeout for the queries made to the chain.
+	queryTimeout = time.Second * 10
+)
+
+// ChainClient is a client to the chain that signs and broadcasts the transfers of the faucet.
+// cosmosclient.Client implements it with its FaucetClient method.
+type ChainClient interface {
+	// Context returns the client context to the chain with the keyring that holds the faucet account.
+	Context() client.Context
+
+	// SendTx signs a tx with msgs for the account and broadcasts it without waiting for the tx to be
+	// included in a block. The txs of an account can be sent concurrently, an error is returned when
+	// the tx is not accepted in the mempool.
+	SendTx(accountName string, msgs ...sdk.Msg) (txHash string, err error)
+
+	// AwaitTx waits for the tx with txHash to be included in a block, an error is returned when the
+	// execution of the tx has failed.
+	AwaitTx(ctx context.Context, txHash string) error
+}
+
+// transfer is a transfer request waiting to be sent.
+type transfer struct {
+	ctx     context.Context
+	address string
+	coins   sdk.Coins
+
+	// done is closed once the transfer is completed and txHash or err is set.
+	done   chan struct{}
+	txHash string
+	err    error
+}
+
+func (t *transfer) finish(txHash string, err error) {
+	t.txHash = txHash
+	t.err = err
+	close(t.done)
+}
+
+// sender batches concurrent transfers into txs that are signed with the faucet account
+// and broadcasted without waiting for the previous txs to be included in a block.
+// the sequences of the account are handed out by the client.
+type sender struct {
+	client      ChainClient
+	accountName string
+	address     string
+	chainID     string
+	metrics     *metrics
+
+	mu      sync.Mutex
+	queue   []*transfer
+	running bool
+
+	// failedAt and lastErr are set when the last tx of the sender has failed.
+	failedAt time.Time
+	lastErr  error
+}
+
+func newSender(client ChainClient, accountName, address, chainID string, metrics *metrics) *sender {
+	return &sender{
+		client:      client,
+		accountName: accountName,
+		address:     address,
+		chainID:     chainID,
+		metrics:     metrics,
+	}
+}
+
+// send queues a transfer of coins to address. the returned transfer is done when
+// its tx is included in a block or when it fails.
+func (s *sender) send(ctx context.Context, address string, coins sdk.Coins) *transfer {
+	t := &transfer{
+		ctx:     ctx,
+		address: address,
+		coins:   coins,
+		done:    make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queue = append(s.queue, t)
+
+	// the broadcast loop runs only while there are queued transfers.
+	if !s.running {
+		s.running = true
+		go s.run()
+	}
+
+	return t
+}
+
+// run broadcasts the queued transfers until the queue is empty.
+func (s *sender) run() {
+	for {
+		batch := s.next()
+		if len(batch) == 0 {
+			return
+		}
+
+		start := time.Now()
+
+		txHash, err := s.client.SendTx(s.accountName, transferMsg(s.address, batch))
+		if err != nil {
+			err = notBroadcastedError{errors.Wrap(err, "cannot send tokens")}
+			s.setErr(err)
+			finishAll(batch, "", err)
+			continue
+		}
+
+		// transfers queued in the meantime are broadcasted in the next txs
+		// while this one is waiting to be included in a block.
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), txTimeout)
+			defer cancel()
+
+			err := s.client.AwaitTx(ctx, txHash)
+			if err == nil {
+				s.metrics.txIncluded(time.Since(start))
+			}
+			s.setErr(err)
+			finishAll(batch, txHash, err)
+		}()
+	}
+}
+
+// next dequeues the next batch of transfers, it stops the broadcast loop when the queue is empty.
+func (s *sender) next() []*transfer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var batch []*transfer
+
+	for len(s.queue) > 0 && len(batch) < maxTransfersPerTx {
+		t := s.queue[0]
+		s.queue = s.queue[1:]
+
+		// requests that are not waiting for their transfers anymore are dropped.
+		if err := t.ctx.Err(); err != nil {
+			t.finish("", err)
+			continue
+		}
+
+		batch = append(batch, t)
+	}
+
+	if len(batch) == 0 {
+		s.running = false
+	}
+
+	return batch
+}
+
+// setErr records the result of the last tx of the sender.
+func (s *sender) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastErr = err
+	if err != nil {
+		s.failedAt = time.Now()
+	}
+}
+
+// err returns the error of the last tx when it has failed within the cooldown.
+func (s *sender) err(cooldown time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.lastErr == nil || time.Since(s.failedAt) > cooldown {
+		return nil
+	}
+	return s.lastErr
+}
+
+// balances queries the balances of the sender account.
+func (s *sender) balances(ctx context.Context) (sdk.Coins, error) {
+	res, err := banktypes.NewQueryClient(s.client.Context()).AllBalances(ctx, &banktypes.QueryAllBalancesRequest{
+		Address: s.address,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Balances, nil
+}
+
+// notBroadcastedError is returned for the transfers of the txs that are rejected before
+// being broadcasted, so they can safely be sent again.
+type notBroadcastedError struct {
+	err error
+}
+
+func (e notBroadcastedError) Error() string {
+	return e.err.Error()
+}
+
+func (e notBroadcastedError) Unwrap() error {
+	return e.err
+}
+
+// transferMsg returns a msg to send the coins of the transfers from address.
+// a bank send is used for a single transfer, otherwise a multi-send.
+func transferMsg(address string, batch []*transfer) sdk.Msg {
+	if len(batch) == 1 {
+		return &banktypes.MsgSend{
+			FromAddress: address,
+			ToAddress:   batch[0].address,
+			Amount:      batch[0].coins,
+		}
+	}
+
+	var (
+		total   sdk.Coins
+		outputs []banktypes.Output
+	)
+
+	for _, t := range batch {
+		total = total.Add(t.coins...)
+		outputs = append(outputs, banktypes.Output{Address: t.address, Coins: t.coins})
+	}
+
+	return &banktypes.MsgMultiSend{
+		Inputs:  []banktypes.Input{{Address: address, Coins: total}},
+		Outputs: outputs,
+	}
+}
+
+func finishAll(batch []*transfer, txHash string, err error) {
+	for _, t := range batch {
+		t.finish(txHash, err)
+	}
+}
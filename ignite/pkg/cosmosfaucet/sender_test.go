@@ -0,0 +1,90 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferMsg(t *testing.T) {
+	const faucetAddress = "cosmos1faucet"
+
+	t.Run("single transfer", func(t *testing.T) {
+		coins := sdk.NewCoins(sdk.NewInt64Coin("token", 10))
+
+		msg := transferMsg(faucetAddress, []*transfer{
+			{address: "cosmos1alice", coins: coins},
+		})
+
+		require.Equal(t, &banktypes.MsgSend{
+			FromAddress: faucetAddress,
+			ToAddress:   "cosmos1alice",
+			Amount:      coins,
+		}, msg)
+	})
+
+	t.Run("batched transfers", func(t *testing.T) {
+		msg := transferMsg(faucetAddress, []*transfer{
+			{address: "cosmos1alice", coins: sdk.NewCoins(sdk.NewInt64Coin("token", 10))},
+			{address: "cosmos1bob", coins: sdk.NewCoins(sdk.NewInt64Coin("stake", 5), sdk.NewInt64Coin("token", 10))},
+			{address: "cosmos1alice", coins: sdk.NewCoins(sdk.NewInt64Coin("stake", 1))},
+		})
+
+		require.Equal(t, &banktypes.MsgMultiSend{
+			Inputs: []banktypes.Input{
+				{
+					Address: faucetAddress,
+					Coins:   sdk.NewCoins(sdk.NewInt64Coin("stake", 6), sdk.NewInt64Coin("token", 20)),
+				},
+			},
+			Outputs: []banktypes.Output{
+				{Address: "cosmos1alice", Coins: sdk.NewCoins(sdk.NewInt64Coin("token", 10))},
+				{Address: "cosmos1bob", Coins: sdk.NewCoins(sdk.NewInt64Coin("stake", 5), sdk.NewInt64Coin("token", 10))},
+				{Address: "cosmos1alice", Coins: sdk.NewCoins(sdk.NewInt64Coin("stake", 1))},
+			},
+		}, msg)
+	})
+}
+
+func TestSenderSend(t *testing.T) {
+	coins := sdk.NewCoins(sdk.NewInt64Coin("token", 10))
+
+	t.Run("transfer included", func(t *testing.T) {
+		client := &testChainClient{}
+		s := newSender(client, "faucet", "cosmos1faucet", "mars", nil)
+
+		tr := s.send(context.Background(), "cosmos1alice", coins)
+		<-tr.done
+
+		require.NoError(t, tr.err)
+		require.NotEmpty(t, tr.txHash)
+		require.Len(t, client.sent, 1)
+		require.NoError(t, s.err(senderCooldown))
+	})
+
+	t.Run("tx not broadcasted", func(t *testing.T) {
+		client := &testChainClient{sendErr: errors.New("insufficient fees")}
+		s := newSender(client, "faucet", "cosmos1faucet", "mars", nil)
+
+		tr := s.send(context.Background(), "cosmos1alice", coins)
+		<-tr.done
+
+		require.ErrorAs(t, tr.err, &notBroadcastedError{})
+		require.Error(t, s.err(senderCooldown))
+	})
+
+	t.Run("tx failed", func(t *testing.T) {
+		client := &testChainClient{awaitErr: errors.New("out of gas")}
+		s := newSender(client, "faucet", "cosmos1faucet", "mars", nil)
+
+		tr := s.send(context.Background(), "cosmos1alice", coins)
+		<-tr.done
+
+		require.EqualError(t, tr.err, "out of gas")
+		require.NotEmpty(t, tr.txHash)
+	})
+}
@@ -3,99 +3,183 @@ package cosmosfaucet
 import (
 	"context"
 	"fmt"
-	"strings"
 	"sync"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
-
-	chaincmdrunner "github.com/ignite/cli/ignite/pkg/chaincmd/runner"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+	"github.com/pkg/errors"
 )
 
-// transferMutex is a mutex used for keeping transfer requests in a queue so checking account balance and sending tokens is atomic
-var transferMutex = &sync.Mutex{}
+// TotalTransferredAmount returns the total transferred amount from faucet account to toAccountAddress
+// within the limit refresh window.
+func (f Faucet) TotalTransferredAmount(toAccountAddress, denom string) (totalAmount uint64, err error) {
+	if toAccountAddress, err = f.validateAddress(toAccountAddress); err != nil {
+		return 0, err
+	}
 
-// TotalTransferredAmount returns the total transferred amount from faucet account to toAccountAddress.
-func (f Faucet) TotalTransferredAmount(ctx context.Context, toAccountAddress, denom string) (totalAmount uint64, err error) {
-	fromAccount, err := f.runner.ShowAccount(ctx, f.accountName)
+	coins, err := f.ledger.Transferred(toAccountAddress, time.Now().Add(-f.limitRefreshWindow))
 	if err != nil {
 		return 0, err
 	}
 
-	events, err := f.runner.QueryTxEvents(ctx,
-		chaincmdrunner.NewEventSelector("message", "sender", fromAccount.Address),
-		chaincmdrunner.NewEventSelector("transfer", "recipient", toAccountAddress))
+	return coins.AmountOf(denom).Uint64(), nil
+}
+
+// Transfer transfer amount of tokens from the faucet account to toAccountAddress.
+// concurrent transfers are batched into the same tx and Transfer returns once
+// the tx is included in a block.
+func (f *Faucet) Transfer(ctx context.Context, toAccountAddress string, coins sdk.Coins) error {
+	return f.transfer(ctx, toAccountAddress, coins, f.coinsMax)
+}
+
+// transfer transfers coins to toAccountAddress by enforcing the max. amounts in coinsMax.
+func (f *Faucet) transfer(ctx context.Context, toAccountAddress string, coins sdk.Coins, coinsMax map[string]uint64) error {
+	toAccountAddress, err := f.validateAddress(toAccountAddress)
 	if err != nil {
-		return 0, err
+		return err
 	}
 
-	for _, event := range events {
-		if event.Type == "transfer" {
-			for _, attr := range event.Attributes {
-				if attr.Key == "amount" {
-					coins, err := sdk.ParseCoinsNormalized(attr.Value)
-					if err != nil {
-						return 0, err
-					}
-
-					amount := coins.AmountOf(denom).Uint64()
-
-					if amount > 0 && time.Since(event.Time) < f.limitRefreshWindow {
-						totalAmount += amount
-					}
-				}
-			}
-		}
+	// coins are copied before sorting because the default coins are shared by all requests.
+	coins = append(sdk.Coins{}, coins...).Sort()
+	if err := coins.Validate(); err != nil {
+		return err
+	}
+
+	if err := f.reserve(toAccountAddress, coins, coinsMax); err != nil {
+		return err
 	}
 
-	return totalAmount, nil
+	done := make(chan error, 1)
+
+	// the transfer is recorded and the reservation is kept until the transfer is completed,
+	// even when the request is canceled.
+	go func() {
+		t := f.send(ctx, toAccountAddress, coins)
+
+		err := t.err
+		if err == nil {
+			err = f.ledger.Add(LedgerEntry{
+				Address: toAccountAddress,
+				Coins:   coins,
+				TxHash:  t.txHash,
+				Time:    time.Now(),
+			})
+			err = errors.Wrap(err, "coins are sent but the transfer cannot be recorded")
+			f.metrics.transferred(f.chainID, coins)
+		}
+
+		f.pending.remove(toAccountAddress, coins)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
-// Transfer transfer amount of tokens from the faucet account to toAccountAddress.
-func (f *Faucet) Transfer(ctx context.Context, toAccountAddress string, coins sdk.Coins) error {
-	transferMutex.Lock()
-	defer transferMutex.Unlock()
+// send sends coins to toAccountAddress from the senders of the pool and returns the completed transfer.
+// transfers that are not broadcasted are sent again from the other senders.
+func (f *Faucet) send(ctx context.Context, toAccountAddress string, coins sdk.Coins) *transfer {
+	tried := make(map[*sender]bool)
+
+	for {
+		s := f.pool.pick(tried)
+		t := s.send(ctx, toAccountAddress, coins)
+		<-t.done
 
-	var coinsStr []string
+		f.pool.refill(s)
+		tried[s] = true
+
+		var notBroadcasted notBroadcastedError
+		if t.err == nil || !errors.As(t.err, &notBroadcasted) || ctx.Err() != nil || len(tried) == len(f.pool.senders) {
+			return t
+		}
+	}
+}
+
+// reserve checks that the max. amounts of coins in coinsMax that can be sent to toAccountAddress
+// won't be exceeded and adds coins to the pending transfers of the account.
+func (f *Faucet) reserve(toAccountAddress string, coins sdk.Coins, coinsMax map[string]uint64) error {
+	// checking the limits and reserving the coins must be atomic.
+	f.pending.mu.Lock()
+	defer f.pending.mu.Unlock()
 
 	// check for each coin, the max transferred amount hasn't been reached
 	for _, c := range coins {
-		totalSent, err := f.TotalTransferredAmount(ctx, toAccountAddress, c.Denom)
+		if coinsMax[c.Denom] == 0 {
+			continue
+		}
+
+		totalSent, err := f.TotalTransferredAmount(toAccountAddress, c.Denom)
 		if err != nil {
 			return err
 		}
 
-		if f.coinsMax[c.Denom] != 0 {
-			if totalSent >= f.coinsMax[c.Denom] {
-				return fmt.Errorf(
-					"account has reached to the max. allowed amount (%d) for %q denom",
-					f.coinsMax[c.Denom],
-					c.Denom,
-				)
-			}
-
-			if (totalSent + c.Amount.Uint64()) > f.coinsMax[c.Denom] {
-				return fmt.Errorf(
-					`ask less amount for %q denom. account is reaching to the limit (%d) that faucet can tolerate`,
-					c.Denom,
-					f.coinsMax[c.Denom],
-				)
-			}
+		// transfers that are not completed yet are not recorded in the ledger.
+		totalSent += f.pending.coins[toAccountAddress].AmountOf(c.Denom).Uint64()
+
+		if totalSent >= coinsMax[c.Denom] {
+			f.metrics.rejected(limitCoinsMax)
+			return fmt.Errorf(
+				"account has reached to the max. allowed amount (%d) for %q denom",
+				coinsMax[c.Denom],
+				c.Denom,
+			)
 		}
 
-		coinsStr = append(coinsStr, c.String())
+		if (totalSent + c.Amount.Uint64()) > coinsMax[c.Denom] {
+			f.metrics.rejected(limitCoinsMax)
+			return fmt.Errorf(
+				`ask less amount for %q denom. account is reaching to the limit (%d) that faucet can tolerate`,
+				c.Denom,
+				coinsMax[c.Denom],
+			)
+		}
 	}
 
-	// perform transfer for all coins
-	fromAccount, err := f.runner.ShowAccount(ctx, f.accountName)
+	f.pending.coins[toAccountAddress] = f.pending.coins[toAccountAddress].Add(coins...)
+
+	return nil
+}
+
+// validateAddress checks that address is a valid account address of the chain and returns it normalized.
+// bech32 addresses are case insensitive, so the address is encoded again in lowercase to compare it
+// with the allow and deny lists and to key the transfers.
+func (f *Faucet) validateAddress(address string) (string, error) {
+	prefix, bytes, err := bech32.DecodeAndConvert(address)
 	if err != nil {
-		return err
+		return "", errors.Wrapf(err, "invalid address %s", address)
 	}
-	txHash, err := f.runner.BankSend(ctx, fromAccount.Address, toAccountAddress, strings.Join(coinsStr, ","))
-	if err != nil {
-		return err
+	if prefix != f.addressPrefix {
+		return "", fmt.Errorf("invalid address %s: address prefix must be %s", address, f.addressPrefix)
 	}
+	return bech32.ConvertAndEncode(f.addressPrefix, bytes)
+}
+
+// pendingTransfers keeps the coins of the transfers that are not completed yet by account addresses.
+type pendingTransfers struct {
+	mu    sync.Mutex
+	coins map[string]sdk.Coins
+}
 
-	// wait for the send tx to be confirmed
-	return f.runner.WaitTx(ctx, txHash, time.Second, 30)
+func newPendingTransfers() *pendingTransfers {
+	return &pendingTransfers{
+		coins: make(map[string]sdk.Coins),
+	}
+}
+
+func (p *pendingTransfers) remove(address string, coins sdk.Coins) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	remaining := p.coins[address].Sub(coins)
+	if remaining.IsZero() {
+		delete(p.coins, address)
+		return
+	}
+	p.coins[address] = remaining
 }
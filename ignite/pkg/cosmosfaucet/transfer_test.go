@@ -0,0 +1,41 @@
+package cosmosfaucet
+
+import (
+	"testing"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAddress(t *testing.T) {
+	f := Faucet{addressPrefix: "cosmos"}
+
+	address, err := f.validateAddress("cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj")
+	require.NoError(t, err)
+	require.Equal(t, "cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj", address)
+
+	address, err = f.validateAddress("COSMOS1DD246YQ6Z5VZJZ9GH8CFF46PLL75YYL8YGNDSJ")
+	require.NoError(t, err)
+	require.Equal(t, "cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj", address, "address is normalized")
+
+	_, err = f.validateAddress("osmo1dd246yq6z5vzjz9gh8cff46pll75yyl8vnqaxq")
+	require.Error(t, err, "wrong prefix")
+	_, err = f.validateAddress("cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8pu8cup")
+	require.Error(t, err, "invalid checksum")
+}
+
+func TestPendingTransfers(t *testing.T) {
+	var (
+		p      = newPendingTransfers()
+		coins  = sdk.NewCoins(sdk.NewInt64Coin("token", 10))
+		double = sdk.NewCoins(sdk.NewInt64Coin("token", 20))
+	)
+
+	p.coins["cosmos1alice"] = double
+
+	p.remove("cosmos1alice", coins)
+	require.Equal(t, coins, p.coins["cosmos1alice"])
+
+	p.remove("cosmos1alice", coins)
+	require.NotContains(t, p.coins, "cosmos1alice")
+}
@@ -0,0 +1,278 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math/bits"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// VerifierPoW is the type of the proof of work verifier.
+	VerifierPoW = "pow"
+
+	// VerifierCaptcha is the type of the captcha verifier.
+	VerifierCaptcha = "captcha"
+
+	// VerifierTest is the type of the test verifier.
+	VerifierTest = "test"
+)
+
+// powChallengeTTL is the duration that a proof of work challenge can be solved within.
+const powChallengeTTL = time.Minute * 5
+
+// ErrVerificationFailed is returned when a transfer request cannot be verified.
+var ErrVerificationFailed = errors.New("verification failed")
+
+// Verifier verifies that transfer requests are made by humans or by allowed clients,
+// e.g. by checking a captcha response or a proof of work.
+type Verifier interface {
+	// Challenge returns a challenge to solve for a transfer request.
+	Challenge() (Challenge, error)
+
+	// Verify checks the verification of a transfer request to address made from the client with ip.
+	Verify(ctx context.Context, address, ip string, v Verification) error
+}
+
+// Challenge is a challenge to solve before requesting a transfer.
+type Challenge struct {
+	// Type is the type of the verifier.
+	Type string `json:"type"`
+
+	// Value is the value of the challenge when the verifier issues challenges.
+	Value string `json:"value,omitempty"`
+
+	// Difficulty is the number of leading zero bits required in the proof of work.
+	Difficulty uint `json:"difficulty,omitempty"`
+}
+
+// Verification is the solution of a challenge sent with a transfer request.
+type Verification struct {
+	// Challenge is the value of the solved challenge.
+	Challenge string `json:"challenge,omitempty"`
+
+	// Solution is the solution of the challenge, e.g. a proof of work nonce or a captcha response.
+	Solution string `json:"solution"`
+}
+
+// PoWVerifier verifies a proof of work made for the address of a transfer request.
+// Challenges are signed by the verifier so they don't need to be stored until they are solved.
+type PoWVerifier struct {
+	difficulty uint
+	secret     []byte
+	now        func() time.Time
+
+	mu   *sync.Mutex
+	used map[string]time.Time
+}
+
+// NewPoWVerifier creates a proof of work verifier that requires hashes with difficulty leading zero bits.
+func NewPoWVerifier(difficulty uint) (PoWVerifier, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return PoWVerifier{}, err
+	}
+
+	return PoWVerifier{
+		difficulty: difficulty,
+		secret:     secret,
+		now:        time.Now,
+		mu:         &sync.Mutex{},
+		used:       make(map[string]time.Time),
+	}, nil
+}
+
+// Challenge implements Verifier.
+func (v PoWVerifier) Challenge() (Challenge, error) {
+	data := make([]byte, 24)
+	binary.BigEndian.PutUint64(data, uint64(v.now().Add(powChallengeTTL).Unix()))
+	if _, err := rand.Read(data[8:]); err != nil {
+		return Challenge{}, err
+	}
+
+	return Challenge{
+		Type:       VerifierPoW,
+		Value:      base64.RawURLEncoding.EncodeToString(append(data, v.sign(data)...)),
+		Difficulty: v.difficulty,
+	}, nil
+}
+
+// Verify implements Verifier.
+func (v PoWVerifier) Verify(_ context.Context, address, _ string, verification Verification) error {
+	value, err := base64.RawURLEncoding.DecodeString(verification.Challenge)
+	if err != nil || len(value) != 24+sha256.Size {
+		return fmt.Errorf("%w: invalid challenge", ErrVerificationFailed)
+	}
+
+	data, signature := value[:24], value[24:]
+	if !hmac.Equal(signature, v.sign(data)) {
+		return fmt.Errorf("%w: invalid challenge", ErrVerificationFailed)
+	}
+
+	now := v.now()
+	expiry := time.Unix(int64(binary.BigEndian.Uint64(data)), 0)
+	if now.After(expiry) {
+		return fmt.Errorf("%w: challenge is expired", ErrVerificationFailed)
+	}
+
+	if PoWZeroBits(verification.Challenge, address, verification.Solution) < v.difficulty {
+		return fmt.Errorf("%w: invalid proof of work", ErrVerificationFailed)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	// solved challenges are kept until they expire to not accept them again.
+	for c, e := range v.used {
+		if now.After(e) {
+			delete(v.used, c)
+		}
+	}
+
+	if _, ok := v.used[verification.Challenge]; ok {
+		return fmt.Errorf("%w: challenge is already used", ErrVerificationFailed)
+	}
+
+	v.used[verification.Challenge] = expiry
+
+	return nil
+}
+
+func (v PoWVerifier) sign(data []byte) []byte {
+	h := hmac.New(sha256.New, v.secret)
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// PoWZeroBits returns the number of leading zero bits in the proof of work hash made with solution
+// for the challenge and address.
+func PoWZeroBits(challenge, address, solution string) uint {
+	hash := sha256.Sum256([]byte(challenge + ":" + address + ":" + solution))
+
+	var n uint
+	for _, b := range hash {
+		if b != 0 {
+			return n + uint(bits.LeadingZeros8(b))
+		}
+		n += 8
+	}
+
+	return n
+}
+
+// SolvePoW finds a solution for the proof of work challenge to request a transfer to address.
+func SolvePoW(ctx context.Context, challenge Challenge, address string) (Verification, error) {
+	for nonce := uint64(0); ; nonce++ {
+		if nonce%10000 == 0 && ctx.Err() != nil {
+			return Verification{}, ctx.Err()
+		}
+
+		solution := strconv.FormatUint(nonce, 10)
+		if PoWZeroBits(challenge.Value, address, solution) >= challenge.Difficulty {
+			return Verification{
+				Challenge: challenge.Value,
+				Solution:  solution,
+			}, nil
+		}
+	}
+}
+
+// CaptchaVerifier verifies captcha responses with the verification endpoint of a captcha provider.
+// hCaptcha, reCAPTCHA and Turnstile share the same verification API.
+type CaptchaVerifier struct {
+	url    string
+	secret string
+}
+
+// NewCaptchaVerifier creates a captcha verifier that verifies responses at url with the secret key.
+func NewCaptchaVerifier(url, secret string) CaptchaVerifier {
+	return CaptchaVerifier{url, secret}
+}
+
+// Challenge implements Verifier.
+func (v CaptchaVerifier) Challenge() (Challenge, error) {
+	return Challenge{Type: VerifierCaptcha}, nil
+}
+
+// Verify implements Verifier.
+func (v CaptchaVerifier) Verify(ctx context.Context, _, ip string, verification Verification) error {
+	if verification.Solution == "" {
+		return fmt.Errorf("%w: captcha response is required", ErrVerificationFailed)
+	}
+
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {verification.Solution},
+	}
+	if ip != "" {
+		form.Set("remoteip", ip)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "cannot verify captcha")
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("cannot verify captcha: %s", http.StatusText(res.StatusCode))
+	}
+
+	var out struct {
+		Success    bool     `json:"success"`
+		ErrorCodes []string `json:"error-codes"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&out); err != nil {
+		return errors.Wrap(err, "cannot verify captcha")
+	}
+
+	if !out.Success {
+		return fmt.Errorf("%w: invalid captcha %s", ErrVerificationFailed, strings.Join(out.ErrorCodes, ", "))
+	}
+
+	return nil
+}
+
+// TestVerifier accepts the transfer requests that are verified with its token.
+// It is meant to be used in tests and in local environments.
+type TestVerifier struct {
+	token []byte
+}
+
+// NewTestVerifier creates a test verifier that accepts token as solution.
+func NewTestVerifier(token string) TestVerifier {
+	return TestVerifier{[]byte(token)}
+}
+
+// Challenge implements Verifier.
+func (v TestVerifier) Challenge() (Challenge, error) {
+	return Challenge{Type: VerifierTest}, nil
+}
+
+// Verify implements Verifier.
+func (v TestVerifier) Verify(_ context.Context, _, _ string, verification Verification) error {
+	if len(v.token) == 0 || subtle.ConstantTimeCompare(v.token, []byte(verification.Solution)) != 1 {
+		return fmt.Errorf("%w: invalid token", ErrVerificationFailed)
+	}
+	return nil
+}
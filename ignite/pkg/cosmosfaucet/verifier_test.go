@@ -0,0 +1,92 @@
+package cosmosfaucet
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testAddress = "cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj"
+
+func TestPoWVerifier(t *testing.T) {
+	ctx := context.Background()
+
+	v, err := NewPoWVerifier(8)
+	require.NoError(t, err)
+
+	challenge, err := v.Challenge()
+	require.NoError(t, err)
+	require.Equal(t, VerifierPoW, challenge.Type)
+	require.EqualValues(t, 8, challenge.Difficulty)
+
+	solution, err := SolvePoW(ctx, challenge, testAddress)
+	require.NoError(t, err)
+
+	// the proof of work is made for the address, a solution is rejected for another
+	// address unless it happens to be a solution for that address too.
+	otherAddress := "cosmos1mmlqwyqk7neqegffp99q86eckpm4pjah3ytlpa"
+	if PoWZeroBits(solution.Challenge, otherAddress, solution.Solution) < 8 {
+		require.ErrorIs(t, v.Verify(ctx, otherAddress, "", solution), ErrVerificationFailed)
+	}
+
+	require.NoError(t, v.Verify(ctx, testAddress, "", solution))
+
+	// challenges can be used once.
+	require.ErrorIs(t, v.Verify(ctx, testAddress, "", solution), ErrVerificationFailed)
+
+	// challenges are signed by the verifier.
+	other, err := NewPoWVerifier(8)
+	require.NoError(t, err)
+	challenge, err = other.Challenge()
+	require.NoError(t, err)
+	solution, err = SolvePoW(ctx, challenge, testAddress)
+	require.NoError(t, err)
+	require.ErrorIs(t, v.Verify(ctx, testAddress, "", solution), ErrVerificationFailed)
+
+	// challenges expire.
+	challenge, err = v.Challenge()
+	require.NoError(t, err)
+	solution, err = SolvePoW(ctx, challenge, testAddress)
+	require.NoError(t, err)
+	v.now = func() time.Time { return time.Now().Add(powChallengeTTL + time.Minute) }
+	require.ErrorIs(t, v.Verify(ctx, testAddress, "", solution), ErrVerificationFailed)
+}
+
+func TestCaptchaVerifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		require.Equal(t, "secret", r.PostForm.Get("secret"))
+		require.Equal(t, "1.2.3.4", r.PostForm.Get("remoteip"))
+
+		if r.PostForm.Get("response") == "valid" {
+			w.Write([]byte(`{"success":true}`))
+			return
+		}
+		w.Write([]byte(`{"success":false,"error-codes":["invalid-input-response"]}`))
+	}))
+	defer server.Close()
+
+	var (
+		ctx = context.Background()
+		v   = NewCaptchaVerifier(server.URL, "secret")
+	)
+
+	require.NoError(t, v.Verify(ctx, testAddress, "1.2.3.4", Verification{Solution: "valid"}))
+	require.ErrorIs(t, v.Verify(ctx, testAddress, "1.2.3.4", Verification{Solution: "invalid"}), ErrVerificationFailed)
+	require.ErrorIs(t, v.Verify(ctx, testAddress, "1.2.3.4", Verification{}), ErrVerificationFailed)
+}
+
+func TestTestVerifier(t *testing.T) {
+	var (
+		ctx = context.Background()
+		v   = NewTestVerifier("token")
+	)
+
+	require.NoError(t, v.Verify(ctx, testAddress, "", Verification{Solution: "token"}))
+	require.ErrorIs(t, v.Verify(ctx, testAddress, "", Verification{Solution: "other"}), ErrVerificationFailed)
+	require.ErrorIs(t, NewTestVerifier("").Verify(ctx, testAddress, "", Verification{}), ErrVerificationFailed)
+}
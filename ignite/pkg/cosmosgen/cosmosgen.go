@@ -13,8 +13,9 @@ import (
 
 // generateOptions used to configure code generation.
 type generateOptions struct {
-	includeDirs []string
-	gomodPath   string
+	includeDirs     []string
+	dependencyPaths []string
+	gomodPath       string
 
 	jsOut               func(module.Module) string
 	jsIncludeThirdParty bool
@@ -25,6 +26,8 @@ type generateOptions struct {
 	dartOut               func(module.Module) string
 	dartIncludeThirdParty bool
 	dartRootPath          string
+
+	plugins []Plugin
 }
 
 // TODO add WithInstall.
@@ -79,6 +82,14 @@ func WithOpenAPIGeneration(out string) Option {
 	}
 }
 
+// WithPluginGeneration adds code generation with custom protoc plugins.
+// Each plugin generates code for the app's modules and optionally for the 3rd party modules.
+func WithPluginGeneration(plugins ...Plugin) Option {
+	return func(o *generateOptions) {
+		o.plugins = append(o.plugins, plugins...)
+	}
+}
+
 // IncludeDirs configures the third party proto dirs that used by app's proto.
 // relative to the projectPath.
 func IncludeDirs(dirs []string) Option {
@@ -87,6 +98,15 @@ func IncludeDirs(dirs []string) Option {
 	}
 }
 
+// DependencyPaths configures the absolute paths of third party proto dependencies
+// that are located outside of the app, e.g. fetched into a cache.
+// Paths are added to the include paths in the given order.
+func DependencyPaths(paths []string) Option {
+	return func(o *generateOptions) {
+		o.dependencyPaths = paths
+	}
+}
+
 // generator generates code for sdk and sdk apps.
 type generator struct {
 	ctx          context.Context
@@ -147,6 +167,12 @@ func Generate(ctx context.Context, cacheStorage cache.Storage, appPath, protoDir
 		}
 	}
 
+	if len(g.o.plugins) > 0 {
+		if err := g.generatePlugins(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
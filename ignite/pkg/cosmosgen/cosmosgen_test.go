@@ -1,6 +1,8 @@
 package cosmosgen
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -57,3 +59,36 @@ func TestVuexStoreModulePath(t *testing.T) {
 		})
 	}
 }
+
+func TestPlugin(t *testing.T) {
+	appPath := t.TempDir()
+	binaryPath := filepath.Join(appPath, "bin", "custom")
+	require.NoError(t, os.MkdirAll(filepath.Dir(binaryPath), 0o755))
+	require.NoError(t, os.WriteFile(binaryPath, nil, 0o755))
+
+	p := Plugin{
+		Name:    "custom",
+		Path:    "bin/custom",
+		Options: []string{"paths=source_relative", "lang=go"},
+		Out:     "gen",
+	}
+	require.NoError(t, p.Validate())
+
+	path, err := p.binaryPath(appPath)
+	require.NoError(t, err)
+	require.Equal(t, binaryPath, path)
+
+	out, plugin, options := p.flags(path)
+	require.Equal(t, "--custom_out=.", out)
+	require.Equal(t, "protoc-gen-custom="+binaryPath, plugin)
+	require.Equal(t, []string{"--custom_opt=paths=source_relative", "--custom_opt=lang=go"}, options)
+
+	_, err = Plugin{Name: "custom", Path: "bin/missing", Out: "gen"}.binaryPath(appPath)
+	require.Error(t, err)
+
+	_, err = Plugin{Name: "ignite-missing-plugin", Out: "gen"}.binaryPath(appPath)
+	require.EqualError(t, err, "protoc plugin ignite-missing-plugin: protoc-gen-ignite-missing-plugin binary cannot be found in $PATH")
+
+	require.EqualError(t, Plugin{Out: "gen"}.Validate(), "protoc plugin name is required")
+	require.EqualError(t, Plugin{Name: "custom"}.Validate(), "protoc plugin custom: out is required")
+}
@@ -170,6 +170,9 @@ func (g *generator) resolveInclude(path string) (paths []string, err error) {
 		paths = append(paths, filepath.Join(path, p))
 	}
 
+	// Append paths of the proto dependencies that are located outside of the app
+	paths = append(paths, g.o.dependencyPaths...)
+
 	// Append paths for dependencies that have protocol buffer files
 	includePaths, err := g.resolveDepencyInclude()
 	if err != nil {
@@ -3,10 +3,15 @@ package cosmosgen
 import (
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/otiai10/copy"
 	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
 
+	"github.com/ignite/cli/ignite/pkg/cache"
+	"github.com/ignite/cli/ignite/pkg/dirchange"
 	"github.com/ignite/cli/ignite/pkg/protoanalysis"
 	"github.com/ignite/cli/ignite/pkg/protoc"
 )
@@ -16,6 +21,11 @@ var goOuts = []string{
 	"--grpc-gateway_out=logtostderr=true:.",
 }
 
+const goDirchangeCacheNamespace = "generate.go.dirchange"
+
+// generatedGoFilePatterns are the patterns of the Go files generated from proto files.
+var generatedGoFilePatterns = []string{"*.pb.go", "*.pb.gw.go"}
+
 func (g *generator) generateGo() error {
 	includePaths, err := g.resolveInclude(g.appPath)
 	if err != nil {
@@ -38,11 +48,74 @@ func (g *generator) generateGo() error {
 		return err
 	}
 
-	// code generate for each module.
+	// only the packages whose proto files, imported proto files or generated
+	// Go files have changed since the last generation are generated again.
+	var (
+		dirCache   = cache.New[[]byte](g.cacheStorage, goDirchangeCacheNamespace)
+		changed    []protoanalysis.Package
+		protoPaths = make(map[string][]string)
+		parsed     = make(map[string]protoanalysis.File)
+	)
+
+	for _, f := range pkgs.Files() {
+		parsed[f.Path] = f
+	}
+
+	checksumPaths := func(pkg protoanalysis.Package) ([]string, error) {
+		outPaths, err := g.generatedGoFiles(pkg)
+		if err != nil {
+			return nil, err
+		}
+		return append(append([]string{}, protoPaths[pkg.Path]...), outPaths...), nil
+	}
+
 	for _, pkg := range pkgs {
-		if err := protoc.Generate(g.ctx, tmp, pkg.Path, includePaths, goOuts); err != nil {
+		deps, err := protoDependencies(parsed, pkg, includePaths)
+		if err != nil {
 			return err
 		}
+
+		protoPaths[pkg.Path] = append([]string{pkg.Path}, deps...)
+
+		paths, err := checksumPaths(pkg)
+		if err != nil {
+			return err
+		}
+
+		hasChanged, err := dirchange.HasDirChecksumChanged(dirCache, pkg.Path, "", paths...)
+		if err != nil {
+			return err
+		}
+
+		if hasChanged {
+			changed = append(changed, pkg)
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil
+	}
+
+	protocCmd, cleanup, err := protoc.Command()
+	if err != nil {
+		return err
+	}
+
+	defer cleanup()
+
+	// code generate for each module. packages are generated independently of each other,
+	// so it can run in parallel.
+	gg := &errgroup.Group{}
+
+	for _, pkg := range changed {
+		pkg := pkg
+		gg.Go(func() error {
+			return protoc.Generate(g.ctx, tmp, pkg.Path, includePaths, goOuts, protoc.WithCommand(protocCmd))
+		})
+	}
+
+	if err := gg.Wait(); err != nil {
+		return err
 	}
 
 	// move generated code for the app under the relative locations in its source code.
@@ -58,5 +131,108 @@ func (g *generator) generateGo() error {
 		return err
 	}
 
+	// checksums are saved after the generated code is placed in the app,
+	// so deleted or modified generated files are detected as changes.
+	for _, pkg := range changed {
+		paths, err := checksumPaths(pkg)
+		if err != nil {
+			return err
+		}
+
+		if err := dirchange.SaveDirChecksum(dirCache, pkg.Path, "", paths...); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// generatedGoFiles returns the paths of the Go files generated for the proto package in the app.
+func (g *generator) generatedGoFiles(pkg protoanalysis.Package) ([]string, error) {
+	// go_package option might also contain the name of the Go package after a semicolon.
+	importPath := strings.Split(pkg.GoImportName, ";")[0]
+
+	rel, err := filepath.Rel(g.o.gomodPath, importPath)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		// code generated outside of the app is not tracked.
+		return nil, nil
+	}
+
+	var paths []string
+	for _, pattern := range generatedGoFilePatterns {
+		matches, err := filepath.Glob(filepath.Join(g.appPath, rel, pattern))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+
+	return paths, nil
+}
+
+// protoDependencies returns the paths of the proto files imported by the files of pkg, including
+// the indirect imports. parsed holds the already parsed files by their paths, it is updated with
+// the imported files that are parsed while looking for the indirect imports.
+// Imports are searched in includePaths and the ones that cannot be found, like the proto files
+// shipped with protoc, are ignored.
+func protoDependencies(parsed map[string]protoanalysis.File, pkg protoanalysis.Package, includePaths []string) ([]string, error) {
+	var (
+		deps    []string
+		visited = make(map[string]bool)
+		visit   func(f protoanalysis.File) error
+	)
+
+	visit = func(f protoanalysis.File) error {
+		for _, dep := range f.Dependencies {
+			path, ok := findProtoFile(dep, includePaths)
+			if !ok || visited[path] {
+				continue
+			}
+			visited[path] = true
+
+			depFile, ok := parsed[path]
+			if !ok {
+				var err error
+				if depFile, err = protoanalysis.ParseFile(path); err != nil {
+					return err
+				}
+				parsed[path] = depFile
+			}
+
+			// files of the package are already a part of the checksum.
+			if filepath.Dir(path) != filepath.Clean(pkg.Path) {
+				deps = append(deps, path)
+			}
+
+			if err := visit(depFile); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, f := range pkg.Files {
+		visited[f.Path] = true
+	}
+
+	for _, f := range pkg.Files {
+		if err := visit(f); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(deps)
+
+	return deps, nil
+}
+
+// findProtoFile returns the path of the imported proto file by searching it in includePaths.
+func findProtoFile(importPath string, includePaths []string) (string, bool) {
+	for _, include := range includePaths {
+		path := filepath.Join(include, importPath)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
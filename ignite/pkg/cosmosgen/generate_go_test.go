@@ -0,0 +1,114 @@
+package cosmosgen
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/protoanalysis"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	}
+}
+
+func TestProtoDependencies(t *testing.T) {
+	var (
+		appPath   = t.TempDir()
+		protoPath = filepath.Join(appPath, "proto")
+		thirdPath = t.TempDir()
+	)
+
+	writeFiles(t, protoPath, map[string]string{
+		"mars/tx.proto": `syntax = "proto3";
+package mars;
+import "mars/params.proto";
+import "gogoproto/gogo.proto";`,
+		"mars/params.proto": `syntax = "proto3";
+package mars;`,
+		"venus/genesis.proto": `syntax = "proto3";
+package venus;
+import "mars/tx.proto";`,
+	})
+	writeFiles(t, thirdPath, map[string]string{
+		"gogoproto/gogo.proto": `syntax = "proto2";
+package gogoproto;
+import "google/protobuf/descriptor.proto";`,
+	})
+
+	pkgs, err := protoanalysis.Parse(context.Background(), nil, protoPath)
+	require.NoError(t, err)
+
+	parsed := make(map[string]protoanalysis.File)
+	for _, f := range pkgs.Files() {
+		parsed[f.Path] = f
+	}
+
+	includePaths := []string{protoPath, thirdPath}
+
+	for _, tt := range []struct {
+		pkg  string
+		want []string
+	}{
+		{
+			pkg:  "mars",
+			want: []string{filepath.Join(thirdPath, "gogoproto/gogo.proto")},
+		},
+		{
+			pkg: "venus",
+			want: []string{
+				filepath.Join(protoPath, "mars/params.proto"),
+				filepath.Join(protoPath, "mars/tx.proto"),
+				filepath.Join(thirdPath, "gogoproto/gogo.proto"),
+			},
+		},
+	} {
+		t.Run(tt.pkg, func(t *testing.T) {
+			var pkg protoanalysis.Package
+			for _, p := range pkgs {
+				if p.Name == tt.pkg {
+					pkg = p
+				}
+			}
+
+			deps, err := protoDependencies(parsed, pkg, includePaths)
+			require.NoError(t, err)
+			require.ElementsMatch(t, tt.want, deps)
+		})
+	}
+}
+
+func TestGeneratedGoFiles(t *testing.T) {
+	appPath := t.TempDir()
+
+	writeFiles(t, appPath, map[string]string{
+		"x/mars/types/tx.pb.go":       "",
+		"x/mars/types/query.pb.go":    "",
+		"x/mars/types/query.pb.gw.go": "",
+		"x/mars/types/msgs.go":        "",
+	})
+
+	g := &generator{
+		appPath: appPath,
+		o:       &generateOptions{gomodPath: "github.com/ignite/mars"},
+	}
+
+	paths, err := g.generatedGoFiles(protoanalysis.Package{GoImportName: "github.com/ignite/mars/x/mars/types;types"})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{
+		filepath.Join(appPath, "x/mars/types/tx.pb.go"),
+		filepath.Join(appPath, "x/mars/types/query.pb.go"),
+		filepath.Join(appPath, "x/mars/types/query.pb.gw.go"),
+	}, paths)
+
+	paths, err = g.generatedGoFiles(protoanalysis.Package{GoImportName: "github.com/cosmos/cosmos-sdk/x/bank/types"})
+	require.NoError(t, err)
+	require.Empty(t, paths)
+}
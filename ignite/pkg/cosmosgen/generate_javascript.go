@@ -80,6 +80,7 @@ func (g *jsGenerator) generateModules() error {
 			gg.Go(func() error {
 				cacheKey := m.Pkg.Path
 				paths := append([]string{m.Pkg.Path, g.g.o.jsOut(m)}, g.g.o.includeDirs...)
+				paths = append(paths, g.g.o.dependencyPaths...)
 				changed, err := dirchange.HasDirChecksumChanged(dirCache, cacheKey, sourcePath, paths...)
 				if err != nil {
 					return err
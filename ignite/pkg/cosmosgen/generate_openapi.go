@@ -54,6 +54,7 @@ func generateOpenAPISpec(g *generator) error {
 		specPath := filepath.Join(dir, "apidocs.swagger.json")
 
 		checksumPaths := append([]string{m.Pkg.Path}, g.o.includeDirs...)
+		checksumPaths = append(checksumPaths, g.o.dependencyPaths...)
 		checksum, err := dirchange.ChecksumFromPaths(src, checksumPaths...)
 		if err != nil {
 			return err
@@ -0,0 +1,208 @@
+package cosmosgen
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ignite/cli/ignite/pkg/cache"
+	"github.com/ignite/cli/ignite/pkg/checksum"
+	"github.com/ignite/cli/ignite/pkg/cosmosanalysis/module"
+	"github.com/ignite/cli/ignite/pkg/dirchange"
+	"github.com/ignite/cli/ignite/pkg/protoc"
+)
+
+const pluginsDirchangeCacheNamespace = "generate.plugins.dirchange"
+
+// Plugin is a custom protoc plugin to generate code with.
+type Plugin struct {
+	// Name is the name of the plugin, it is used to create protoc flags like --name_out.
+	Name string
+
+	// Path is the path of the plugin's binary, relative to the app's path when it isn't absolute.
+	// protoc-gen-name binary is looked up in $PATH when it is empty.
+	Path string
+
+	// Options are passed to the plugin with --name_opt flags.
+	Options []string
+
+	// Out is the output dir of the generated code, relative to the app's path when it isn't absolute.
+	Out string
+
+	// IncludeThirdPartyModules enables code generation for the 3rd party modules used by the app,
+	// including the SDK.
+	IncludeThirdPartyModules bool
+}
+
+// Validate checks that the plugin is correctly configured.
+func (p Plugin) Validate() error {
+	if p.Name == "" {
+		return errors.New("protoc plugin name is required")
+	}
+	if p.Out == "" {
+		return fmt.Errorf("protoc plugin %s: out is required", p.Name)
+	}
+	return nil
+}
+
+// binaryPath returns the absolute path of the plugin's binary.
+func (p Plugin) binaryPath(appPath string) (string, error) {
+	if p.Path != "" {
+		path := p.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(appPath, path)
+		}
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("protoc plugin %s: %w", p.Name, err)
+		}
+		return path, nil
+	}
+
+	name := fmt.Sprintf("protoc-gen-%s", p.Name)
+
+	path, err := exec.LookPath(name)
+	if err != nil {
+		return "", fmt.Errorf("protoc plugin %s: %s binary cannot be found in $PATH", p.Name, name)
+	}
+
+	return path, nil
+}
+
+// flags returns the protoc flags to run the plugin that is located at binaryPath.
+func (p Plugin) flags(binaryPath string) (out string, plugin string, options []string) {
+	out = fmt.Sprintf("--%s_out=.", p.Name)
+
+	// the name of the binary is set explicitly because protoc derives plugin
+	// names from binary names which might not follow the protoc-gen-name format.
+	plugin = fmt.Sprintf("protoc-gen-%s=%s", p.Name, binaryPath)
+
+	for _, o := range p.Options {
+		options = append(options, fmt.Sprintf("--%s_opt=%s", p.Name, o))
+	}
+
+	return out, plugin, options
+}
+
+func (g *generator) generatePlugins() error {
+	protocCmd, cleanup, err := protoc.Command()
+	if err != nil {
+		return err
+	}
+
+	defer cleanup()
+
+	// make sure that all plugins are ready to use before generating any code.
+	binaryPaths := make([]string, len(g.o.plugins))
+
+	for i, p := range g.o.plugins {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+
+		if binaryPaths[i], err = p.binaryPath(g.appPath); err != nil {
+			return err
+		}
+	}
+
+	var (
+		gg       = &errgroup.Group{}
+		dirCache = cache.New[[]byte](g.cacheStorage, pluginsDirchangeCacheNamespace)
+	)
+
+	for i, p := range g.o.plugins {
+		var (
+			p          = p
+			binaryPath = binaryPaths[i]
+			out        = p.Out
+		)
+
+		if !filepath.IsAbs(out) {
+			out = filepath.Join(g.appPath, out)
+		}
+
+		// code is generated for all modules again when the output dir is removed.
+		_, err := os.Stat(out)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		isOutRemoved := os.IsNotExist(err)
+
+		if err := os.MkdirAll(out, 0o766); err != nil {
+			return err
+		}
+
+		add := func(sourcePath string, modules []module.Module) {
+			for _, m := range modules {
+				m := m
+				gg.Go(func() error {
+					// the output dir is shared by all modules, so it isn't a part of the checksum.
+					// the plugin's binary is, to generate code again when the plugin is updated.
+					cacheKey := checksum.Strings(p.Name, binaryPath, strings.Join(p.Options, ","), out, m.Pkg.Path)
+					paths := append([]string{m.Pkg.Path, binaryPath}, g.o.includeDirs...)
+					paths = append(paths, g.o.dependencyPaths...)
+
+					if !isOutRemoved {
+						changed, err := dirchange.HasDirChecksumChanged(dirCache, cacheKey, sourcePath, paths...)
+						if err != nil {
+							return err
+						}
+
+						if !changed {
+							return nil
+						}
+					}
+
+					if err := g.generatePluginModule(g.ctx, protocCmd, p, binaryPath, out, sourcePath, m); err != nil {
+						return err
+					}
+
+					return dirchange.SaveDirChecksum(dirCache, cacheKey, sourcePath, paths...)
+				})
+			}
+		}
+
+		add(g.appPath, g.appModules)
+
+		if p.IncludeThirdPartyModules {
+			for sourcePath, modules := range g.thirdModules {
+				add(sourcePath, modules)
+			}
+		}
+	}
+
+	return gg.Wait()
+}
+
+// generatePluginModule generates code for a module with a custom protoc plugin.
+func (g *generator) generatePluginModule(
+	ctx context.Context,
+	cmd protoc.Cmd,
+	p Plugin,
+	binaryPath, out, appPath string,
+	m module.Module,
+) error {
+	includePaths, err := g.resolveInclude(appPath)
+	if err != nil {
+		return err
+	}
+
+	protocOut, plugin, options := p.flags(binaryPath)
+
+	err = protoc.Generate(
+		ctx,
+		out,
+		m.Pkg.Path,
+		includePaths,
+		[]string{protocOut},
+		protoc.Plugin(plugin, options...),
+		protoc.WithCommand(cmd),
+	)
+
+	return errors.Wrapf(err, "protoc plugin %s", p.Name)
+}
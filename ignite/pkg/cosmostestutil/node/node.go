@@ -0,0 +1,163 @@
+// Package cosmostestutilnode provides a fake Tendermint RPC node to test the clients of a chain
+// without running it.
+package cosmostestutilnode
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpcserver "github.com/tendermint/tendermint/rpc/jsonrpc/server"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+const (
+	// accountQueryPath is the path of the ABCI query of an account.
+	accountQueryPath = "/cosmos.auth.v1beta1.Query/Account"
+
+	// simulateQueryPath is the path of the ABCI query that simulates a tx.
+	simulateQueryPath = "/cosmos.tx.v1beta1.Service/Simulate"
+
+	// SimulatedGas is the gas used by any simulated tx.
+	SimulatedGas = 100000
+)
+
+// Node is a Tendermint RPC server that publishes the events pushed by the tests.
+// A block is produced each time its status is queried. The broadcasted txs are accepted and
+// included in the next block, and any account exists with the number 1 and the sequence 0.
+// The simulated txs use SimulatedGas.
+type Node struct {
+	*httptest.Server
+
+	// Subscriptions receives the context of each subscription to publish its events.
+	Subscriptions chan *rpctypes.Context
+
+	mu          sync.Mutex
+	height      int64
+	txs         map[string]*ctypes.ResultTx
+	broadcasted []tmtypes.Tx
+}
+
+// New starts a node that is closed at the end of the test.
+func New(t *testing.T) *Node {
+	n := &Node{
+		Subscriptions: make(chan *rpctypes.Context, 1),
+		height:        1,
+		txs:           make(map[string]*ctypes.ResultTx),
+	}
+
+	routes := map[string]*rpcserver.RPCFunc{
+		"subscribe": rpcserver.NewWSRPCFunc(func(ctx *rpctypes.Context, query string) (*ctypes.ResultSubscribe, error) {
+			n.Subscriptions <- ctx
+			return &ctypes.ResultSubscribe{}, nil
+		}, "query"),
+		"status":            rpcserver.NewRPCFunc(n.status, ""),
+		"tx":                rpcserver.NewRPCFunc(n.tx, "hash,prove"),
+		"tx_search":         rpcserver.NewRPCFunc(n.txSearch, "query,prove,page,per_page,order_by"),
+		"abci_query":        rpcserver.NewRPCFunc(n.abciQuery, "path,data,height,prove"),
+		"broadcast_tx_sync": rpcserver.NewRPCFunc(n.broadcastTxSync, "tx"),
+	}
+
+	mux := http.NewServeMux()
+	rpcserver.RegisterRPCFuncs(mux, routes, log.NewNopLogger())
+	wm := rpcserver.NewWebsocketManager(routes)
+	mux.HandleFunc("/websocket", wm.WebsocketHandler)
+
+	n.Server = httptest.NewServer(mux)
+	t.Cleanup(n.Close)
+
+	return n
+}
+
+// IncludeTx makes tx queryable by its hash.
+func (n *Node) IncludeTx(tx *ctypes.ResultTx) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.txs[string(tx.Hash)] = tx
+}
+
+// Broadcasted returns the txs broadcasted to the node.
+func (n *Node) Broadcasted() []tmtypes.Tx {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]tmtypes.Tx{}, n.broadcasted...)
+}
+
+// Publish sends an event to the subscription of ctx.
+func (n *Node) Publish(t *testing.T, ctx *rpctypes.Context, data tmtypes.TMEventData) {
+	event := &ctypes.ResultEvent{Query: ctx.JSONReq.Params.String(), Data: data}
+	err := ctx.WSConn.WriteRPCResponse(context.Background(), rpctypes.NewRPCSuccessResponse(ctx.JSONReq.ID, event))
+	require.NoError(t, err)
+}
+
+func (n *Node) status(*rpctypes.Context) (*ctypes.ResultStatus, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.height++
+	return &ctypes.ResultStatus{SyncInfo: ctypes.SyncInfo{LatestBlockHeight: n.height}}, nil
+}
+
+func (n *Node) tx(_ *rpctypes.Context, hash []byte, _ bool) (*ctypes.ResultTx, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if tx, ok := n.txs[string(hash)]; ok {
+		return tx, nil
+	}
+	return nil, errors.New("tx not found")
+}
+
+func (n *Node) txSearch(*rpctypes.Context, string, bool, *int, *int, string) (*ctypes.ResultTxSearch, error) {
+	return &ctypes.ResultTxSearch{}, nil
+}
+
+func (n *Node) abciQuery(_ *rpctypes.Context, path string, _ tmbytes.HexBytes, _ int64, _ bool) (*ctypes.ResultABCIQuery, error) {
+	var res interface{ Marshal() ([]byte, error) }
+
+	switch path {
+	case accountQueryPath:
+		account, err := codectypes.NewAnyWithValue(&authtypes.BaseAccount{AccountNumber: 1})
+		if err != nil {
+			return nil, err
+		}
+		res = &authtypes.QueryAccountResponse{Account: account}
+
+	case simulateQueryPath:
+		res = &txtypes.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: SimulatedGas}, Result: &sdk.Result{}}
+
+	default:
+		return nil, fmt.Errorf("unknown query %s", path)
+	}
+
+	value, err := res.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultABCIQuery{Response: abci.ResponseQuery{Value: value, Height: 1}}, nil
+}
+
+func (n *Node) broadcastTxSync(_ *rpctypes.Context, tx tmtypes.Tx) (*ctypes.ResultBroadcastTx, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.broadcasted = append(n.broadcasted, tx)
+	n.txs[string(tx.Hash())] = &ctypes.ResultTx{Hash: tx.Hash(), Height: n.height + 1, Tx: tx}
+
+	return &ctypes.ResultBroadcastTx{Hash: tx.Hash()}, nil
+}
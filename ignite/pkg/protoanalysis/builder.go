@@ -46,14 +46,23 @@ func (b builder) buildMessages() (messages []Message) {
 	for _, f := range b.p.files {
 		for _, message := range f.messages {
 
-			// Find the highest field number
-			var highestFieldNumber int
+			// Find the highest field number and collect the fields
+			var (
+				highestFieldNumber int
+				fields             []Field
+			)
 			for _, elem := range message.Elements {
 				field, ok := elem.(*proto.NormalField)
 				if ok {
 					if field.Sequence > highestFieldNumber {
 						highestFieldNumber = field.Sequence
 					}
+
+					fields = append(fields, Field{
+						Name:       field.Name,
+						Type:       field.Type,
+						IsRepeated: field.Repeated,
+					})
 				}
 			}
 
@@ -82,6 +91,7 @@ func (b builder) buildMessages() (messages []Message) {
 				Name:               name,
 				Path:               f.path,
 				HighestFieldNumber: highestFieldNumber,
+				Fields:             fields,
 			})
 		}
 	}
@@ -81,6 +81,22 @@ type Message struct {
 	// HighestFieldNumber is the highest field number among fields of the message
 	// This allows to determine new field number when writing to proto message
 	HighestFieldNumber int
+
+	// Fields is a list of fields defined in the message.
+	Fields []Field
+}
+
+// Field is a proto message field.
+type Field struct {
+	// Name of the field.
+	Name string
+
+	// Type of the field as it is written in the proto file.
+	// e.g. string or cosmos.base.query.v1beta1.PageRequest.
+	Type string
+
+	// IsRepeated indicates if the field is a list.
+	IsRepeated bool
 }
 
 // Service is an RPC service.
@@ -49,55 +49,493 @@ func TestLiquidity(t *testing.T) {
 			},
 			GoImportName: "github.com/tendermint/liquidity/x/liquidity/types",
 			Messages: []Message{
-				{Name: "PoolRecord", Path: "testdata/liquidity/genesis.proto", HighestFieldNumber: 6},
-				{Name: "GenesisState", Path: "testdata/liquidity/genesis.proto", HighestFieldNumber: 2},
-				{Name: "PoolType", Path: "testdata/liquidity/liquidity.proto", HighestFieldNumber: 5},
-				{Name: "Params", Path: "testdata/liquidity/liquidity.proto", HighestFieldNumber: 9},
-				{Name: "Pool", Path: "testdata/liquidity/liquidity.proto", HighestFieldNumber: 5},
-				{Name: "PoolMetadata", Path: "testdata/liquidity/liquidity.proto", HighestFieldNumber: 3},
-				{Name: "PoolMetadataResponse", Path: "testdata/liquidity/liquidity.proto", HighestFieldNumber: 2},
-				{Name: "PoolBatch", Path: "testdata/liquidity/liquidity.proto", HighestFieldNumber: 7},
-				{Name: "PoolBatchResponse", Path: "testdata/liquidity/liquidity.proto", HighestFieldNumber: 6},
-				{Name: "DepositMsgState", Path: "testdata/liquidity/liquidity.proto", HighestFieldNumber: 6},
-				{Name: "WithdrawMsgState", Path: "testdata/liquidity/liquidity.proto", HighestFieldNumber: 6},
-				{Name: "SwapMsgState", Path: "testdata/liquidity/liquidity.proto", HighestFieldNumber: 10},
-				{Name: "QueryLiquidityPoolRequest", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 1},
-				{Name: "QueryLiquidityPoolResponse", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 1},
-				{Name: "QueryLiquidityPoolBatchRequest", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 1},
-				{Name: "QueryLiquidityPoolBatchResponse", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 1},
-				{Name: "QueryLiquidityPoolsRequest", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 1},
-				{Name: "QueryLiquidityPoolsResponse", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 2},
+				{
+					Name:               "PoolRecord",
+					Path:               "testdata/liquidity/genesis.proto",
+					HighestFieldNumber: 6,
+					Fields: []Field{
+						{Name: "pool", Type: "Pool"},
+						{Name: "pool_metadata", Type: "PoolMetadata"},
+						{Name: "pool_batch", Type: "PoolBatch"},
+						{Name: "deposit_msg_states", Type: "DepositMsgState", IsRepeated: true},
+						{Name: "withdraw_msg_states", Type: "WithdrawMsgState", IsRepeated: true},
+						{Name: "swap_msg_states", Type: "SwapMsgState", IsRepeated: true},
+					},
+				},
+				{
+					Name:               "GenesisState",
+					Path:               "testdata/liquidity/genesis.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "params", Type: "Params"},
+						{Name: "pool_records", Type: "PoolRecord", IsRepeated: true},
+					},
+				},
+				{
+					Name:               "PoolType",
+					Path:               "testdata/liquidity/liquidity.proto",
+					HighestFieldNumber: 5,
+					Fields: []Field{
+						{Name: "id", Type: "uint32"},
+						{Name: "name", Type: "string"},
+						{Name: "min_reserve_coin_num", Type: "uint32"},
+						{Name: "max_reserve_coin_num", Type: "uint32"},
+						{Name: "description", Type: "string"},
+					},
+				},
+				{
+					Name:               "Params",
+					Path:               "testdata/liquidity/liquidity.proto",
+					HighestFieldNumber: 9,
+					Fields: []Field{
+						{Name: "pool_types", Type: "PoolType", IsRepeated: true},
+						{Name: "min_init_deposit_amount", Type: "string"},
+						{Name: "init_pool_coin_mint_amount", Type: "string"},
+						{Name: "max_reserve_coin_amount", Type: "string"},
+						{Name: "pool_creation_fee", Type: "cosmos.base.v1beta1.Coin", IsRepeated: true},
+						{Name: "swap_fee_rate", Type: "bytes"},
+						{Name: "withdraw_fee_rate", Type: "bytes"},
+						{Name: "max_order_amount_ratio", Type: "bytes"},
+						{Name: "unit_batch_height", Type: "uint32"},
+					},
+				},
+				{
+					Name:               "Pool",
+					Path:               "testdata/liquidity/liquidity.proto",
+					HighestFieldNumber: 5,
+					Fields: []Field{
+						{Name: "id", Type: "uint64"},
+						{Name: "type_id", Type: "uint32"},
+						{Name: "reserve_coin_denoms", Type: "string", IsRepeated: true},
+						{Name: "reserve_account_address", Type: "string"},
+						{Name: "pool_coin_denom", Type: "string"},
+					},
+				},
+				{
+					Name:               "PoolMetadata",
+					Path:               "testdata/liquidity/liquidity.proto",
+					HighestFieldNumber: 3,
+					Fields: []Field{
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "pool_coin_total_supply", Type: "cosmos.base.v1beta1.Coin"},
+						{Name: "reserve_coins", Type: "cosmos.base.v1beta1.Coin", IsRepeated: true},
+					},
+				},
+				{
+					Name:               "PoolMetadataResponse",
+					Path:               "testdata/liquidity/liquidity.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "pool_coin_total_supply", Type: "cosmos.base.v1beta1.Coin"},
+						{Name: "reserve_coins", Type: "cosmos.base.v1beta1.Coin", IsRepeated: true},
+					},
+				},
+				{
+					Name:               "PoolBatch",
+					Path:               "testdata/liquidity/liquidity.proto",
+					HighestFieldNumber: 7,
+					Fields: []Field{
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "index", Type: "uint64"},
+						{Name: "begin_height", Type: "int64"},
+						{Name: "deposit_msg_index", Type: "uint64"},
+						{Name: "withdraw_msg_index", Type: "uint64"},
+						{Name: "swap_msg_index", Type: "uint64"},
+						{Name: "executed", Type: "bool"},
+					},
+				},
+				{
+					Name:               "PoolBatchResponse",
+					Path:               "testdata/liquidity/liquidity.proto",
+					HighestFieldNumber: 6,
+					Fields: []Field{
+						{Name: "index", Type: "uint64"},
+						{Name: "begin_height", Type: "int64"},
+						{Name: "deposit_msg_index", Type: "uint64"},
+						{Name: "withdraw_msg_index", Type: "uint64"},
+						{Name: "swap_msg_index", Type: "uint64"},
+						{Name: "executed", Type: "bool"},
+					},
+				},
+				{
+					Name:               "DepositMsgState",
+					Path:               "testdata/liquidity/liquidity.proto",
+					HighestFieldNumber: 6,
+					Fields: []Field{
+						{Name: "msg_height", Type: "int64"},
+						{Name: "msg_index", Type: "uint64"},
+						{Name: "executed", Type: "bool"},
+						{Name: "succeeded", Type: "bool"},
+						{Name: "to_be_deleted", Type: "bool"},
+						{Name: "msg", Type: "MsgDepositWithinBatch"},
+					},
+				},
+				{
+					Name:               "WithdrawMsgState",
+					Path:               "testdata/liquidity/liquidity.proto",
+					HighestFieldNumber: 6,
+					Fields: []Field{
+						{Name: "msg_height", Type: "int64"},
+						{Name: "msg_index", Type: "uint64"},
+						{Name: "executed", Type: "bool"},
+						{Name: "succeeded", Type: "bool"},
+						{Name: "to_be_deleted", Type: "bool"},
+						{Name: "msg", Type: "MsgWithdrawWithinBatch"},
+					},
+				},
+				{
+					Name:               "SwapMsgState",
+					Path:               "testdata/liquidity/liquidity.proto",
+					HighestFieldNumber: 10,
+					Fields: []Field{
+						{Name: "msg_height", Type: "int64"},
+						{Name: "msg_index", Type: "uint64"},
+						{Name: "executed", Type: "bool"},
+						{Name: "succeeded", Type: "bool"},
+						{Name: "to_be_deleted", Type: "bool"},
+						{Name: "order_expiry_height", Type: "int64"},
+						{Name: "exchanged_offer_coin", Type: "cosmos.base.v1beta1.Coin"},
+						{Name: "remaining_offer_coin", Type: "cosmos.base.v1beta1.Coin"},
+						{Name: "reserved_offer_coin_fee", Type: "cosmos.base.v1beta1.Coin"},
+						{Name: "msg", Type: "MsgSwapWithinBatch"},
+					},
+				},
+				{
+					Name:               "QueryLiquidityPoolRequest",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "pool_id", Type: "uint64"},
+					},
+				},
+				{
+					Name:               "QueryLiquidityPoolResponse",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "pool", Type: "Pool"},
+					},
+				},
+				{
+					Name:               "QueryLiquidityPoolBatchRequest",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "pool_id", Type: "uint64"},
+					},
+				},
+				{
+					Name:               "QueryLiquidityPoolBatchResponse",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "batch", Type: "PoolBatch"},
+					},
+				},
+				{
+					Name:               "QueryLiquidityPoolsRequest",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "pagination", Type: "cosmos.base.query.v1beta1.PageRequest"},
+					},
+				},
+				{
+					Name:               "QueryLiquidityPoolsResponse",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "pools", Type: "Pool", IsRepeated: true},
+						{Name: "pagination", Type: "cosmos.base.query.v1beta1.PageResponse"},
+					},
+				},
 				{Name: "QueryParamsRequest", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 0},
-				{Name: "QueryParamsResponse", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 1},
-				{Name: "QueryPoolBatchSwapMsgsRequest", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 2},
-				{Name: "QueryPoolBatchSwapMsgRequest", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 2},
-				{Name: "QueryPoolBatchSwapMsgsResponse", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 2},
-				{Name: "QueryPoolBatchSwapMsgResponse", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 1},
-				{Name: "QueryPoolBatchDepositMsgsRequest", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 2},
-				{Name: "QueryPoolBatchDepositMsgRequest", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 2},
-				{Name: "QueryPoolBatchDepositMsgsResponse", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 2},
-				{Name: "QueryPoolBatchDepositMsgResponse", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 1},
-				{Name: "QueryPoolBatchWithdrawMsgsRequest", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 2},
-				{Name: "QueryPoolBatchWithdrawMsgRequest", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 2},
-				{Name: "QueryPoolBatchWithdrawMsgsResponse", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 2},
-				{Name: "QueryPoolBatchWithdrawMsgResponse", Path: "testdata/liquidity/query.proto", HighestFieldNumber: 1},
-				{Name: "MsgCreatePool", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 4},
-				{Name: "MsgCreatePoolRequest", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 2},
-				{Name: "MsgCreatePoolResponse", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 1},
-				{Name: "MsgDepositWithinBatch", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 3},
-				{Name: "MsgDepositWithinBatchRequest", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 3},
-				{Name: "MsgDepositWithinBatchResponse", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 1},
-				{Name: "MsgWithdrawWithinBatch", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 3},
-				{Name: "MsgWithdrawWithinBatchRequest", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 3},
-				{Name: "MsgWithdrawWithinBatchResponse", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 1},
-				{Name: "MsgSwapWithinBatch", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 7},
-				{Name: "MsgSwapWithinBatchRequest", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 3},
-				{Name: "MsgSwapWithinBatchResponse", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 1},
-				{Name: "BaseReq", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 11},
-				{Name: "Fee", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 2},
-				{Name: "PubKey", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 2},
-				{Name: "Signature", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 4},
-				{Name: "StdTx", Path: "testdata/liquidity/tx.proto", HighestFieldNumber: 4},
+				{
+					Name:               "QueryParamsResponse",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "params", Type: "Params"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchSwapMsgsRequest",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "pagination", Type: "cosmos.base.query.v1beta1.PageRequest"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchSwapMsgRequest",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "msg_index", Type: "uint64"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchSwapMsgsResponse",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "swaps", Type: "SwapMsgState", IsRepeated: true},
+						{Name: "pagination", Type: "cosmos.base.query.v1beta1.PageResponse"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchSwapMsgResponse",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "swap", Type: "SwapMsgState"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchDepositMsgsRequest",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "pagination", Type: "cosmos.base.query.v1beta1.PageRequest"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchDepositMsgRequest",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "msg_index", Type: "uint64"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchDepositMsgsResponse",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "deposits", Type: "DepositMsgState", IsRepeated: true},
+						{Name: "pagination", Type: "cosmos.base.query.v1beta1.PageResponse"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchDepositMsgResponse",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "deposit", Type: "DepositMsgState"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchWithdrawMsgsRequest",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "pagination", Type: "cosmos.base.query.v1beta1.PageRequest"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchWithdrawMsgRequest",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "msg_index", Type: "uint64"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchWithdrawMsgsResponse",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "withdraws", Type: "WithdrawMsgState", IsRepeated: true},
+						{Name: "pagination", Type: "cosmos.base.query.v1beta1.PageResponse"},
+					},
+				},
+				{
+					Name:               "QueryPoolBatchWithdrawMsgResponse",
+					Path:               "testdata/liquidity/query.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "withdraw", Type: "WithdrawMsgState"},
+					},
+				},
+				{
+					Name:               "MsgCreatePool",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 4,
+					Fields: []Field{
+						{Name: "pool_creator_address", Type: "string"},
+						{Name: "pool_type_id", Type: "uint32"},
+						{Name: "deposit_coins", Type: "cosmos.base.v1beta1.Coin", IsRepeated: true},
+					},
+				},
+				{
+					Name:               "MsgCreatePoolRequest",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "base_req", Type: "BaseReq"},
+						{Name: "msg", Type: "MsgCreatePool"},
+					},
+				},
+				{
+					Name:               "MsgCreatePoolResponse",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "std_tx", Type: "StdTx"},
+					},
+				},
+				{
+					Name:               "MsgDepositWithinBatch",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 3,
+					Fields: []Field{
+						{Name: "depositor_address", Type: "string"},
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "deposit_coins", Type: "cosmos.base.v1beta1.Coin", IsRepeated: true},
+					},
+				},
+				{
+					Name:               "MsgDepositWithinBatchRequest",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 3,
+					Fields: []Field{
+						{Name: "base_req", Type: "BaseReq"},
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "msg", Type: "MsgDepositWithinBatch"},
+					},
+				},
+				{
+					Name:               "MsgDepositWithinBatchResponse",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "std_tx", Type: "StdTx"},
+					},
+				},
+				{
+					Name:               "MsgWithdrawWithinBatch",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 3,
+					Fields: []Field{
+						{Name: "withdrawer_address", Type: "string"},
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "pool_coin", Type: "cosmos.base.v1beta1.Coin"},
+					},
+				},
+				{
+					Name:               "MsgWithdrawWithinBatchRequest",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 3,
+					Fields: []Field{
+						{Name: "base_req", Type: "BaseReq"},
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "msg", Type: "MsgWithdrawWithinBatch"},
+					},
+				},
+				{
+					Name:               "MsgWithdrawWithinBatchResponse",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "std_tx", Type: "StdTx"},
+					},
+				},
+				{
+					Name:               "MsgSwapWithinBatch",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 7,
+					Fields: []Field{
+						{Name: "swap_requester_address", Type: "string"},
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "swap_type_id", Type: "uint32"},
+						{Name: "offer_coin", Type: "cosmos.base.v1beta1.Coin"},
+						{Name: "demand_coin_denom", Type: "string"},
+						{Name: "offer_coin_fee", Type: "cosmos.base.v1beta1.Coin"},
+						{Name: "order_price", Type: "bytes"},
+					},
+				},
+				{
+					Name:               "MsgSwapWithinBatchRequest",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 3,
+					Fields: []Field{
+						{Name: "base_req", Type: "BaseReq"},
+						{Name: "pool_id", Type: "uint64"},
+						{Name: "msg", Type: "MsgSwapWithinBatch"},
+					},
+				},
+				{
+					Name:               "MsgSwapWithinBatchResponse",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 1,
+					Fields: []Field{
+						{Name: "std_tx", Type: "StdTx"},
+					},
+				},
+				{
+					Name:               "BaseReq",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 11,
+					Fields: []Field{
+						{Name: "from", Type: "string"},
+						{Name: "memo", Type: "string"},
+						{Name: "chain_id", Type: "string"},
+						{Name: "account_number", Type: "uint64"},
+						{Name: "sequence", Type: "uint64"},
+						{Name: "timeout_height", Type: "uint64"},
+						{Name: "fees", Type: "cosmos.base.v1beta1.Coin", IsRepeated: true},
+						{Name: "gas_prices", Type: "cosmos.base.v1beta1.DecCoin", IsRepeated: true},
+						{Name: "gas", Type: "uint64"},
+						{Name: "gas_adjustment", Type: "string"},
+						{Name: "simulate", Type: "bool"},
+					},
+				},
+				{
+					Name:               "Fee",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "gas", Type: "uint64"},
+						{Name: "amount", Type: "cosmos.base.v1beta1.Coin", IsRepeated: true},
+					},
+				},
+				{
+					Name:               "PubKey",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 2,
+					Fields: []Field{
+						{Name: "type", Type: "string"},
+						{Name: "value", Type: "string"},
+					},
+				},
+				{
+					Name:               "Signature",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 4,
+					Fields: []Field{
+						{Name: "signature", Type: "string"},
+						{Name: "pub_key", Type: "PubKey"},
+						{Name: "account_number", Type: "uint64"},
+						{Name: "sequence", Type: "uint64"},
+					},
+				},
+				{
+					Name:               "StdTx",
+					Path:               "testdata/liquidity/tx.proto",
+					HighestFieldNumber: 4,
+					Fields: []Field{
+						{Name: "msg", Type: "string", IsRepeated: true},
+						{Name: "fee", Type: "Fee"},
+						{Name: "memo", Type: "string"},
+						{Name: "signature", Type: "Signature"},
+					},
+				},
 			},
 			Services: []Service{
 				{
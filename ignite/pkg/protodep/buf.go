@@ -0,0 +1,238 @@
+package protodep
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	bufResolvePinsMethod = "buf.alpha.registry.v1alpha1.ResolveService/GetModulePins"
+	bufDownloadMethod    = "buf.alpha.registry.v1alpha1.DownloadService/Download"
+
+	bufDefaultReference = "main"
+
+	// bufDependenciesFile is the name of the file that keeps the dependencies of a downloaded module.
+	bufDependenciesFile = ".dependencies.json"
+)
+
+// defaultBufAddress returns the API address of a Buf registry remote.
+func defaultBufAddress(remote string) string {
+	return fmt.Sprintf("https://api.%s", remote)
+}
+
+// moduleRef is a Buf module reference.
+type moduleRef struct {
+	Remote     string
+	Owner      string
+	Repository string
+}
+
+func (m moduleRef) String() string {
+	return path.Join(m.Remote, m.Owner, m.Repository)
+}
+
+// parseModuleRef parses a module reference in the remote/owner/repository format.
+func parseModuleRef(module string) (moduleRef, error) {
+	items := strings.Split(module, "/")
+	if len(items) != 3 || items[0] == "" || items[1] == "" || items[2] == "" {
+		return moduleRef{}, fmt.Errorf("invalid buf module %q, expected format is remote/owner/repository", module)
+	}
+
+	return moduleRef{
+		Remote:     items[0],
+		Owner:      items[1],
+		Repository: items[2],
+	}, nil
+}
+
+// bufRegistry calls Buf Schema Registry APIs by using the JSON encoding of the Connect protocol.
+type bufRegistry struct {
+	address func(remote string) string
+	client  *http.Client
+}
+
+func newBufRegistry(address func(remote string) string) bufRegistry {
+	return bufRegistry{
+		address: address,
+		client:  http.DefaultClient,
+	}
+}
+
+type bufModulePin struct {
+	Remote     string `json:"remote"`
+	Owner      string `json:"owner"`
+	Repository string `json:"repository"`
+	Commit     string `json:"commit"`
+}
+
+// bufPinnedModule is a module pinned to a commit.
+type bufPinnedModule struct {
+	Module string `json:"module"`
+	Commit string `json:"commit"`
+}
+
+type bufModule struct {
+	Files []struct {
+		Path    string `json:"path"`
+		Content []byte `json:"content"`
+	} `json:"files"`
+	Dependencies []bufModulePin `json:"dependencies"`
+}
+
+// resolveCommit resolves the commit of the module at the given reference.
+func (b bufRegistry) resolveCommit(ctx context.Context, module, reference string) (string, error) {
+	ref, err := parseModuleRef(module)
+	if err != nil {
+		return "", err
+	}
+
+	if reference == "" {
+		reference = bufDefaultReference
+	}
+
+	type moduleReference struct {
+		Remote     string `json:"remote"`
+		Owner      string `json:"owner"`
+		Repository string `json:"repository"`
+		Reference  string `json:"reference"`
+	}
+
+	var (
+		req = struct {
+			ModuleReferences []moduleReference `json:"moduleReferences"`
+		}{
+			ModuleReferences: []moduleReference{{
+				Remote:     ref.Remote,
+				Owner:      ref.Owner,
+				Repository: ref.Repository,
+				Reference:  reference,
+			}},
+		}
+		res struct {
+			ModulePins []bufModulePin `json:"modulePins"`
+		}
+	)
+
+	if err := b.call(ctx, ref.Remote, bufResolvePinsMethod, req, &res); err != nil {
+		return "", err
+	}
+
+	if len(res.ModulePins) == 0 || res.ModulePins[0].Commit == "" {
+		return "", fmt.Errorf("cannot resolve %s at %s", module, reference)
+	}
+
+	return res.ModulePins[0].Commit, nil
+}
+
+// download downloads the proto files of the module at commit into dir.
+// Dependencies of the module are saved into the dir as well, so they can be
+// read later without calling the registry again.
+func (b bufRegistry) download(ctx context.Context, module, commit, dir string) error {
+	m, err := b.module(ctx, module, commit)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range m.Files {
+		if path.Ext(f.Path) != protoFileExt {
+			continue
+		}
+		if err := writeProtoFile(dir, f.Path, f.Content); err != nil {
+			return err
+		}
+	}
+
+	var deps []bufPinnedModule
+	for _, pin := range m.Dependencies {
+		deps = append(deps, bufPinnedModule{
+			Module: moduleRef{pin.Remote, pin.Owner, pin.Repository}.String(),
+			Commit: pin.Commit,
+		})
+	}
+
+	content, err := json.Marshal(deps)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, bufDependenciesFile), content, 0o644)
+}
+
+// readBufDependencies reads the dependencies of a module downloaded into dir.
+func readBufDependencies(dir string) ([]bufPinnedModule, error) {
+	content, err := os.ReadFile(filepath.Join(dir, bufDependenciesFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var deps []bufPinnedModule
+	err = json.Unmarshal(content, &deps)
+	return deps, err
+}
+
+func (b bufRegistry) module(ctx context.Context, module, commit string) (bufModule, error) {
+	ref, err := parseModuleRef(module)
+	if err != nil {
+		return bufModule{}, err
+	}
+
+	var (
+		req = struct {
+			Owner      string `json:"owner"`
+			Repository string `json:"repository"`
+			Reference  string `json:"reference"`
+		}{ref.Owner, ref.Repository, commit}
+		res struct {
+			Module bufModule `json:"module"`
+		}
+	)
+
+	if err := b.call(ctx, ref.Remote, bufDownloadMethod, req, &res); err != nil {
+		return bufModule{}, err
+	}
+
+	return res.Module, nil
+}
+
+func (b bufRegistry) call(ctx context.Context, remote, method string, req, res interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/%s", b.address(remote), method)
+
+	hreq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	hreq.Header.Set("Content-Type", "application/json")
+
+	hres, err := b.client.Do(hreq)
+	if err != nil {
+		return err
+	}
+	defer hres.Body.Close()
+
+	if hres.StatusCode != http.StatusOK {
+		var rerr struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(hres.Body).Decode(&rerr); err != nil || rerr.Message == "" {
+			return fmt.Errorf("buf registry %s: %s", method, hres.Status)
+		}
+		return fmt.Errorf("buf registry %s: %s: %s", method, rerr.Code, rerr.Message)
+	}
+
+	return errors.Wrap(json.NewDecoder(hres.Body).Decode(res), "cannot decode buf registry response")
+}
@@ -0,0 +1,122 @@
+package protodep
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// gitFetcher fetches proto files from git repositories.
+// Repositories are cloned once per resolution and reused for all revisions.
+type gitFetcher struct {
+	clones map[string]*git.Repository
+	dirs   []string
+}
+
+func newGitFetcher() *gitFetcher {
+	return &gitFetcher{
+		clones: make(map[string]*git.Repository),
+	}
+}
+
+// cleanup removes the cloned repositories.
+func (g *gitFetcher) cleanup() {
+	for _, dir := range g.dirs {
+		os.RemoveAll(dir)
+	}
+}
+
+func (g *gitFetcher) clone(ctx context.Context, url string) (*git.Repository, error) {
+	if repo, ok := g.clones[url]; ok {
+		return repo, nil
+	}
+
+	dir, err := os.MkdirTemp("", "protodep-git")
+	if err != nil {
+		return nil, err
+	}
+	g.dirs = append(g.dirs, dir)
+
+	repo, err := git.PlainCloneContext(ctx, dir, false, &git.CloneOptions{
+		URL: url,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g.clones[url] = repo
+
+	return repo, nil
+}
+
+// resolveCommit resolves the commit of a revision, HEAD is used when revision is empty.
+// revision can be a commit, a tag or a branch of the remote.
+func (g *gitFetcher) resolveCommit(ctx context.Context, url, revision string) (string, error) {
+	repo, err := g.clone(ctx, url)
+	if err != nil {
+		return "", err
+	}
+
+	if revision == "" {
+		ref, err := repo.Head()
+		if err != nil {
+			return "", err
+		}
+		return ref.Hash().String(), nil
+	}
+
+	// branches other than the default one are only available as remote branches.
+	for _, rev := range []string{revision, fmt.Sprintf("%s/%s", git.DefaultRemoteName, revision)} {
+		hash, err := repo.ResolveRevision(plumbing.Revision(rev))
+		if err == nil {
+			return hash.String(), nil
+		}
+		if err != plumbing.ErrReferenceNotFound {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("cannot resolve revision %s of %s", revision, url)
+}
+
+// fetch copies the proto files found under path of the repository at commit into dir.
+func (g *gitFetcher) fetch(ctx context.Context, url, commit, path, dir string) error {
+	repo, err := g.clone(ctx, url)
+	if err != nil {
+		return err
+	}
+
+	c, err := repo.CommitObject(plumbing.NewHash(commit))
+	if err != nil {
+		return err
+	}
+
+	tree, err := c.Tree()
+	if err != nil {
+		return err
+	}
+
+	if path != "" {
+		if tree, err = tree.Tree(filepath.ToSlash(path)); err != nil {
+			return fmt.Errorf("cannot find %s in %s: %w", path, url, err)
+		}
+	}
+
+	return tree.Files().ForEach(func(f *object.File) error {
+		if filepath.Ext(f.Name) != protoFileExt {
+			return nil
+		}
+
+		content, err := f.Contents()
+		if err != nil {
+			return err
+		}
+
+		return writeProtoFile(dir, f.Name, []byte(content))
+	})
+}
@@ -0,0 +1,82 @@
+package protodep
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ignite/cli/ignite/pkg/confile"
+)
+
+// LockVersion is the version of the lock file format.
+const LockVersion = "1"
+
+// Lock pins the proto dependencies to commits.
+type Lock struct {
+	Version      string             `yaml:"version"`
+	Dependencies []LockedDependency `yaml:"dependencies"`
+}
+
+// LockedDependency is a dependency pinned to a commit.
+type LockedDependency struct {
+	// Module is the Buf module reference.
+	Module string `yaml:"module,omitempty"`
+
+	// Git is the URL of the git repository.
+	Git string `yaml:"git,omitempty"`
+
+	// Path is the dir of the proto files inside the git repository.
+	Path string `yaml:"path,omitempty"`
+
+	// Revision is the revision requested in the config.
+	Revision string `yaml:"revision,omitempty"`
+
+	// Commit is the commit that the revision resolved to.
+	Commit string `yaml:"commit"`
+
+	// Digest is the digest of the fetched proto files.
+	Digest string `yaml:"digest"`
+}
+
+// Name returns the name that identifies the dependency source.
+func (d LockedDependency) Name() string {
+	return Dependency{Module: d.Module, Git: d.Git, Path: d.Path}.Name()
+}
+
+// LoadLock loads the lock file from path.
+// An empty lock is returned when the file doesn't exist.
+func LoadLock(path string) (Lock, error) {
+	var lock Lock
+
+	if err := confile.New(confile.DefaultYAMLEncodingCreator, path).Load(&lock); err != nil {
+		return Lock{}, err
+	}
+
+	if lock.Version != "" && lock.Version != LockVersion {
+		return Lock{}, fmt.Errorf("unsupported proto lock file version %q", lock.Version)
+	}
+
+	return lock, nil
+}
+
+// Save saves the lock file to path.
+func (l Lock) Save(path string) error {
+	return confile.New(confile.DefaultYAMLEncodingCreator, path).Save(l)
+}
+
+// Equal checks if both locks pin the same dependencies.
+func (l Lock) Equal(other Lock) bool {
+	if len(l.Dependencies) == 0 && len(other.Dependencies) == 0 {
+		return l.Version == other.Version
+	}
+	return reflect.DeepEqual(l, other)
+}
+
+// find looks for a locked dependency with the same source and requested revision.
+func (l Lock) find(dep Dependency, revision string) (LockedDependency, bool) {
+	for _, d := range l.Dependencies {
+		if d.Name() == dep.Name() && d.Revision == revision {
+			return d, true
+		}
+	}
+	return LockedDependency{}, false
+}
@@ -0,0 +1,338 @@
+// Package protodep fetches third party proto dependencies from Buf Schema Registry
+// modules and git repositories into a local cache and pins their versions in a lock file,
+// so proto include paths can be resolved deterministically.
+package protodep
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/ignite/cli/ignite/pkg/checksum"
+)
+
+// LockFileName is the default name of the lock file placed in the app's root.
+const LockFileName = "proto.lock"
+
+const protoFileExt = ".proto"
+
+// ErrDigestMismatch is returned when the content of a cached dependency doesn't match
+// the digest recorded in the lock file.
+var ErrDigestMismatch = errors.New("proto dependency digest doesn't match with the lock file")
+
+// Dependency is a proto dependency that is either a Buf module or a git repository.
+type Dependency struct {
+	// Module is a Buf module reference in the remote/owner/repository format.
+	// e.g. buf.build/cosmos/gogo-proto.
+	Module string
+
+	// Git is the URL of a git repository hosting proto files.
+	Git string
+
+	// Revision is the commit, tag or branch to fetch.
+	// The default branch is used when it's empty.
+	Revision string
+
+	// Path is the dir of the proto files inside the git repository.
+	// The root of the repository is used when it's empty.
+	Path string
+}
+
+// Validate checks that the dependency is either a Buf module or a git repository.
+func (d Dependency) Validate() error {
+	switch {
+	case d.Module == "" && d.Git == "":
+		return errors.New("proto dependency must have a module or a git repository")
+	case d.Module != "" && d.Git != "":
+		return fmt.Errorf("proto dependency %s cannot have both a module and a git repository", d.Module)
+	case d.Module != "":
+		if _, err := parseModuleRef(d.Module); err != nil {
+			return err
+		}
+		if d.Path != "" {
+			return fmt.Errorf("proto dependency %s: path is only supported for git repositories", d.Module)
+		}
+	}
+	return nil
+}
+
+// Name returns the name that identifies the dependency source.
+func (d Dependency) Name() string {
+	if d.Module != "" {
+		return d.Module
+	}
+	if d.Path != "" {
+		return fmt.Sprintf("%s//%s", d.Git, d.Path)
+	}
+	return d.Git
+}
+
+type resolveOptions struct {
+	update     bool
+	bufAddress func(remote string) string
+}
+
+// Option configures dependency resolution.
+type Option func(*resolveOptions)
+
+// Update ignores the versions pinned in the lock file and resolves the dependency
+// revisions again. The lock file is updated with the new versions.
+func Update() Option {
+	return func(o *resolveOptions) {
+		o.update = true
+	}
+}
+
+// BufAddress overwrites the function that returns the API address of a Buf registry remote.
+func BufAddress(address func(remote string) string) Option {
+	return func(o *resolveOptions) {
+		o.bufAddress = address
+	}
+}
+
+// Resolve fetches the dependencies into cacheDir when they're not already there and
+// returns the proto include paths of them in the same order as the dependencies.
+// Versions are pinned in the lock file at lockPath which is created or updated when
+// the dependencies change, or removed when there are no dependencies anymore.
+func Resolve(ctx context.Context, cacheDir, lockPath string, deps []Dependency, options ...Option) (includePaths []string, err error) {
+	if len(deps) == 0 {
+		if err := os.Remove(lockPath); err != nil && !os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	o := resolveOptions{
+		bufAddress: defaultBufAddress,
+	}
+	for _, apply := range options {
+		apply(&o)
+	}
+
+	lock, err := LoadLock(lockPath)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &resolver{
+		o:        o,
+		cacheDir: cacheDir,
+		lock:     lock,
+		buf:      newBufRegistry(o.bufAddress),
+		git:      newGitFetcher(),
+	}
+	defer r.git.cleanup()
+
+	for _, dep := range deps {
+		if err := dep.Validate(); err != nil {
+			return nil, err
+		}
+		if err := r.resolve(ctx, dep, dep.Revision, false); err != nil {
+			return nil, errors.Wrapf(err, "proto dependency %s", dep.Name())
+		}
+	}
+
+	newLock := Lock{
+		Version:      LockVersion,
+		Dependencies: r.resolved,
+	}
+
+	if !newLock.Equal(lock) {
+		if err := newLock.Save(lockPath); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, d := range r.resolved {
+		includePaths = append(includePaths, r.cachePath(d))
+	}
+
+	return includePaths, nil
+}
+
+type resolver struct {
+	o        resolveOptions
+	cacheDir string
+	lock     Lock
+	buf      bufRegistry
+	git      *gitFetcher
+	resolved []LockedDependency
+}
+
+// resolve pins and fetches the dependency. transitive dependencies of Buf modules
+// are resolved as well, they're always pinned to a commit by the parent module.
+func (r *resolver) resolve(ctx context.Context, dep Dependency, revision string, isTransitive bool) error {
+	for _, d := range r.resolved {
+		if d.Name() == dep.Name() {
+			return nil
+		}
+	}
+
+	locked, ok := r.lock.find(dep, revision)
+	if !ok || (r.o.update && !isTransitive) {
+		// transitive dependencies are already pinned to a commit.
+		commit := revision
+		if !isTransitive {
+			var err error
+			if commit, err = r.resolveCommit(ctx, dep, revision); err != nil {
+				return err
+			}
+		}
+
+		locked = LockedDependency{
+			Module:   dep.Module,
+			Git:      dep.Git,
+			Path:     dep.Path,
+			Revision: revision,
+			Commit:   commit,
+		}
+	}
+
+	path := r.cachePath(locked)
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if err := r.fetch(ctx, locked, path); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+
+	digest, err := Digest(path)
+	if err != nil {
+		return err
+	}
+
+	if locked.Digest != "" && locked.Digest != digest {
+		return errors.Wrapf(ErrDigestMismatch, "%s at %s", locked.Name(), path)
+	}
+
+	locked.Digest = digest
+	r.resolved = append(r.resolved, locked)
+
+	if locked.Module == "" {
+		return nil
+	}
+
+	deps, err := readBufDependencies(path)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range deps {
+		if err := r.resolve(ctx, Dependency{Module: d.Module}, d.Commit, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *resolver) resolveCommit(ctx context.Context, dep Dependency, revision string) (string, error) {
+	if dep.Module != "" {
+		return r.buf.resolveCommit(ctx, dep.Module, revision)
+	}
+	return r.git.resolveCommit(ctx, dep.Git, revision)
+}
+
+// fetch downloads the dependency into a temporary dir that is moved under path when
+// all files are fetched, so interrupted downloads never leave partial content in the cache.
+func (r *resolver) fetch(ctx context.Context, d LockedDependency, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.MkdirTemp(filepath.Dir(path), ".fetch")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if d.Module != "" {
+		err = r.buf.download(ctx, d.Module, d.Commit, tmp)
+	} else {
+		err = r.git.fetch(ctx, d.Git, d.Commit, d.Path, tmp)
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(tmp, path)
+}
+
+// cachePath returns the path of a dependency inside the cache.
+func (r *resolver) cachePath(d LockedDependency) string {
+	if d.Module != "" {
+		return filepath.Join(r.cacheDir, "buf", d.Module, d.Commit)
+	}
+
+	// git URLs might contain chars that are not valid in paths.
+	source := checksum.Strings(d.Git, d.Path)[:16]
+	return filepath.Join(r.cacheDir, "git", source, d.Commit)
+}
+
+// Digest computes a digest of the proto files inside the dir.
+// It only depends on the relative paths and the content of the files.
+func Digest(dir string) (string, error) {
+	var paths []string
+
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() && filepath.Ext(path) == protoFileExt {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sort.Strings(paths)
+
+	h := sha256.New()
+
+	for _, path := range paths {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return "", err
+		}
+
+		fmt.Fprintf(h, "%s\n", filepath.ToSlash(rel))
+
+		f, err := os.Open(path)
+		if err != nil {
+			return "", err
+		}
+		_, err = io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf("sha256:%x", h.Sum(nil)), nil
+}
+
+// writeProtoFile writes a proto file content under dir while making sure that the
+// relative path doesn't point outside of the dir.
+func writeProtoFile(dir, path string, content []byte) error {
+	path = filepath.Join(dir, filepath.FromSlash(path))
+	if !strings.HasPrefix(path, filepath.Clean(dir)+string(filepath.Separator)) {
+		return fmt.Errorf("invalid proto file path: %s", path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0o644)
+}
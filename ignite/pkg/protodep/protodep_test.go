@@ -0,0 +1,263 @@
+package protodep
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveGit(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		repoPath = t.TempDir()
+		cacheDir = t.TempDir()
+		lockPath = filepath.Join(t.TempDir(), LockFileName)
+	)
+
+	commit := commitFiles(t, repoPath, map[string]string{
+		"proto/foo/v1/foo.proto": `syntax = "proto3";`,
+		"proto/foo/v1/README.md": "foo",
+		"other/bar.proto":        `syntax = "proto3";`,
+	})
+
+	deps := []Dependency{{Git: repoPath, Path: "proto"}}
+
+	paths, err := Resolve(ctx, cacheDir, lockPath, deps)
+	require.NoError(t, err)
+	require.Len(t, paths, 1)
+	require.FileExists(t, filepath.Join(paths[0], "foo/v1/foo.proto"))
+	require.NoFileExists(t, filepath.Join(paths[0], "foo/v1/README.md"))
+	require.NoFileExists(t, filepath.Join(paths[0], "other/bar.proto"))
+
+	lock, err := LoadLock(lockPath)
+	require.NoError(t, err)
+	require.Equal(t, LockVersion, lock.Version)
+	require.Len(t, lock.Dependencies, 1)
+	require.Equal(t, commit, lock.Dependencies[0].Commit)
+	require.Equal(t, repoPath, lock.Dependencies[0].Git)
+	require.Equal(t, "proto", lock.Dependencies[0].Path)
+
+	digest, err := Digest(paths[0])
+	require.NoError(t, err)
+	require.Equal(t, digest, lock.Dependencies[0].Digest)
+
+	// new commits are ignored until the dependencies are updated.
+	newCommit := commitFiles(t, repoPath, map[string]string{
+		"proto/foo/v1/bar.proto": `syntax = "proto3";`,
+	})
+
+	pinnedPaths, err := Resolve(ctx, cacheDir, lockPath, deps)
+	require.NoError(t, err)
+	require.Equal(t, paths, pinnedPaths)
+
+	updatedPaths, err := Resolve(ctx, cacheDir, lockPath, deps, Update())
+	require.NoError(t, err)
+	require.NotEqual(t, paths, updatedPaths)
+	require.FileExists(t, filepath.Join(updatedPaths[0], "foo/v1/bar.proto"))
+
+	lock, err = LoadLock(lockPath)
+	require.NoError(t, err)
+	require.Equal(t, newCommit, lock.Dependencies[0].Commit)
+
+	// modified cache content doesn't match with the lock file anymore.
+	err = os.WriteFile(filepath.Join(updatedPaths[0], "foo/v1/bar.proto"), []byte("modified"), 0o644)
+	require.NoError(t, err)
+
+	_, err = Resolve(ctx, cacheDir, lockPath, deps)
+	require.ErrorIs(t, err, ErrDigestMismatch)
+}
+
+func TestResolveNoDependencies(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		repoPath = t.TempDir()
+		cacheDir = t.TempDir()
+		lockPath = filepath.Join(t.TempDir(), LockFileName)
+	)
+
+	commitFiles(t, repoPath, map[string]string{
+		"proto/foo/v1/foo.proto": `syntax = "proto3";`,
+	})
+
+	_, err := Resolve(ctx, cacheDir, lockPath, []Dependency{{Git: repoPath, Path: "proto"}})
+	require.NoError(t, err)
+	require.FileExists(t, lockPath)
+
+	// the lock file is removed with the last dependency.
+	paths, err := Resolve(ctx, cacheDir, lockPath, nil)
+	require.NoError(t, err)
+	require.Empty(t, paths)
+	require.NoFileExists(t, lockPath)
+
+	_, err = Resolve(ctx, cacheDir, lockPath, nil)
+	require.NoError(t, err)
+}
+
+func TestResolveBuf(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		cacheDir = t.TempDir()
+		lockPath = filepath.Join(t.TempDir(), LockFileName)
+		requests []string
+	)
+
+	modules := map[string]bufModule{
+		"cosmos/cosmos-sdk@c1": {
+			Dependencies: []bufModulePin{{Remote: "buf.build", Owner: "cosmos", Repository: "gogo-proto", Commit: "g1"}},
+		},
+		"cosmos/gogo-proto@g1": {},
+	}
+	addFile := func(key, path, content string) {
+		m := modules[key]
+		m.Files = append(m.Files, struct {
+			Path    string `json:"path"`
+			Content []byte `json:"content"`
+		}{path, []byte(content)})
+		modules[key] = m
+	}
+	addFile("cosmos/cosmos-sdk@c1", "cosmos/bank/v1beta1/bank.proto", `syntax = "proto3";`)
+	addFile("cosmos/cosmos-sdk@c1", "buf.yaml", "version: v1")
+	addFile("cosmos/gogo-proto@g1", "gogoproto/gogo.proto", `syntax = "proto2";`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+
+		switch r.URL.Path {
+		case "/" + bufResolvePinsMethod:
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"modulePins": []bufModulePin{{Remote: "buf.build", Owner: "cosmos", Repository: "cosmos-sdk", Commit: "c1"}},
+			})
+		case "/" + bufDownloadMethod:
+			var req struct {
+				Owner      string `json:"owner"`
+				Repository string `json:"repository"`
+				Reference  string `json:"reference"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"module": modules[req.Owner+"/"+req.Repository+"@"+req.Reference],
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	var (
+		deps    = []Dependency{{Module: "buf.build/cosmos/cosmos-sdk", Revision: "v0.46.0"}}
+		address = BufAddress(func(remote string) string {
+			require.Equal(t, "buf.build", remote)
+			return server.URL
+		})
+	)
+
+	paths, err := Resolve(ctx, cacheDir, lockPath, deps, address)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(cacheDir, "buf/buf.build/cosmos/cosmos-sdk/c1"),
+		filepath.Join(cacheDir, "buf/buf.build/cosmos/gogo-proto/g1"),
+	}, paths)
+	require.FileExists(t, filepath.Join(paths[0], "cosmos/bank/v1beta1/bank.proto"))
+	require.NoFileExists(t, filepath.Join(paths[0], "buf.yaml"))
+	require.FileExists(t, filepath.Join(paths[1], "gogoproto/gogo.proto"))
+
+	lock, err := LoadLock(lockPath)
+	require.NoError(t, err)
+	require.Len(t, lock.Dependencies, 2)
+	require.Equal(t, "v0.46.0", lock.Dependencies[0].Revision)
+	require.Equal(t, "c1", lock.Dependencies[0].Commit)
+	require.Equal(t, "buf.build/cosmos/gogo-proto", lock.Dependencies[1].Module)
+	require.Equal(t, "g1", lock.Dependencies[1].Commit)
+
+	// the registry is not called again when dependencies are locked and cached.
+	requests = nil
+	_, err = Resolve(ctx, cacheDir, lockPath, deps, address)
+	require.NoError(t, err)
+	require.Empty(t, requests)
+}
+
+func TestDependencyValidate(t *testing.T) {
+	cases := []struct {
+		name string
+		dep  Dependency
+		err  string
+	}{
+		{
+			name: "buf module",
+			dep:  Dependency{Module: "buf.build/cosmos/cosmos-sdk"},
+		},
+		{
+			name: "git repository",
+			dep:  Dependency{Git: "https://github.com/cosmos/cosmos-sdk", Path: "proto"},
+		},
+		{
+			name: "no source",
+			dep:  Dependency{Revision: "main"},
+			err:  "must have a module or a git repository",
+		},
+		{
+			name: "both sources",
+			dep:  Dependency{Module: "buf.build/cosmos/cosmos-sdk", Git: "https://github.com/cosmos/cosmos-sdk"},
+			err:  "cannot have both",
+		},
+		{
+			name: "invalid module",
+			dep:  Dependency{Module: "cosmos/cosmos-sdk"},
+			err:  "expected format is remote/owner/repository",
+		},
+		{
+			name: "module with path",
+			dep:  Dependency{Module: "buf.build/cosmos/cosmos-sdk", Path: "proto"},
+			err:  "path is only supported for git repositories",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.dep.Validate()
+			if tt.err != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// commitFiles writes files into the git repository at path and commits them.
+func commitFiles(t *testing.T, path string, files map[string]string) string {
+	repo, err := git.PlainOpen(path)
+	if err == git.ErrRepositoryNotExists {
+		repo, err = git.PlainInit(path, false)
+	}
+	require.NoError(t, err)
+
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+
+	for name, content := range files {
+		p := filepath.Join(path, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(p), 0o755))
+		require.NoError(t, os.WriteFile(p, []byte(content), 0o644))
+		_, err := wt.Add(strings.TrimPrefix(name, "/"))
+		require.NoError(t, err)
+	}
+
+	hash, err := wt.Commit("update", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@ignite.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+
+	return hash.String()
+}
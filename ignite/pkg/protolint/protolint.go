@@ -0,0 +1,342 @@
+// Package protolint provides lint rules to check if proto files of Cosmos SDK apps
+// follow the module conventions used across the SDK.
+package protolint
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ignite/cli/ignite/pkg/protoanalysis"
+)
+
+const (
+	// RuleMsgResponse checks that each Msg RPC uses MsgX request and MsgXResponse response types.
+	RuleMsgResponse = "msg-response"
+
+	// RuleMsgSigner checks that each Msg request type has a signer field.
+	RuleMsgSigner = "msg-signer"
+
+	// RuleGoPackage checks that the go_package option is defined and points inside the app's Go module.
+	RuleGoPackage = "go-package"
+
+	// RuleQueryNaming checks that each Query RPC uses QueryXRequest and QueryXResponse types.
+	RuleQueryNaming = "query-naming"
+
+	// RuleQueryHTTP checks that each Query RPC has a google.api.http option.
+	RuleQueryHTTP = "query-http"
+
+	// RuleQueryPagination checks that list queries have pagination fields.
+	RuleQueryPagination = "query-pagination"
+)
+
+const (
+	serviceMsg   = "Msg"
+	serviceQuery = "Query"
+
+	typePageRequest  = "cosmos.base.query.v1beta1.PageRequest"
+	typePageResponse = "cosmos.base.query.v1beta1.PageResponse"
+)
+
+// Rules is the list of all available lint rules.
+var Rules = []string{
+	RuleMsgResponse,
+	RuleMsgSigner,
+	RuleGoPackage,
+	RuleQueryNaming,
+	RuleQueryHTTP,
+	RuleQueryPagination,
+}
+
+// DefaultSignerFields is a list of field names that are recognized as Msg signers.
+var DefaultSignerFields = []string{
+	"creator",
+	"signer",
+	"sender",
+	"authority",
+	"admin",
+	"owner",
+	"from_address",
+	"delegator_address",
+	"validator_address",
+	"granter",
+	"proposer",
+	"depositor",
+	"voter",
+}
+
+// scalarTypes is a list of proto scalar value types.
+var scalarTypes = []string{
+	"double", "float", "int32", "int64", "uint32", "uint64", "sint32", "sint64",
+	"fixed32", "fixed64", "sfixed32", "sfixed64", "bool", "string", "bytes",
+}
+
+// Issue is a lint rule violation found in a proto file.
+type Issue struct {
+	// Rule is the name of the violated rule.
+	Rule string `json:"rule"`
+
+	// Path of the proto file where the issue is found.
+	Path string `json:"path"`
+
+	// Element is the name of the proto element that has the issue.
+	// e.g. the RPC func or the proto package name.
+	Element string `json:"element"`
+
+	// Message describes the issue.
+	Message string `json:"message"`
+}
+
+// String returns a human readable representation of the issue.
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s (%s)", i.Path, i.Element, i.Message, i.Rule)
+}
+
+type lintOptions struct {
+	excludedRules []string
+	signerFields  []string
+}
+
+// Option configures the linter.
+type Option func(*lintOptions)
+
+// ExcludeRules disables the given rules.
+func ExcludeRules(rules ...string) Option {
+	return func(o *lintOptions) {
+		o.excludedRules = append(o.excludedRules, rules...)
+	}
+}
+
+// SignerFields adds field names that are recognized as Msg signers
+// in addition to the default ones.
+func SignerFields(names ...string) Option {
+	return func(o *lintOptions) {
+		o.signerFields = append(o.signerFields, names...)
+	}
+}
+
+type linter struct {
+	o            lintOptions
+	goModulePath string
+	issues       []Issue
+}
+
+// Lint checks the proto packages found under protoPath against Cosmos SDK module conventions.
+// goModulePath is the Go module path of the app, it is used to verify go_package options.
+func Lint(ctx context.Context, protoPath, goModulePath string, options ...Option) ([]Issue, error) {
+	l := &linter{
+		o: lintOptions{
+			signerFields: append([]string{}, DefaultSignerFields...),
+		},
+		goModulePath: goModulePath,
+	}
+
+	for _, apply := range options {
+		apply(&l.o)
+	}
+
+	pkgs, err := protoanalysis.Parse(ctx, nil, protoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pkg := range pkgs {
+		l.lintPackage(pkg)
+	}
+
+	return l.issues, nil
+}
+
+func (l *linter) report(rule, path, element, format string, a ...interface{}) {
+	for _, r := range l.o.excludedRules {
+		if r == rule {
+			return
+		}
+	}
+
+	l.issues = append(l.issues, Issue{
+		Rule:    rule,
+		Path:    path,
+		Element: element,
+		Message: fmt.Sprintf(format, a...),
+	})
+}
+
+func (l *linter) lintPackage(pkg protoanalysis.Package) {
+	l.lintGoPackage(pkg)
+
+	for _, s := range pkg.Services {
+		switch s.Name {
+		case serviceMsg:
+			for _, rpc := range s.RPCFuncs {
+				l.lintMsg(pkg, rpc)
+			}
+		case serviceQuery:
+			for _, rpc := range s.RPCFuncs {
+				l.lintQuery(pkg, rpc)
+			}
+		}
+	}
+}
+
+func (l *linter) lintGoPackage(pkg protoanalysis.Package) {
+	if len(pkg.Files) == 0 {
+		return
+	}
+
+	path := pkg.Files[0].Path
+
+	if pkg.GoImportName == "" {
+		l.report(RuleGoPackage, path, pkg.Name, "go_package option is not defined")
+		return
+	}
+
+	importPath := pkg.GoImportPath()
+	if importPath != l.goModulePath && !strings.HasPrefix(importPath, l.goModulePath+"/") {
+		l.report(
+			RuleGoPackage,
+			path,
+			pkg.Name,
+			"go_package %q is not inside the app's Go module %q",
+			importPath,
+			l.goModulePath,
+		)
+	}
+}
+
+func (l *linter) lintMsg(pkg protoanalysis.Package, rpc protoanalysis.RPCFunc) {
+	var (
+		element      = fmt.Sprintf("%s.%s", serviceMsg, rpc.Name)
+		requestType  = typeName(rpc.RequestType)
+		returnsType  = typeName(rpc.ReturnsType)
+		wantRequest  = serviceMsg + rpc.Name
+		wantResponse = wantRequest + "Response"
+	)
+
+	request, err := pkg.MessageByName(requestType)
+	if err != nil {
+		return
+	}
+
+	if requestType != wantRequest {
+		l.report(RuleMsgResponse, request.Path, element, "request type should be named %s instead of %s", wantRequest, requestType)
+	}
+
+	if returnsType != wantResponse {
+		l.report(RuleMsgResponse, request.Path, element, "response type should be named %s instead of %s", wantResponse, returnsType)
+	} else if _, err := pkg.MessageByName(returnsType); err != nil {
+		l.report(RuleMsgResponse, request.Path, element, "response type %s is not defined in package %s", returnsType, pkg.Name)
+	}
+
+	if !l.hasSignerField(request) {
+		l.report(
+			RuleMsgSigner,
+			request.Path,
+			element,
+			"%s has no signer field, expected one of: %s",
+			requestType,
+			strings.Join(l.o.signerFields, ", "),
+		)
+	}
+}
+
+func (l *linter) lintQuery(pkg protoanalysis.Package, rpc protoanalysis.RPCFunc) {
+	var (
+		element     = fmt.Sprintf("%s.%s", serviceQuery, rpc.Name)
+		requestType = typeName(rpc.RequestType)
+		returnsType = typeName(rpc.ReturnsType)
+	)
+
+	request, err := pkg.MessageByName(requestType)
+	if err != nil {
+		return
+	}
+
+	// request and response types share the same stem, e.g. QueryGetPostRequest and
+	// QueryGetPostResponse, the stem itself is not required to match with the RPC name.
+	stem := strings.TrimSuffix(strings.TrimPrefix(requestType, serviceQuery), "Request")
+	if requestType != fmt.Sprintf("%s%sRequest", serviceQuery, stem) {
+		l.report(
+			RuleQueryNaming,
+			request.Path,
+			element,
+			"request type %s should be named with the %s prefix and the Request suffix, e.g. %s%sRequest",
+			requestType,
+			serviceQuery,
+			serviceQuery,
+			rpc.Name,
+		)
+	} else if wantResponse := fmt.Sprintf("%s%sResponse", serviceQuery, stem); returnsType != wantResponse {
+		l.report(RuleQueryNaming, request.Path, element, "response type should be named %s instead of %s", wantResponse, returnsType)
+	}
+
+	if len(rpc.HTTPRules) == 0 {
+		l.report(RuleQueryHTTP, request.Path, element, "google.api.http option is missing")
+	}
+
+	// only responses defined in the same package can be checked for pagination.
+	response, err := pkg.MessageByName(returnsType)
+	if err != nil || !isList(response) {
+		return
+	}
+
+	if !hasFieldOfType(request, typePageRequest) {
+		l.report(RuleQueryPagination, request.Path, element, "list query request %s has no %s field", requestType, typePageRequest)
+	}
+
+	if !hasFieldOfType(response, typePageResponse) {
+		l.report(RuleQueryPagination, response.Path, element, "list query response %s has no %s field", returnsType, typePageResponse)
+	}
+}
+
+func (l *linter) hasSignerField(m protoanalysis.Message) bool {
+	for _, f := range m.Fields {
+		if f.IsRepeated || f.Type != "string" {
+			continue
+		}
+
+		for _, name := range l.o.signerFields {
+			if f.Name == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isList checks if the message contains a list of other proto messages.
+func isList(m protoanalysis.Message) bool {
+	for _, f := range m.Fields {
+		if f.IsRepeated && !isScalar(f.Type) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasFieldOfType(m protoanalysis.Message, fullType string) bool {
+	for _, f := range m.Fields {
+		if strings.TrimPrefix(f.Type, ".") == fullType {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isScalar(t string) bool {
+	for _, s := range scalarTypes {
+		if t == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+// typeName returns the name of a proto type without its package.
+func typeName(t string) string {
+	return t[strings.LastIndex(t, ".")+1:]
+}
@@ -0,0 +1,88 @@
+package protolint
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint(t *testing.T) {
+	issues, err := Lint(context.Background(), "testdata", "github.com/username/mars")
+	require.NoError(t, err)
+
+	var (
+		txPath      = "testdata/mars/tx.proto"
+		queryPath   = "testdata/mars/query.proto"
+		genesisPath = "testdata/venus/genesis.proto"
+	)
+
+	require.ElementsMatch(t, []Issue{
+		{
+			Rule:    RuleMsgResponse,
+			Path:    txPath,
+			Element: "Msg.DeletePost",
+			Message: "request type should be named MsgDeletePost instead of MsgRemovePost",
+		},
+		{
+			Rule:    RuleMsgResponse,
+			Path:    txPath,
+			Element: "Msg.DeletePost",
+			Message: "response type MsgDeletePostResponse is not defined in package username.mars.mars",
+		},
+		{
+			Rule:    RuleMsgSigner,
+			Path:    txPath,
+			Element: "Msg.DeletePost",
+			Message: "MsgRemovePost has no signer field, expected one of: creator, signer, sender, authority, admin, owner, from_address, delegator_address, validator_address, granter, proposer, depositor, voter",
+		},
+		{
+			Rule:    RuleQueryNaming,
+			Path:    queryPath,
+			Element: "Query.Comments",
+			Message: "response type should be named QueryCommentsResponse instead of CommentsResponse",
+		},
+		{
+			Rule:    RuleQueryNaming,
+			Path:    queryPath,
+			Element: "Query.Likes",
+			Message: "request type LikesRequest should be named with the Query prefix and the Request suffix, e.g. QueryLikesRequest",
+		},
+		{
+			Rule:    RuleQueryPagination,
+			Path:    queryPath,
+			Element: "Query.PostsByTitle",
+			Message: "list query request QueryPostsByTitleRequest has no cosmos.base.query.v1beta1.PageRequest field",
+		},
+		{
+			Rule:    RuleQueryPagination,
+			Path:    queryPath,
+			Element: "Query.PostsByTitle",
+			Message: "list query response QueryPostsByTitleResponse has no cosmos.base.query.v1beta1.PageResponse field",
+		},
+		{
+			Rule:    RuleQueryHTTP,
+			Path:    queryPath,
+			Element: "Query.Comments",
+			Message: "google.api.http option is missing",
+		},
+		{
+			Rule:    RuleGoPackage,
+			Path:    genesisPath,
+			Element: "username.venus.venus",
+			Message: `go_package "github.com/username/venus/x/venus/types" is not inside the app's Go module "github.com/username/mars"`,
+		},
+	}, issues)
+}
+
+func TestLintOptions(t *testing.T) {
+	issues, err := Lint(
+		context.Background(),
+		"testdata/mars",
+		"github.com/username/mars",
+		ExcludeRules(RuleQueryNaming, RuleQueryPagination, RuleQueryHTTP, RuleMsgResponse),
+		SignerFields("remover"),
+	)
+	require.NoError(t, err)
+	require.Empty(t, issues)
+}
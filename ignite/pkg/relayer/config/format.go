@@ -0,0 +1,119 @@
+package relayerconf
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+
+	"github.com/ignite/cli/ignite/pkg/xurl"
+)
+
+// Format is the format of the config file of a relayer.
+type Format string
+
+const (
+	// FormatHermes is the TOML config of the Hermes relayer.
+	FormatHermes Format = "hermes"
+
+	// FormatRly is the YAML config of the Go relayer (rly) v2.
+	FormatRly Format = "rly"
+)
+
+const (
+	// defaultPortID is the port of the paths imported from configs that don't store the ports.
+	defaultPortID = "transfer"
+
+	// defaultGRPCPort is the port of the gRPC server of the chains, the configs of the other
+	// relayers require a gRPC address that isn't part of the Ignite config.
+	defaultGRPCPort = "9090"
+)
+
+// Formats are the supported formats to export and import the config.
+var Formats = []Format{FormatHermes, FormatRly}
+
+// Export writes c to w in the config format of another relayer.
+func Export(w io.Writer, c Config, format Format) error {
+	switch format {
+	case FormatHermes:
+		return exportHermes(w, c)
+	case FormatRly:
+		return exportRly(w, c)
+	default:
+		return fmt.Errorf("unknown relayer config format %q", format)
+	}
+}
+
+// Import reads a config in the format of another relayer from r.
+func Import(r io.Reader, format Format) (Config, error) {
+	switch format {
+	case FormatHermes:
+		return importHermes(r)
+	case FormatRly:
+		return importRly(r)
+	default:
+		return Config{}, fmt.Errorf("unknown relayer config format %q", format)
+	}
+}
+
+// Merge adds the chains and paths of other to c, the ones with the same ids are replaced.
+func (c *Config) Merge(other Config) {
+	for _, chain := range other.Chains {
+		if i := c.chainIndex(chain.ID); i != -1 {
+			c.Chains[i] = chain
+			continue
+		}
+		c.Chains = append(c.Chains, chain)
+	}
+
+	for _, path := range other.Paths {
+		if i := c.pathIndex(path.ID); i != -1 {
+			c.Paths[i] = path
+			continue
+		}
+		c.Paths = append(c.Paths, path)
+	}
+}
+
+func (c Config) chainIndex(id string) int {
+	for i, chain := range c.Chains {
+		if chain.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (c Config) pathIndex(id string) int {
+	for i, path := range c.Paths {
+		if path.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// grpcAddress returns the gRPC address of the chain served by the node at rpcAddress, assuming the
+// gRPC server listens on the default port of the same host.
+func grpcAddress(rpcAddress string) (string, error) {
+	u, err := url.Parse(xurl.HTTPEnsurePort(rpcAddress))
+	if err != nil {
+		return "", err
+	}
+	return "http://" + net.JoinHostPort(u.Hostname(), defaultGRPCPort), nil
+}
+
+// websocketAddress returns the websocket address of the node at rpcAddress.
+func websocketAddress(rpcAddress string) (string, error) {
+	u, err := url.Parse(xurl.HTTPEnsurePort(rpcAddress))
+	if err != nil {
+		return "", err
+	}
+
+	scheme := "ws"
+	if u.Scheme == "https" {
+		scheme = "wss"
+	}
+
+	return fmt.Sprintf("%s://%s/websocket", scheme, u.Host), nil
+}
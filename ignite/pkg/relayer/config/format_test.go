@@ -0,0 +1,100 @@
+package relayerconf
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{
+	Chains: []Chain{
+		{
+			ID:            "mars",
+			Account:       "default",
+			AddressPrefix: "cosmos",
+			RPCAddress:    "http://localhost:26657",
+			GasPrice:      "0.00025stake",
+			GasLimit:      300000,
+			ClientID:      "07-tendermint-0",
+		},
+		{
+			ID:            "venus",
+			Account:       "default",
+			AddressPrefix: "venus",
+			RPCAddress:    "https://rpc.venus.com",
+			GasPrice:      "0.025uvenus",
+			ClientID:      "07-tendermint-3",
+		},
+	},
+	Paths: []Path{
+		{
+			ID: "mars-venus",
+			Src: PathEnd{
+				ChainID:      "mars",
+				ConnectionID: "connection-0",
+				ChannelID:    "channel-0",
+				PortID:       "transfer",
+			},
+			Dst: PathEnd{
+				ChainID:      "venus",
+				ConnectionID: "connection-2",
+				ChannelID:    "channel-5",
+				PortID:       "transfer",
+			},
+		},
+	},
+}
+
+func TestExportImportRly(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Export(&buf, testConfig, FormatRly))
+	require.Contains(t, buf.String(), "client-id: 07-tendermint-3")
+
+	c, err := Import(&buf, FormatRly)
+	require.NoError(t, err)
+	require.Equal(t, testConfig.Chains, c.Chains)
+
+	// the counterparty channel is not part of the config of the Go relayer.
+	expected := testConfig.Paths[0]
+	expected.Dst.ChannelID = ""
+	require.Equal(t, []Path{expected}, c.Paths)
+}
+
+func TestExportImportHermes(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Export(&buf, testConfig, FormatHermes))
+
+	out := buf.String()
+	require.Contains(t, out, `grpc_addr = "http://localhost:9090"`)
+	require.Contains(t, out, `websocket_addr = "wss://rpc.venus.com:443/websocket"`)
+	require.Contains(t, out, `"channel-5"`)
+
+	c, err := Import(&buf, FormatHermes)
+	require.NoError(t, err)
+	require.Empty(t, c.Paths)
+
+	// the clients are not part of the config of Hermes.
+	expected := make([]Chain, len(testConfig.Chains))
+	copy(expected, testConfig.Chains)
+	for i := range expected {
+		expected[i].ClientID = ""
+	}
+	require.Equal(t, expected, c.Chains)
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	require.Error(t, Export(&bytes.Buffer{}, testConfig, Format("unknown")))
+}
+
+func TestMerge(t *testing.T) {
+	c := Config{
+		Chains: []Chain{{ID: "mars", Account: "alice"}, {ID: "earth"}},
+		Paths:  []Path{{ID: "mars-earth"}},
+	}
+
+	c.Merge(testConfig)
+
+	require.Equal(t, []Chain{testConfig.Chains[0], {ID: "earth"}, testConfig.Chains[1]}, c.Chains)
+	require.Equal(t, []Path{{ID: "mars-earth"}, testConfig.Paths[0]}, c.Paths)
+}
@@ -0,0 +1,141 @@
+package relayerconf
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/pelletier/go-toml"
+)
+
+// hermesConfig is the subset of the config of Hermes built from the Ignite config.
+// Hermes discovers the clients and connections of the channels it relays on its own, so the
+// paths are stored as the allowed channels of the chains.
+type hermesConfig struct {
+	Global hermesGlobal  `toml:"global"`
+	Mode   hermesMode    `toml:"mode"`
+	Chains []hermesChain `toml:"chains"`
+}
+
+type hermesGlobal struct {
+	LogLevel string `toml:"log_level"`
+}
+
+type hermesMode struct {
+	Clients     hermesModeEntry `toml:"clients"`
+	Connections hermesModeEntry `toml:"connections"`
+	Channels    hermesModeEntry `toml:"channels"`
+	Packets     hermesModeEntry `toml:"packets"`
+}
+
+type hermesModeEntry struct {
+	Enabled      bool `toml:"enabled"`
+	Refresh      bool `toml:"refresh,omitempty"`
+	ClearOnStart bool `toml:"clear_on_start,omitempty"`
+}
+
+type hermesChain struct {
+	ID            string             `toml:"id"`
+	RPCAddr       string             `toml:"rpc_addr"`
+	GRPCAddr      string             `toml:"grpc_addr"`
+	WebsocketAddr string             `toml:"websocket_addr"`
+	AccountPrefix string             `toml:"account_prefix"`
+	KeyName       string             `toml:"key_name"`
+	StorePrefix   string             `toml:"store_prefix"`
+	MaxGas        int64              `toml:"max_gas,omitempty"`
+	GasPrice      hermesGasPrice     `toml:"gas_price"`
+	PacketFilter  hermesPacketFilter `toml:"packet_filter"`
+}
+
+type hermesGasPrice struct {
+	Price float64 `toml:"price"`
+	Denom string  `toml:"denom"`
+}
+
+type hermesPacketFilter struct {
+	Policy string     `toml:"policy"`
+	List   [][]string `toml:"list"`
+}
+
+func exportHermes(w io.Writer, c Config) error {
+	conf := hermesConfig{
+		Global: hermesGlobal{LogLevel: "info"},
+		Mode: hermesMode{
+			Clients:     hermesModeEntry{Enabled: true, Refresh: true},
+			Connections: hermesModeEntry{Enabled: false},
+			Channels:    hermesModeEntry{Enabled: false},
+			Packets:     hermesModeEntry{Enabled: true, ClearOnStart: true},
+		},
+	}
+
+	for _, chain := range c.Chains {
+		hc := hermesChain{
+			ID:            chain.ID,
+			RPCAddr:       chain.RPCAddress,
+			AccountPrefix: chain.AddressPrefix,
+			KeyName:       chain.Account,
+			StorePrefix:   "ibc",
+			MaxGas:        chain.GasLimit,
+			PacketFilter:  hermesPacketFilter{Policy: "allow", List: [][]string{}},
+		}
+
+		var err error
+		if hc.GRPCAddr, err = grpcAddress(chain.RPCAddress); err != nil {
+			return err
+		}
+		if hc.WebsocketAddr, err = websocketAddress(chain.RPCAddress); err != nil {
+			return err
+		}
+
+		if chain.GasPrice != "" {
+			gasPrice, err := sdk.ParseDecCoin(chain.GasPrice)
+			if err != nil {
+				return fmt.Errorf("invalid gas price of chain %s: %w", chain.ID, err)
+			}
+			if hc.GasPrice.Price, err = strconv.ParseFloat(gasPrice.Amount.String(), 64); err != nil {
+				return err
+			}
+			hc.GasPrice.Denom = gasPrice.Denom
+		}
+
+		for _, path := range c.Paths {
+			for _, end := range []PathEnd{path.Src, path.Dst} {
+				if end.ChainID == chain.ID && end.ChannelID != "" {
+					hc.PacketFilter.List = append(hc.PacketFilter.List, []string{end.PortID, end.ChannelID})
+				}
+			}
+		}
+
+		conf.Chains = append(conf.Chains, hc)
+	}
+
+	return toml.NewEncoder(w).Encode(conf)
+}
+
+// importHermes imports the chains of a Hermes config, Hermes doesn't store the paths in its config.
+func importHermes(r io.Reader) (Config, error) {
+	var conf hermesConfig
+	if err := toml.NewDecoder(r).Decode(&conf); err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+	for _, hc := range conf.Chains {
+		chain := Chain{
+			ID:            hc.ID,
+			Account:       hc.KeyName,
+			AddressPrefix: hc.AccountPrefix,
+			RPCAddress:    hc.RPCAddr,
+			GasLimit:      hc.MaxGas,
+		}
+
+		if hc.GasPrice.Denom != "" {
+			chain.GasPrice = strconv.FormatFloat(hc.GasPrice.Price, 'f', -1, 64) + hc.GasPrice.Denom
+		}
+
+		c.Chains = append(c.Chains, chain)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,200 @@
+package relayerconf
+
+import (
+	"io"
+	"sort"
+
+	"github.com/goccy/go-yaml"
+)
+
+const (
+	rlyChainType        = "cosmos"
+	rlyKeyringBackend   = "test"
+	rlyGasAdjustment    = 1.5
+	rlyChannelAllowlist = "allowlist"
+)
+
+// rlyConfig is the subset of the config of the Go relayer v2 built from the Ignite config.
+// The Go relayer doesn't store the ports of the paths, their channels are stored as the channel
+// filters of the paths.
+type rlyConfig struct {
+	Global rlyGlobal           `yaml:"global"`
+	Chains map[string]rlyChain `yaml:"chains"`
+	Paths  map[string]rlyPath  `yaml:"paths"`
+}
+
+type rlyGlobal struct {
+	APIListenAddr  string `yaml:"api-listen-addr"`
+	Timeout        string `yaml:"timeout"`
+	Memo           string `yaml:"memo"`
+	LightCacheSize int    `yaml:"light-cache-size"`
+}
+
+type rlyChain struct {
+	Type  string        `yaml:"type"`
+	Value rlyChainValue `yaml:"value"`
+}
+
+type rlyChainValue struct {
+	Key            string  `yaml:"key"`
+	ChainID        string  `yaml:"chain-id"`
+	RPCAddr        string  `yaml:"rpc-addr"`
+	AccountPrefix  string  `yaml:"account-prefix"`
+	KeyringBackend string  `yaml:"keyring-backend"`
+	GasAdjustment  float64 `yaml:"gas-adjustment"`
+	GasPrices      string  `yaml:"gas-prices"`
+	MaxGasAmount   int64   `yaml:"max-gas-amount,omitempty"`
+	Debug          bool    `yaml:"debug"`
+	Timeout        string  `yaml:"timeout"`
+	OutputFormat   string  `yaml:"output-format"`
+	SignMode       string  `yaml:"sign-mode"`
+}
+
+type rlyPath struct {
+	Src              rlyPathEnd       `yaml:"src"`
+	Dst              rlyPathEnd       `yaml:"dst"`
+	SrcChannelFilter rlyChannelFilter `yaml:"src-channel-filter"`
+}
+
+type rlyPathEnd struct {
+	ChainID      string `yaml:"chain-id"`
+	ClientID     string `yaml:"client-id,omitempty"`
+	ConnectionID string `yaml:"connection-id,omitempty"`
+}
+
+type rlyChannelFilter struct {
+	Rule        string   `yaml:"rule"`
+	ChannelList []string `yaml:"channel-list"`
+}
+
+func exportRly(w io.Writer, c Config) error {
+	conf := rlyConfig{
+		Global: rlyGlobal{
+			APIListenAddr:  ":5183",
+			Timeout:        "10s",
+			LightCacheSize: 20,
+		},
+		Chains: make(map[string]rlyChain),
+		Paths:  make(map[string]rlyPath),
+	}
+
+	for _, chain := range c.Chains {
+		conf.Chains[chain.ID] = rlyChain{
+			Type: rlyChainType,
+			Value: rlyChainValue{
+				Key:            chain.Account,
+				ChainID:        chain.ID,
+				RPCAddr:        chain.RPCAddress,
+				AccountPrefix:  chain.AddressPrefix,
+				KeyringBackend: rlyKeyringBackend,
+				GasAdjustment:  rlyGasAdjustment,
+				GasPrices:      chain.GasPrice,
+				MaxGasAmount:   chain.GasLimit,
+				Timeout:        "20s",
+				OutputFormat:   "json",
+				SignMode:       "direct",
+			},
+		}
+	}
+
+	for _, path := range c.Paths {
+		rp := rlyPath{
+			Src: rlyPathEnd{
+				ChainID:      path.Src.ChainID,
+				ClientID:     clientID(c, path.Src),
+				ConnectionID: path.Src.ConnectionID,
+			},
+			Dst: rlyPathEnd{
+				ChainID:      path.Dst.ChainID,
+				ClientID:     clientID(c, path.Dst),
+				ConnectionID: path.Dst.ConnectionID,
+			},
+			SrcChannelFilter: rlyChannelFilter{ChannelList: []string{}},
+		}
+
+		if path.Src.ChannelID != "" {
+			rp.SrcChannelFilter = rlyChannelFilter{
+				Rule:        rlyChannelAllowlist,
+				ChannelList: []string{path.Src.ChannelID},
+			}
+		}
+
+		conf.Paths[path.ID] = rp
+	}
+
+	return yaml.NewEncoder(w).Encode(conf)
+}
+
+// importRly imports the chains and paths of a Go relayer config. The channels of the paths are
+// imported from the allowlists of their channel filters when they allow a single channel.
+func importRly(r io.Reader) (Config, error) {
+	var conf rlyConfig
+	if err := yaml.NewDecoder(r).Decode(&conf); err != nil {
+		return Config{}, err
+	}
+
+	var c Config
+
+	for _, name := range sortedKeys(conf.Chains) {
+		rc := conf.Chains[name].Value
+		c.Chains = append(c.Chains, Chain{
+			ID:            rc.ChainID,
+			Account:       rc.Key,
+			AddressPrefix: rc.AccountPrefix,
+			RPCAddress:    rc.RPCAddr,
+			GasPrice:      rc.GasPrices,
+			GasLimit:      rc.MaxGasAmount,
+		})
+	}
+
+	for _, name := range sortedKeys(conf.Paths) {
+		rp := conf.Paths[name]
+		path := Path{
+			ID: name,
+			Src: PathEnd{
+				ChainID:      rp.Src.ChainID,
+				ConnectionID: rp.Src.ConnectionID,
+				PortID:       defaultPortID,
+			},
+			Dst: PathEnd{
+				ChainID:      rp.Dst.ChainID,
+				ConnectionID: rp.Dst.ConnectionID,
+				PortID:       defaultPortID,
+			},
+		}
+
+		filter := rp.SrcChannelFilter
+		if filter.Rule == rlyChannelAllowlist && len(filter.ChannelList) == 1 {
+			path.Src.ChannelID = filter.ChannelList[0]
+		}
+
+		// the clients are set to the chains, the Ignite config has a client by chain.
+		for _, end := range []rlyPathEnd{rp.Src, rp.Dst} {
+			if i := c.chainIndex(end.ChainID); i != -1 && end.ClientID != "" {
+				c.Chains[i].ClientID = end.ClientID
+			}
+		}
+
+		c.Paths = append(c.Paths, path)
+	}
+
+	return c, nil
+}
+
+// clientID returns the id of the light client of the chain of end.
+func clientID(c Config, end PathEnd) string {
+	chain, err := c.ChainByID(end.ChainID)
+	if err != nil {
+		return ""
+	}
+	return chain.ClientID
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,199 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	ibctmtypes "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	relayerconf "github.com/ignite/cli/ignite/pkg/relayer/config"
+)
+
+const (
+	// gasAdjustment is the factor the simulated gas of the IBC txs is multiplied by.
+	gasAdjustment = 1.5
+
+	// blockPollInterval is the interval to poll the nodes for new blocks.
+	blockPollInterval = time.Millisecond * 500
+
+	// validatorsPerPage is the number of validators fetched by request to build the headers.
+	validatorsPerPage = 100
+)
+
+// endpoint is a chain of a path with the account of the relayer on the chain.
+type endpoint struct {
+	chain  relayerconf.Chain
+	client cosmosclient.Client
+
+	// address is the address of the relayer account on the chain.
+	address string
+
+	// revision is the revision number of the chain, parsed from its chain id.
+	revision uint64
+
+	// clientID is the id of the light client on the chain that tracks the counterparty chain.
+	clientID string
+
+	// connectionID is the id of the connection on the chain to the counterparty chain.
+	connectionID string
+}
+
+// newEndpoint creates an endpoint for chain, the txs are signed with the account of the chain
+// from the account registry of the relayer.
+func (r Relayer) newEndpoint(ctx context.Context, chain relayerconf.Chain) (*endpoint, error) {
+	client, err := cosmosclient.New(
+		ctx,
+		cosmosclient.WithNodeAddress(chain.RPCAddress),
+		cosmosclient.WithChainID(chain.ID),
+		cosmosclient.WithAddressPrefix(chain.AddressPrefix),
+		cosmosclient.WithAccountRegistry(r.ca),
+		cosmosclient.WithGasPrices(chain.GasPrice),
+		cosmosclient.WithGasAdjustment(gasAdjustment),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := r.ca.GetByName(chain.Account)
+	if err != nil {
+		return nil, err
+	}
+
+	return &endpoint{
+		chain:    chain,
+		client:   client,
+		address:  account.Address(chain.AddressPrefix),
+		revision: clienttypes.ParseChainID(chain.ID),
+		clientID: chain.ClientID,
+	}, nil
+}
+
+// height returns the IBC height of the block at height.
+func (e *endpoint) height(height int64) clienttypes.Height {
+	return clienttypes.NewHeight(e.revision, uint64(height))
+}
+
+// clientContext returns the client context to query the state of the chain with proofs at height,
+// the proofs are verified with the header at height.
+func (e *endpoint) clientContext(height clienttypes.Height) client.Context {
+	return e.client.Context().WithHeight(int64(height.RevisionHeight))
+}
+
+// latestBlock returns the height and the time of the latest block of the chain.
+func (e *endpoint) latestBlock(ctx context.Context) (int64, time.Time, error) {
+	status, err := e.client.Status(ctx)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	return status.SyncInfo.LatestBlockHeight, status.SyncInfo.LatestBlockTime, nil
+}
+
+// waitForHeight waits until the chain reaches height.
+func (e *endpoint) waitForHeight(ctx context.Context, height int64) error {
+	for {
+		latest, _, err := e.latestBlock(ctx)
+		if err != nil {
+			return err
+		}
+		if latest >= height {
+			return nil
+		}
+
+		select {
+		case <-time.After(blockPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// send broadcasts a tx with msgs and waits for the block that follows the one that includes it,
+// so the state changed by the tx can be proven to the counterparty chain right away.
+func (e *endpoint) send(ctx context.Context, msgs ...sdk.Msg) (cosmosclient.Response, error) {
+	client := e.client
+
+	// the gas limit of the chain is the gas of each message, the gas is simulated otherwise.
+	if e.chain.GasLimit > 0 {
+		client = client.WithTxOptions(cosmosclient.TxGas(uint64(e.chain.GasLimit) * uint64(len(msgs))))
+	}
+
+	resp, err := client.BroadcastTx(e.chain.Account, msgs...)
+	if err != nil {
+		return resp, fmt.Errorf("%s: %w", e.chain.ID, err)
+	}
+
+	return resp, e.waitForHeight(ctx, resp.Height+1)
+}
+
+// header returns the header of the block at height to update a light client of the chain
+// from its consensus state at trustedHeight.
+func (e *endpoint) header(ctx context.Context, height int64, trustedHeight clienttypes.Height) (*ibctmtypes.Header, error) {
+	commit, err := e.client.RPC.Commit(ctx, &height)
+	if err != nil {
+		return nil, err
+	}
+
+	validators, err := e.validatorSet(ctx, height)
+	if err != nil {
+		return nil, err
+	}
+
+	// the trusted validators are the next validators of the trusted consensus state.
+	trustedValidators, err := e.validatorSet(ctx, int64(trustedHeight.RevisionHeight)+1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ibctmtypes.Header{
+		SignedHeader:      commit.SignedHeader.ToProto(),
+		ValidatorSet:      validators,
+		TrustedHeight:     trustedHeight,
+		TrustedValidators: trustedValidators,
+	}, nil
+}
+
+// validatorSet returns the validators of the block at height.
+func (e *endpoint) validatorSet(ctx context.Context, height int64) (*tmproto.ValidatorSet, error) {
+	var (
+		validators []*tmtypes.Validator
+		perPage    = validatorsPerPage
+	)
+
+	for page := 1; ; page++ {
+		res, err := e.client.RPC.Validators(ctx, &height, &page, &perPage)
+		if err != nil {
+			return nil, err
+		}
+
+		validators = append(validators, res.Validators...)
+		if len(res.Validators) == 0 || len(validators) >= res.Total {
+			break
+		}
+	}
+
+	return tmtypes.NewValidatorSet(validators).ToProto()
+}
+
+// eventAttribute returns the value of the attribute key of the first event of eventType emitted by a tx.
+func eventAttribute(resp cosmosclient.Response, eventType, key string) (string, error) {
+	for _, log := range resp.Logs {
+		for _, event := range log.Events {
+			if event.Type != eventType {
+				continue
+			}
+			for _, attr := range event.Attributes {
+				if attr.Key == key {
+					return attr.Value, nil
+				}
+			}
+		}
+	}
+	return "", fmt.Errorf("no %s.%s event in tx %s", eventType, key, resp.TxHash)
+}
@@ -0,0 +1,47 @@
+package relayer
+
+import (
+	"context"
+	"testing"
+
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	cosmostestutilnode "github.com/ignite/cli/ignite/pkg/cosmostestutil/node"
+	relayerconf "github.com/ignite/cli/ignite/pkg/relayer/config"
+)
+
+func TestSendWithAddressPrefix(t *testing.T) {
+	ca, err := cosmosaccount.NewInMemory()
+	require.NoError(t, err)
+
+	account, _, err := ca.Create("relayer")
+	require.NoError(t, err)
+
+	var (
+		node = cosmostestutilnode.New(t)
+		ctx  = context.Background()
+	)
+
+	e, err := New(ca).newEndpoint(ctx, relayerconf.Chain{
+		ID:            "mars-1",
+		Account:       "relayer",
+		AddressPrefix: "mars",
+		RPCAddress:    node.URL,
+		GasPrice:      "0.1stake",
+		GasLimit:      300000,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account.Address("mars"), e.address)
+
+	_, err = e.send(ctx, &clienttypes.MsgUpdateClient{ClientId: "07-tendermint-0", Signer: e.address})
+	require.NoError(t, err)
+
+	broadcasted := node.Broadcasted()
+	require.Len(t, broadcasted, 1)
+
+	tx, err := e.client.Context().TxConfig.TxDecoder()(broadcasted[0])
+	require.NoError(t, err)
+	require.Equal(t, e.address, tx.GetMsgs()[0].(*clienttypes.MsgUpdateClient).Signer)
+}
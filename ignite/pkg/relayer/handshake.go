@@ -0,0 +1,345 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clientutils "github.com/cosmos/ibc-go/v3/modules/core/02-client/client/utils"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	connectionutils "github.com/cosmos/ibc-go/v3/modules/core/03-connection/client/utils"
+	connectiontypes "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+	channelutils "github.com/cosmos/ibc-go/v3/modules/core/04-channel/client/utils"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v3/modules/core/23-commitment/types"
+	host "github.com/cosmos/ibc-go/v3/modules/core/24-host"
+	"github.com/cosmos/ibc-go/v3/modules/core/exported"
+
+	relayerconf "github.com/ignite/cli/ignite/pkg/relayer/config"
+)
+
+// merklePrefix is the prefix of the IBC store of the chains.
+var merklePrefix = commitmenttypes.NewMerklePrefix([]byte(host.StoreKey))
+
+// link is a pair of chains connected by IBC.
+type link struct {
+	src, dst *endpoint
+}
+
+// connect creates the light clients of the chains that don't have one yet and opens a connection
+// in between with the 4-way handshake of IBC.
+func (l link) connect(ctx context.Context) error {
+	var err error
+
+	if l.dst.clientID == "" {
+		if l.dst.clientID, err = createClient(ctx, l.dst, l.src); err != nil {
+			return fmt.Errorf("cannot create client on %s: %w", l.dst.chain.ID, err)
+		}
+	}
+
+	if l.src.clientID == "" {
+		if l.src.clientID, err = createClient(ctx, l.src, l.dst); err != nil {
+			return fmt.Errorf("cannot create client on %s: %w", l.src.chain.ID, err)
+		}
+	}
+
+	// init on src.
+	resp, err := l.src.send(ctx, connectiontypes.NewMsgConnectionOpenInit(
+		l.src.clientID,
+		l.dst.clientID,
+		merklePrefix,
+		connectiontypes.DefaultIBCVersion,
+		0,
+		l.src.address,
+	))
+	if err != nil {
+		return fmt.Errorf("cannot init connection: %w", err)
+	}
+	if l.src.connectionID, err = eventAttribute(
+		resp,
+		connectiontypes.EventTypeConnectionOpenInit,
+		connectiontypes.AttributeKeyConnectionID,
+	); err != nil {
+		return err
+	}
+
+	// try on dst.
+	resp, err = sendWithProofs(ctx, l.dst, l.src, func(proofCtx client.Context) ([]sdk.Msg, error) {
+		p, err := queryConnectionProofs(proofCtx, l.src)
+		if err != nil {
+			return nil, err
+		}
+
+		return []sdk.Msg{connectiontypes.NewMsgConnectionOpenTry(
+			"",
+			l.dst.clientID,
+			l.src.connectionID,
+			l.src.clientID,
+			p.clientState,
+			merklePrefix,
+			connectiontypes.ExportedVersionsToProto(connectiontypes.GetCompatibleVersions()),
+			0,
+			p.proofConnection,
+			p.proofClient,
+			p.proofConsensus,
+			p.proofHeight,
+			p.consensusHeight,
+			l.dst.address,
+		)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot try connection: %w", err)
+	}
+	if l.dst.connectionID, err = eventAttribute(
+		resp,
+		connectiontypes.EventTypeConnectionOpenTry,
+		connectiontypes.AttributeKeyConnectionID,
+	); err != nil {
+		return err
+	}
+
+	// ack on src.
+	_, err = sendWithProofs(ctx, l.src, l.dst, func(proofCtx client.Context) ([]sdk.Msg, error) {
+		p, err := queryConnectionProofs(proofCtx, l.dst)
+		if err != nil {
+			return nil, err
+		}
+
+		return []sdk.Msg{connectiontypes.NewMsgConnectionOpenAck(
+			l.src.connectionID,
+			l.dst.connectionID,
+			p.clientState,
+			p.proofConnection,
+			p.proofClient,
+			p.proofConsensus,
+			p.proofHeight,
+			p.consensusHeight,
+			connectiontypes.DefaultIBCVersion,
+			l.src.address,
+		)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot ack connection: %w", err)
+	}
+
+	// confirm on dst.
+	_, err = sendWithProofs(ctx, l.dst, l.src, func(proofCtx client.Context) ([]sdk.Msg, error) {
+		res, err := connectionutils.QueryConnection(proofCtx, l.src.connectionID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		return []sdk.Msg{connectiontypes.NewMsgConnectionOpenConfirm(
+			l.dst.connectionID,
+			res.Proof,
+			res.ProofHeight,
+			l.dst.address,
+		)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot confirm connection: %w", err)
+	}
+
+	return nil
+}
+
+// connectionProofs are the proofs of the state of a chain to open a connection on the counterparty chain.
+type connectionProofs struct {
+	clientState     exported.ClientState
+	proofConnection []byte
+	proofClient     []byte
+	proofConsensus  []byte
+	proofHeight     clienttypes.Height
+	consensusHeight clienttypes.Height
+}
+
+// queryConnectionProofs queries the connection and the light client of e with their proofs.
+func queryConnectionProofs(proofCtx client.Context, e *endpoint) (connectionProofs, error) {
+	connection, err := connectionutils.QueryConnection(proofCtx, e.connectionID, true)
+	if err != nil {
+		return connectionProofs{}, err
+	}
+
+	client, err := clientutils.QueryClientStateABCI(proofCtx, e.clientID)
+	if err != nil {
+		return connectionProofs{}, err
+	}
+
+	clientState, err := clienttypes.UnpackClientState(client.ClientState)
+	if err != nil {
+		return connectionProofs{}, err
+	}
+
+	consensusHeight, ok := clientState.GetLatestHeight().(clienttypes.Height)
+	if !ok {
+		return connectionProofs{}, fmt.Errorf("invalid height of client %s", e.clientID)
+	}
+
+	consensus, err := clientutils.QueryConsensusStateABCI(proofCtx, e.clientID, consensusHeight)
+	if err != nil {
+		return connectionProofs{}, err
+	}
+
+	return connectionProofs{
+		clientState:     clientState,
+		proofConnection: connection.Proof,
+		proofClient:     client.Proof,
+		proofConsensus:  consensus.Proof,
+		proofHeight:     connection.ProofHeight,
+		consensusHeight: consensusHeight,
+	}, nil
+}
+
+// openChannel opens a channel between the ports of path on the connection of the link with the
+// 4-way handshake of IBC, the ids of the channel are set to path.
+func (l link) openChannel(ctx context.Context, path *relayerconf.Path) error {
+	ordering, ok := channeltypes.Order_value[path.Ordering]
+	if !ok {
+		return fmt.Errorf("invalid channel ordering %q", path.Ordering)
+	}
+	order := channeltypes.Order(ordering)
+
+	// init on src.
+	resp, err := l.src.send(ctx, channeltypes.NewMsgChannelOpenInit(
+		path.Src.PortID,
+		path.Src.Version,
+		order,
+		[]string{l.src.connectionID},
+		path.Dst.PortID,
+		l.src.address,
+	))
+	if err != nil {
+		return fmt.Errorf("cannot init channel: %w", err)
+	}
+	if path.Src.ChannelID, err = eventAttribute(
+		resp,
+		channeltypes.EventTypeChannelOpenInit,
+		channeltypes.AttributeKeyChannelID,
+	); err != nil {
+		return err
+	}
+
+	// try on dst.
+	resp, err = sendWithProofs(ctx, l.dst, l.src, func(proofCtx client.Context) ([]sdk.Msg, error) {
+		res, err := channelutils.QueryChannel(proofCtx, path.Src.PortID, path.Src.ChannelID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		return []sdk.Msg{channeltypes.NewMsgChannelOpenTry(
+			path.Dst.PortID,
+			"",
+			path.Dst.Version,
+			order,
+			[]string{l.dst.connectionID},
+			path.Src.PortID,
+			path.Src.ChannelID,
+			res.Channel.Version,
+			res.Proof,
+			res.ProofHeight,
+			l.dst.address,
+		)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot try channel: %w", err)
+	}
+	if path.Dst.ChannelID, err = eventAttribute(
+		resp,
+		channeltypes.EventTypeChannelOpenTry,
+		channeltypes.AttributeKeyChannelID,
+	); err != nil {
+		return err
+	}
+
+	// ack on src.
+	_, err = sendWithProofs(ctx, l.src, l.dst, func(proofCtx client.Context) ([]sdk.Msg, error) {
+		res, err := channelutils.QueryChannel(proofCtx, path.Dst.PortID, path.Dst.ChannelID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		return []sdk.Msg{channeltypes.NewMsgChannelOpenAck(
+			path.Src.PortID,
+			path.Src.ChannelID,
+			path.Dst.ChannelID,
+			res.Channel.Version,
+			res.Proof,
+			res.ProofHeight,
+			l.src.address,
+		)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot ack channel: %w", err)
+	}
+
+	// confirm on dst.
+	_, err = sendWithProofs(ctx, l.dst, l.src, func(proofCtx client.Context) ([]sdk.Msg, error) {
+		res, err := channelutils.QueryChannel(proofCtx, path.Src.PortID, path.Src.ChannelID, true)
+		if err != nil {
+			return nil, err
+		}
+
+		return []sdk.Msg{channeltypes.NewMsgChannelOpenConfirm(
+			path.Dst.PortID,
+			path.Dst.ChannelID,
+			res.Proof,
+			res.ProofHeight,
+			l.dst.address,
+		)}, nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot confirm channel: %w", err)
+	}
+
+	path.Src.ConnectionID = l.src.connectionID
+	path.Dst.ConnectionID = l.dst.connectionID
+
+	return nil
+}
+
+// resolveClients sets the ids of the light clients of the link from the connections of its chains.
+func (l link) resolveClients(ctx context.Context) error {
+	for _, e := range []*endpoint{l.src, l.dst} {
+		res, err := connectiontypes.NewQueryClient(e.client.Context()).Connection(ctx, &connectiontypes.QueryConnectionRequest{
+			ConnectionId: e.connectionID,
+		})
+		if err != nil {
+			return fmt.Errorf("cannot query connection %s on %s: %w", e.connectionID, e.chain.ID, err)
+		}
+		e.clientID = res.Connection.ClientId
+	}
+	return nil
+}
+
+// resolveChannel sets the ports and the counterparty channel of path from the channel of its source
+// end, which is found among the channels of the connection of the link.
+func (l link) resolveChannel(ctx context.Context, path *relayerconf.Path) error {
+	if l.src.connectionID == "" {
+		return fmt.Errorf("path %s has a channel without connection", path.ID)
+	}
+
+	channels, err := channeltypes.NewQueryClient(l.src.client.Context()).ConnectionChannels(ctx, &channeltypes.QueryConnectionChannelsRequest{
+		Connection: l.src.connectionID,
+	})
+	if err != nil {
+		return fmt.Errorf("cannot query channels of %s on %s: %w", l.src.connectionID, l.src.chain.ID, err)
+	}
+
+	for _, channel := range channels.Channels {
+		if channel.ChannelId != path.Src.ChannelID {
+			continue
+		}
+
+		path.Ordering = channel.Ordering.String()
+		path.Src.PortID = channel.PortId
+		path.Src.Version = channel.Version
+		path.Dst.PortID = channel.Counterparty.PortId
+		path.Dst.ChannelID = channel.Counterparty.ChannelId
+		path.Dst.Version = channel.Version
+
+		return nil
+	}
+
+	return fmt.Errorf("channel %s not found on connection %s of %s", path.Src.ChannelID, l.src.connectionID, l.src.chain.ID)
+}
@@ -0,0 +1,229 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	upgradetypes "github.com/cosmos/cosmos-sdk/x/upgrade/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	commitmenttypes "github.com/cosmos/ibc-go/v3/modules/core/23-commitment/types"
+	ibctmtypes "github.com/cosmos/ibc-go/v3/modules/light-clients/07-tendermint/types"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+)
+
+const (
+	// maxClockDrift is the max. time a header of the counterparty chain can be ahead of the chain.
+	maxClockDrift = time.Second * 20
+
+	// clientMaxAge is the max. age of the latest consensus state of a client before it's updated
+	// even if there are no packets to relay.
+	clientMaxAge = time.Hour * 24
+
+	// clientRefreshRate is the fraction of the trusting period of a client, as a divisor, after
+	// which the client is updated even if there are no packets to relay.
+	clientRefreshRate = 3
+)
+
+// createClient creates a light client on host that tracks counterparty and returns its id.
+func createClient(ctx context.Context, host, counterparty *endpoint) (string, error) {
+	height, _, err := counterparty.latestBlock(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	commit, err := counterparty.client.RPC.Commit(ctx, &height)
+	if err != nil {
+		return "", err
+	}
+
+	params, err := stakingtypes.NewQueryClient(counterparty.client.Context()).Params(ctx, &stakingtypes.QueryParamsRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	// the client must be updated within two thirds of the unbonding period of the counterparty
+	// chain, so a misbehavior can be punished while the validators are still bonded.
+	var (
+		unbondingPeriod = params.Params.UnbondingTime
+		trustingPeriod  = unbondingPeriod * 2 / 3
+		header          = commit.SignedHeader.Header
+	)
+
+	clientState := ibctmtypes.NewClientState(
+		counterparty.chain.ID,
+		ibctmtypes.DefaultTrustLevel,
+		trustingPeriod,
+		unbondingPeriod,
+		maxClockDrift,
+		counterparty.height(height),
+		commitmenttypes.GetSDKSpecs(),
+		[]string{upgradetypes.StoreKey, upgradetypes.KeyUpgradedIBCState},
+		false,
+		false,
+	)
+
+	consensusState := ibctmtypes.NewConsensusState(
+		header.Time,
+		commitmenttypes.NewMerkleRoot(header.AppHash),
+		header.NextValidatorsHash,
+	)
+
+	msg, err := clienttypes.NewMsgCreateClient(clientState, consensusState, host.address)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := host.send(ctx, msg)
+	if err != nil {
+		return "", err
+	}
+
+	return eventAttribute(resp, clienttypes.EventTypeCreateClient, clienttypes.AttributeKeyClientID)
+}
+
+// clientState returns the state of the light client of host.
+func clientState(ctx context.Context, host *endpoint) (*ibctmtypes.ClientState, error) {
+	res, err := clienttypes.NewQueryClient(host.client.Context()).ClientState(ctx, &clienttypes.QueryClientStateRequest{
+		ClientId: host.clientID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := clienttypes.UnpackClientState(res.ClientState)
+	if err != nil {
+		return nil, err
+	}
+
+	tmState, ok := state.(*ibctmtypes.ClientState)
+	if !ok {
+		return nil, fmt.Errorf("client %s on %s is not a Tendermint client", host.clientID, host.chain.ID)
+	}
+
+	return tmState, nil
+}
+
+// consensusState returns the consensus state of the light client of host at height.
+func consensusState(ctx context.Context, host *endpoint, height clienttypes.Height) (*ibctmtypes.ConsensusState, error) {
+	res, err := clienttypes.NewQueryClient(host.client.Context()).ConsensusState(ctx, &clienttypes.QueryConsensusStateRequest{
+		ClientId:       host.clientID,
+		RevisionNumber: height.RevisionNumber,
+		RevisionHeight: height.RevisionHeight,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := clienttypes.UnpackConsensusState(res.ConsensusState)
+	if err != nil {
+		return nil, err
+	}
+
+	tmState, ok := state.(*ibctmtypes.ConsensusState)
+	if !ok {
+		return nil, fmt.Errorf("client %s on %s is not a Tendermint client", host.clientID, host.chain.ID)
+	}
+
+	return tmState, nil
+}
+
+// updateClientMsg returns the message to update the light client of host to the latest height of
+// counterparty, and the height the client is updated to. The message is nil when the client is
+// already up-to-date.
+func updateClientMsg(ctx context.Context, host, counterparty *endpoint) (sdk.Msg, clienttypes.Height, error) {
+	state, err := clientState(ctx, host)
+	if err != nil {
+		return nil, clienttypes.Height{}, err
+	}
+
+	latest, _, err := counterparty.latestBlock(ctx)
+	if err != nil {
+		return nil, clienttypes.Height{}, err
+	}
+
+	trustedHeight := state.LatestHeight
+	if int64(trustedHeight.RevisionHeight) >= latest {
+		return nil, trustedHeight, nil
+	}
+
+	header, err := counterparty.header(ctx, latest, trustedHeight)
+	if err != nil {
+		return nil, clienttypes.Height{}, err
+	}
+
+	msg, err := clienttypes.NewMsgUpdateClient(host.clientID, header, host.address)
+	if err != nil {
+		return nil, clienttypes.Height{}, err
+	}
+
+	return msg, counterparty.height(latest), nil
+}
+
+// updateClientIfStale updates the light client of host to the latest height of counterparty when
+// its latest consensus state is older than maxAge or than the fraction of its trusting period set
+// by clientRefreshRate. It returns the height the client is updated to, zero when it's not updated.
+func updateClientIfStale(ctx context.Context, host, counterparty *endpoint, maxAge time.Duration) (clienttypes.Height, error) {
+	state, err := clientState(ctx, host)
+	if err != nil {
+		return clienttypes.ZeroHeight(), err
+	}
+
+	consensus, err := consensusState(ctx, host, state.LatestHeight)
+	if err != nil {
+		return clienttypes.ZeroHeight(), err
+	}
+
+	if time.Since(consensus.Timestamp) < clientRefreshAge(state.TrustingPeriod, maxAge) {
+		return clienttypes.ZeroHeight(), nil
+	}
+
+	msg, height, err := updateClientMsg(ctx, host, counterparty)
+	if err != nil || msg == nil {
+		return clienttypes.ZeroHeight(), err
+	}
+
+	if _, err := host.send(ctx, msg); err != nil {
+		return clienttypes.ZeroHeight(), err
+	}
+
+	return height, nil
+}
+
+// clientRefreshAge returns the age of the latest consensus state of a client with trustingPeriod
+// from which the client is updated, which is at most maxAge.
+func clientRefreshAge(trustingPeriod, maxAge time.Duration) time.Duration {
+	if age := trustingPeriod / clientRefreshRate; age < maxAge {
+		return age
+	}
+	return maxAge
+}
+
+// sendWithProofs updates the light client of host to the latest height of counterparty and
+// broadcasts to host, in the same tx, the msgs built by build from the state of counterparty
+// proven at the height of the client.
+func sendWithProofs(
+	ctx context.Context,
+	host, counterparty *endpoint,
+	build func(proofCtx client.Context) ([]sdk.Msg, error),
+) (cosmosclient.Response, error) {
+	update, height, err := updateClientMsg(ctx, host, counterparty)
+	if err != nil {
+		return cosmosclient.Response{}, err
+	}
+
+	msgs, err := build(counterparty.clientContext(height))
+	if err != nil {
+		return cosmosclient.Response{}, err
+	}
+
+	if update != nil {
+		msgs = append([]sdk.Msg{update}, msgs...)
+	}
+
+	return host.send(ctx, msgs...)
+}
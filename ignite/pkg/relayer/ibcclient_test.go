@@ -0,0 +1,13 @@
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientRefreshAge(t *testing.T) {
+	require.Equal(t, time.Hour, clientRefreshAge(time.Hour*3, clientMaxAge))
+	require.Equal(t, clientMaxAge, clientRefreshAge(time.Hour*24*14, clientMaxAge))
+}
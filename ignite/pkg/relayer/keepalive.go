@@ -0,0 +1,176 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	"github.com/cosmos/ibc-go/v3/modules/core/exported"
+
+	"github.com/ignite/cli/ignite/pkg/ctxticker"
+	relayerconf "github.com/ignite/cli/ignite/pkg/relayer/config"
+)
+
+// keepAliveInterval is the interval to check the light clients kept alive.
+const keepAliveInterval = time.Minute
+
+// ClientUpdate is an update of a light client made to prevent its expiry.
+type ClientUpdate struct {
+	// ChainID is the id of the chain that hosts the light client.
+	ChainID  string
+	ClientID string
+
+	// CounterpartyChainID is the id of the chain tracked by the light client.
+	CounterpartyChainID string
+
+	// Height is the height of the counterparty chain the client is updated to.
+	Height clienttypes.Height
+
+	// Expiry is the time the light client expires at without any further update.
+	Expiry time.Time
+}
+
+// trackedClient is a light client on host that tracks counterparty.
+type trackedClient struct {
+	host, counterparty *endpoint
+}
+
+// KeepAlive updates the light clients of the chains and linked paths from config file before they
+// expire until ctx is canceled. The clients are updated once their latest consensus states are
+// older than a third of their trusting period, onUpdate is called after each update.
+func (r Relayer) KeepAlive(ctx context.Context, onUpdate func(ClientUpdate)) error {
+	conf, err := relayerconf.Get()
+	if err != nil {
+		return err
+	}
+
+	clients, err := r.trackedClients(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	if len(clients) == 0 {
+		return fmt.Errorf("no light clients to keep alive, configure the client ids of the chains or link a path")
+	}
+
+	for _, c := range clients {
+		if err := checkClientActive(ctx, c.host); err != nil {
+			return err
+		}
+	}
+
+	return ctxticker.DoNow(ctx, keepAliveInterval, func() error {
+		for _, c := range clients {
+			height, err := updateClientIfStale(ctx, c.host, c.counterparty, clientMaxAge)
+			if err != nil {
+				return fmt.Errorf("cannot update client %s on %s: %w", c.host.clientID, c.host.chain.ID, err)
+			}
+			if height.IsZero() {
+				continue
+			}
+
+			update := ClientUpdate{
+				ChainID:             c.host.chain.ID,
+				ClientID:            c.host.clientID,
+				CounterpartyChainID: c.counterparty.chain.ID,
+				Height:              height,
+			}
+
+			state, err := clientState(ctx, c.host)
+			if err != nil {
+				return err
+			}
+
+			consensus, err := consensusState(ctx, c.host, height)
+			if err != nil {
+				return err
+			}
+			update.Expiry = consensus.Timestamp.Add(state.TrustingPeriod)
+
+			if onUpdate != nil {
+				onUpdate(update)
+			}
+		}
+		return nil
+	})
+}
+
+// trackedClients returns the light clients set to the chains and the ones of the linked paths
+// from conf, each client is returned once.
+func (r Relayer) trackedClients(ctx context.Context, conf relayerconf.Config) ([]trackedClient, error) {
+	var (
+		clients []trackedClient
+		tracked = make(map[string]bool)
+	)
+
+	add := func(host, counterparty *endpoint) {
+		key := host.chain.ID + "/" + host.clientID
+		if tracked[key] {
+			return
+		}
+		tracked[key] = true
+		clients = append(clients, trackedClient{host: host, counterparty: counterparty})
+	}
+
+	for _, chain := range conf.Chains {
+		if chain.ClientID == "" {
+			continue
+		}
+
+		host, err := r.newEndpoint(ctx, chain)
+		if err != nil {
+			return nil, err
+		}
+
+		// the counterparty chain is the one tracked by the client.
+		state, err := clientState(ctx, host)
+		if err != nil {
+			return nil, fmt.Errorf("cannot query client %s on %s: %w", host.clientID, chain.ID, err)
+		}
+
+		counterpartyChain, err := conf.ChainByID(state.ChainId)
+		if err != nil {
+			return nil, fmt.Errorf("chain tracked by client %s on %s: %w", host.clientID, chain.ID, err)
+		}
+
+		counterparty, err := r.newEndpoint(ctx, counterpartyChain)
+		if err != nil {
+			return nil, err
+		}
+
+		add(host, counterparty)
+	}
+
+	for _, path := range conf.Paths {
+		if path.Src.ConnectionID == "" {
+			continue
+		}
+
+		l, err := r.newLink(ctx, conf, path)
+		if err != nil {
+			return nil, err
+		}
+
+		add(l.src, l.dst)
+		add(l.dst, l.src)
+	}
+
+	return clients, nil
+}
+
+// checkClientActive checks that the light client of host can still be updated.
+func checkClientActive(ctx context.Context, host *endpoint) error {
+	res, err := clienttypes.NewQueryClient(host.client.Context()).ClientStatus(ctx, &clienttypes.QueryClientStatusRequest{
+		ClientId: host.clientID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if res.Status != exported.Active.String() {
+		return fmt.Errorf("client %s on %s is %s and cannot be updated anymore", host.clientID, host.chain.ID, res.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,516 @@
+package relayer
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	channelutils "github.com/cosmos/ibc-go/v3/modules/core/04-channel/client/utils"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	relayerconf "github.com/ignite/cli/ignite/pkg/relayer/config"
+)
+
+const (
+	// maxMsgsPerTx is the max. number of packets relayed by tx.
+	maxMsgsPerTx = 50
+
+	// txsPerPage is the number of txs fetched by request to search for packets.
+	txsPerPage = 100
+
+	// timeoutMargin is the min. time left before the timeout of a packet to relay it,
+	// the packets closer to their timeout are timed out instead once they expire.
+	timeoutMargin = time.Second * 5
+)
+
+// packetEvent is a packet emitted by a tx, with its acknowledgement for the
+// write_acknowledgement events.
+type packetEvent struct {
+	packet channeltypes.Packet
+	ack    []byte
+
+	// height is the height of the tx that emitted the event.
+	height int64
+}
+
+// relay relays the packets sent on both ends of path since their packet heights, and their
+// acknowledgements written since the ack heights. The packets that timed out are timed out on
+// the chain that sent them. The heights of path are moved past the blocks searched.
+func (l link) relay(ctx context.Context, path *relayerconf.Path) error {
+	ordered := path.Ordering == OrderingOrdered
+
+	if err := relayPackets(ctx, l.src, l.dst, &path.Src, ordered); err != nil {
+		return fmt.Errorf("cannot relay packets from %s: %w", l.src.chain.ID, err)
+	}
+	if err := relayPackets(ctx, l.dst, l.src, &path.Dst, ordered); err != nil {
+		return fmt.Errorf("cannot relay packets from %s: %w", l.dst.chain.ID, err)
+	}
+	if err := relayAcks(ctx, l.src, l.dst, &path.Dst); err != nil {
+		return fmt.Errorf("cannot relay acknowledgements to %s: %w", l.src.chain.ID, err)
+	}
+	if err := relayAcks(ctx, l.dst, l.src, &path.Src); err != nil {
+		return fmt.Errorf("cannot relay acknowledgements to %s: %w", l.dst.chain.ID, err)
+	}
+
+	if _, err := updateClientIfStale(ctx, l.src, l.dst, clientMaxAge); err != nil {
+		return fmt.Errorf("cannot update client on %s: %w", l.src.chain.ID, err)
+	}
+	if _, err := updateClientIfStale(ctx, l.dst, l.src, clientMaxAge); err != nil {
+		return fmt.Errorf("cannot update client on %s: %w", l.dst.chain.ID, err)
+	}
+
+	return nil
+}
+
+// relayPackets relays the packets sent by the end of from to to, or times them out on from when
+// they expired. The packets are searched from the packet height of the end.
+func relayPackets(ctx context.Context, from, to *endpoint, end *relayerconf.PathEnd, ordered bool) error {
+	events, height, err := queryPacketEvents(ctx, from, channeltypes.EventTypeSendPacket, end.PortID, end.ChannelID, end.PacketHeight)
+	if err != nil {
+		return err
+	}
+
+	skipped, err := relayPacketEvents(ctx, from, to, events, ordered)
+	if err != nil {
+		return err
+	}
+
+	end.PacketHeight = nextPacketHeight(height, skipped)
+	return nil
+}
+
+// nextPacketHeight returns the height to search the next packets from, height unless packets are
+// skipped, the skipped packets being searched again from the lowest of their heights.
+func nextPacketHeight(height int64, skipped []packetEvent) int64 {
+	for _, e := range skipped {
+		if e.height < height {
+			height = e.height
+		}
+	}
+	return height
+}
+
+// relayPacketEvents relays the pending packets of events sent by from to to, or times them out on
+// from when they expired. The packets too close to their timeout to be received are skipped until
+// they time out, they are returned to be relayed again.
+func relayPacketEvents(ctx context.Context, from, to *endpoint, events []packetEvent, ordered bool) (skipped []packetEvent, err error) {
+	packets, err := pendingPackets(ctx, from, to, events)
+	if err != nil {
+		return nil, err
+	}
+
+	latest, latestTime, err := to.latestBlock(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var receivable, timedOut []packetEvent
+	for _, e := range packets {
+		switch packetTimeout(e.packet, to.height(latest), latestTime) {
+		case timeoutNone:
+			receivable = append(receivable, e)
+		case timeoutClose:
+			skipped = append(skipped, e)
+		case timeoutExpired:
+			timedOut = append(timedOut, e)
+		}
+	}
+
+	for _, batch := range batchPacketEvents(receivable) {
+		batch := batch
+		if _, err := sendWithProofs(ctx, to, from, func(proofCtx client.Context) ([]sdk.Msg, error) {
+			return recvPacketMsgs(proofCtx, batch, to.address)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, batch := range batchPacketEvents(timedOut) {
+		batch := batch
+		if _, err := sendWithProofs(ctx, from, to, func(proofCtx client.Context) ([]sdk.Msg, error) {
+			return timeoutMsgs(proofCtx, batch, ordered, from.address)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	return skipped, nil
+}
+
+// relayAcks relays to from the acknowledgements written by the end of to for the packets sent by
+// from. The acknowledgements are searched from the ack height of the end.
+func relayAcks(ctx context.Context, from, to *endpoint, end *relayerconf.PathEnd) error {
+	events, height, err := queryPacketEvents(ctx, to, channeltypes.EventTypeWriteAck, end.PortID, end.ChannelID, end.AckHeight)
+	if err != nil {
+		return err
+	}
+
+	if err := relayAckEvents(ctx, from, to, events); err != nil {
+		return err
+	}
+
+	end.AckHeight = height
+	return nil
+}
+
+// relayAckEvents relays to from the pending acknowledgements of events written by to.
+func relayAckEvents(ctx context.Context, from, to *endpoint, events []packetEvent) error {
+	// the acknowledgements are pending until the commitments of their packets are deleted.
+	sequences, err := committedSequences(ctx, from, events)
+	if err != nil {
+		return err
+	}
+
+	var acks []packetEvent
+	for _, e := range events {
+		if sequences[e.packet.Sequence] {
+			acks = append(acks, e)
+		}
+	}
+
+	for _, batch := range batchPacketEvents(acks) {
+		batch := batch
+		if _, err := sendWithProofs(ctx, from, to, func(proofCtx client.Context) ([]sdk.Msg, error) {
+			return ackMsgs(proofCtx, batch, from.address)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func recvPacketMsgs(proofCtx client.Context, packets []packetEvent, signer string) ([]sdk.Msg, error) {
+	var msgs []sdk.Msg
+	for _, e := range packets {
+		p := e.packet
+		res, err := channelutils.QueryPacketCommitment(proofCtx, p.SourcePort, p.SourceChannel, p.Sequence, true)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, channeltypes.NewMsgRecvPacket(p, res.Proof, res.ProofHeight, signer))
+	}
+	return msgs, nil
+}
+
+func timeoutMsgs(proofCtx client.Context, packets []packetEvent, ordered bool, signer string) ([]sdk.Msg, error) {
+	var msgs []sdk.Msg
+	for _, e := range packets {
+		p := e.packet
+		// an ordered channel proves the timeout with its next sequence to receive, an unordered
+		// channel with the absence of the receipt of the packet.
+		if ordered {
+			res, err := channelutils.QueryNextSequenceReceive(proofCtx, p.DestinationPort, p.DestinationChannel, true)
+			if err != nil {
+				return nil, err
+			}
+			msgs = append(msgs, channeltypes.NewMsgTimeout(p, res.NextSequenceReceive, res.Proof, res.ProofHeight, signer))
+			continue
+		}
+
+		res, err := channelutils.QueryPacketReceipt(proofCtx, p.DestinationPort, p.DestinationChannel, p.Sequence, true)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, channeltypes.NewMsgTimeout(p, p.Sequence, res.Proof, res.ProofHeight, signer))
+	}
+	return msgs, nil
+}
+
+func ackMsgs(proofCtx client.Context, acks []packetEvent, signer string) ([]sdk.Msg, error) {
+	var msgs []sdk.Msg
+	for _, e := range acks {
+		p := e.packet
+		res, err := channelutils.QueryPacketAcknowledgement(proofCtx, p.DestinationPort, p.DestinationChannel, p.Sequence, true)
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, channeltypes.NewMsgAcknowledgement(p, e.ack, res.Proof, res.ProofHeight, signer))
+	}
+	return msgs, nil
+}
+
+// pendingPackets returns the packets of events that are not received by to yet and whose
+// commitments still exist on from, i.e. the packets that are neither received nor timed out.
+func pendingPackets(ctx context.Context, from, to *endpoint, events []packetEvent) ([]packetEvent, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+
+	committed, err := committedSequences(ctx, from, events)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		p         = events[0].packet
+		sequences = make([]uint64, 0, len(events))
+	)
+	for _, e := range events {
+		sequences = append(sequences, e.packet.Sequence)
+	}
+
+	res, err := channeltypes.NewQueryClient(to.client.Context()).UnreceivedPackets(ctx, &channeltypes.QueryUnreceivedPacketsRequest{
+		PortId:                    p.DestinationPort,
+		ChannelId:                 p.DestinationChannel,
+		PacketCommitmentSequences: sequences,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	unreceived := make(map[uint64]bool)
+	for _, sequence := range res.Sequences {
+		unreceived[sequence] = true
+	}
+
+	var packets []packetEvent
+	for _, e := range events {
+		if unreceived[e.packet.Sequence] && committed[e.packet.Sequence] {
+			packets = append(packets, e)
+		}
+	}
+
+	return packets, nil
+}
+
+// committedSequences returns the sequences of the packets of events whose commitments still
+// exist on from, i.e. the packets sent by from that are neither acknowledged nor timed out.
+func committedSequences(ctx context.Context, from *endpoint, events []packetEvent) (map[uint64]bool, error) {
+	committed := make(map[uint64]bool)
+	if len(events) == 0 {
+		return committed, nil
+	}
+
+	var (
+		p         = events[0].packet
+		sequences = make([]uint64, 0, len(events))
+	)
+	for _, e := range events {
+		sequences = append(sequences, e.packet.Sequence)
+	}
+
+	res, err := channeltypes.NewQueryClient(from.client.Context()).UnreceivedAcks(ctx, &channeltypes.QueryUnreceivedAcksRequest{
+		PortId:             p.SourcePort,
+		ChannelId:          p.SourceChannel,
+		PacketAckSequences: sequences,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sequence := range res.Sequences {
+		committed[sequence] = true
+	}
+
+	return committed, nil
+}
+
+// queryPacketEvents returns the packets of the events of eventType emitted by the txs of e from
+// fromHeight for port and channel, the source ones for the send_packet events and the destination
+// ones for the write_acknowledgement events. It returns the height to search the next events from.
+func queryPacketEvents(
+	ctx context.Context,
+	e *endpoint,
+	eventType, port, channel string,
+	fromHeight int64,
+) (events []packetEvent, nextHeight int64, err error) {
+	latest, _, err := e.latestBlock(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	events, err = searchPacketEvents(ctx, e, eventType, port, channel,
+		fmt.Sprintf("tx.height>=%d AND tx.height<=%d", fromHeight, latest))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return events, latest + 1, nil
+}
+
+// queryPacketEventsBySequence returns the packets with sequences of the events of eventType
+// emitted by the txs of e for port and channel, whatever the height of the txs.
+func queryPacketEventsBySequence(
+	ctx context.Context,
+	e *endpoint,
+	eventType, port, channel string,
+	sequences []uint64,
+) ([]packetEvent, error) {
+	var events []packetEvent
+
+	for _, sequence := range sequences {
+		found, err := searchPacketEvents(ctx, e, eventType, port, channel,
+			fmt.Sprintf("%s.%s='%d'", eventType, channeltypes.AttributeKeySequence, sequence))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pe := range found {
+			if pe.packet.Sequence == sequence {
+				events = append(events, pe)
+				break
+			}
+		}
+	}
+
+	return events, nil
+}
+
+// searchPacketEvents returns the packets of the events of eventType for port and channel emitted
+// by the txs of e that match conditions, sorted by sequence.
+func searchPacketEvents(ctx context.Context, e *endpoint, eventType, port, channel, conditions string) ([]packetEvent, error) {
+	portKey, channelKey := channeltypes.AttributeKeySrcPort, channeltypes.AttributeKeySrcChannel
+	if eventType == channeltypes.EventTypeWriteAck {
+		portKey, channelKey = channeltypes.AttributeKeyDstPort, channeltypes.AttributeKeyDstChannel
+	}
+
+	query := fmt.Sprintf("%[1]s.%[2]s='%[3]s' AND %[1]s.%[4]s='%[5]s' AND %[6]s",
+		eventType, portKey, port, channelKey, channel, conditions)
+
+	var (
+		events  []packetEvent
+		seen    = make(map[uint64]bool)
+		perPage = txsPerPage
+	)
+
+	for page, count := 1, 0; ; page++ {
+		res, err := e.client.RPC.TxSearch(ctx, query, false, &page, &perPage, "asc")
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tx := range res.Txs {
+			for _, event := range tx.TxResult.Events {
+				if event.Type != eventType {
+					continue
+				}
+
+				pe, err := parsePacketEvent(event)
+				if err != nil {
+					return nil, err
+				}
+
+				// a tx can emit the events of packets of other channels.
+				end := pe.packet.SourcePort + pe.packet.SourceChannel
+				if eventType == channeltypes.EventTypeWriteAck {
+					end = pe.packet.DestinationPort + pe.packet.DestinationChannel
+				}
+				if end != port+channel || seen[pe.packet.Sequence] {
+					continue
+				}
+
+				pe.height = tx.Height
+				seen[pe.packet.Sequence] = true
+				events = append(events, pe)
+			}
+		}
+
+		count += len(res.Txs)
+		if len(res.Txs) == 0 || count >= res.TotalCount {
+			break
+		}
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].packet.Sequence < events[j].packet.Sequence
+	})
+
+	return events, nil
+}
+
+// parsePacketEvent parses the packet of a send_packet or write_acknowledgement event.
+func parsePacketEvent(event abci.Event) (packetEvent, error) {
+	attributes := make(map[string]string)
+	for _, attr := range event.Attributes {
+		attributes[string(attr.Key)] = string(attr.Value)
+	}
+
+	var (
+		pe  packetEvent
+		err error
+	)
+
+	p := &pe.packet
+	p.SourcePort = attributes[channeltypes.AttributeKeySrcPort]
+	p.SourceChannel = attributes[channeltypes.AttributeKeySrcChannel]
+	p.DestinationPort = attributes[channeltypes.AttributeKeyDstPort]
+	p.DestinationChannel = attributes[channeltypes.AttributeKeyDstChannel]
+
+	if p.Sequence, err = strconv.ParseUint(attributes[channeltypes.AttributeKeySequence], 10, 64); err != nil {
+		return packetEvent{}, fmt.Errorf("invalid packet sequence: %w", err)
+	}
+	if p.Data, err = hex.DecodeString(attributes[channeltypes.AttributeKeyDataHex]); err != nil {
+		return packetEvent{}, fmt.Errorf("invalid packet data: %w", err)
+	}
+	if p.TimeoutHeight, err = clienttypes.ParseHeight(attributes[channeltypes.AttributeKeyTimeoutHeight]); err != nil {
+		return packetEvent{}, fmt.Errorf("invalid packet timeout height: %w", err)
+	}
+	if p.TimeoutTimestamp, err = strconv.ParseUint(attributes[channeltypes.AttributeKeyTimeoutTimestamp], 10, 64); err != nil {
+		return packetEvent{}, fmt.Errorf("invalid packet timeout timestamp: %w", err)
+	}
+
+	if event.Type == channeltypes.EventTypeWriteAck {
+		if pe.ack, err = hex.DecodeString(attributes[channeltypes.AttributeKeyAckHex]); err != nil {
+			return packetEvent{}, fmt.Errorf("invalid packet acknowledgement: %w", err)
+		}
+	}
+
+	return pe, nil
+}
+
+// timeoutState is the state of the timeout of a packet.
+type timeoutState int
+
+const (
+	// timeoutNone is the state of a packet that can be received.
+	timeoutNone timeoutState = iota
+
+	// timeoutClose is the state of a packet too close to its timeout to be received.
+	timeoutClose
+
+	// timeoutExpired is the state of a packet that timed out.
+	timeoutExpired
+)
+
+// packetTimeout returns the state of the timeout of p on a chain whose latest block is at height and time.
+func packetTimeout(p channeltypes.Packet, height clienttypes.Height, blockTime time.Time) timeoutState {
+	var (
+		timeoutHeight = p.TimeoutHeight
+		timestamp     = uint64(blockTime.UnixNano())
+	)
+
+	if (!timeoutHeight.IsZero() && height.GTE(timeoutHeight)) ||
+		(p.TimeoutTimestamp != 0 && timestamp >= p.TimeoutTimestamp) {
+		return timeoutExpired
+	}
+
+	// the packet is received by the next block at the earliest.
+	if (!timeoutHeight.IsZero() && height.Increment().GTE(timeoutHeight)) ||
+		(p.TimeoutTimestamp != 0 && timestamp+uint64(timeoutMargin) >= p.TimeoutTimestamp) {
+		return timeoutClose
+	}
+
+	return timeoutNone
+}
+
+// batchPacketEvents splits events into batches of maxMsgsPerTx events.
+func batchPacketEvents(events []packetEvent) [][]packetEvent {
+	var batches [][]packetEvent
+	for len(events) > 0 {
+		n := maxMsgsPerTx
+		if len(events) < n {
+			n = len(events)
+		}
+		batches = append(batches, events[:n])
+		events = events[n:]
+	}
+	return batches
+}
@@ -0,0 +1,111 @@
+package relayer
+
+import (
+	"testing"
+	"time"
+
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestParsePacketEvent(t *testing.T) {
+	attributes := func(pairs ...string) []abci.EventAttribute {
+		var attrs []abci.EventAttribute
+		for i := 0; i < len(pairs); i += 2 {
+			attrs = append(attrs, abci.EventAttribute{Key: []byte(pairs[i]), Value: []byte(pairs[i+1])})
+		}
+		return attrs
+	}
+
+	packetAttributes := []string{
+		channeltypes.AttributeKeyDataHex, "7b7d",
+		channeltypes.AttributeKeyTimeoutHeight, "1-100",
+		channeltypes.AttributeKeyTimeoutTimestamp, "0",
+		channeltypes.AttributeKeySequence, "3",
+		channeltypes.AttributeKeySrcPort, "transfer",
+		channeltypes.AttributeKeySrcChannel, "channel-0",
+		channeltypes.AttributeKeyDstPort, "transfer",
+		channeltypes.AttributeKeyDstChannel, "channel-1",
+	}
+
+	expected := channeltypes.NewPacket(
+		[]byte("{}"),
+		3,
+		"transfer",
+		"channel-0",
+		"transfer",
+		"channel-1",
+		clienttypes.NewHeight(1, 100),
+		0,
+	)
+
+	pe, err := parsePacketEvent(abci.Event{
+		Type:       channeltypes.EventTypeSendPacket,
+		Attributes: attributes(packetAttributes...),
+	})
+	require.NoError(t, err)
+	require.Equal(t, expected, pe.packet)
+	require.Nil(t, pe.ack)
+
+	pe, err = parsePacketEvent(abci.Event{
+		Type:       channeltypes.EventTypeWriteAck,
+		Attributes: attributes(append(packetAttributes, channeltypes.AttributeKeyAckHex, "01")...),
+	})
+	require.NoError(t, err)
+	require.Equal(t, expected, pe.packet)
+	require.Equal(t, []byte{1}, pe.ack)
+
+	_, err = parsePacketEvent(abci.Event{
+		Type:       channeltypes.EventTypeSendPacket,
+		Attributes: attributes(channeltypes.AttributeKeySequence, "x"),
+	})
+	require.Error(t, err)
+}
+
+func TestPacketTimeout(t *testing.T) {
+	var (
+		now    = time.Unix(1000, 0)
+		height = clienttypes.NewHeight(1, 10)
+	)
+
+	packet := func(timeoutHeight uint64, timeoutTime time.Time) channeltypes.Packet {
+		p := channeltypes.Packet{TimeoutHeight: clienttypes.ZeroHeight()}
+		if timeoutHeight != 0 {
+			p.TimeoutHeight = clienttypes.NewHeight(1, timeoutHeight)
+		}
+		if !timeoutTime.IsZero() {
+			p.TimeoutTimestamp = uint64(timeoutTime.UnixNano())
+		}
+		return p
+	}
+
+	tests := []struct {
+		name   string
+		packet channeltypes.Packet
+		want   timeoutState
+	}{
+		{"no timeout", packet(0, time.Time{}), timeoutNone},
+		{"future height", packet(20, time.Time{}), timeoutNone},
+		{"next height", packet(11, time.Time{}), timeoutClose},
+		{"past height", packet(10, time.Time{}), timeoutExpired},
+		{"future time", packet(0, now.Add(time.Minute)), timeoutNone},
+		{"close time", packet(0, now.Add(time.Second)), timeoutClose},
+		{"past time", packet(20, now), timeoutExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, packetTimeout(tt.packet, height, now))
+		})
+	}
+}
+
+func TestNextPacketHeight(t *testing.T) {
+	require.Equal(t, int64(20), nextPacketHeight(20, nil))
+
+	// the packets skipped close to their timeout are searched again until they time out.
+	skipped := []packetEvent{{height: 15}, {height: 12}, {height: 18}}
+	require.Equal(t, int64(12), nextPacketHeight(20, skipped))
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
 	"github.com/ignite/cli/ignite/pkg/cosmosclient"
 	"github.com/ignite/cli/ignite/pkg/ctxticker"
-	tsrelayer "github.com/ignite/cli/ignite/pkg/nodetime/programs/ts-relayer"
 	relayerconf "github.com/ignite/cli/ignite/pkg/relayer/config"
 	"github.com/ignite/cli/ignite/pkg/xurl"
 )
@@ -70,11 +69,31 @@ func (r Relayer) Link(
 		return conf, err
 	}
 
-	if path.Src.ChannelID != "" {
+	if path.Src.ChannelID != "" && path.Dst.ChannelID != "" {
 		return conf, fmt.Errorf("path %s already linked", path.ID)
 	}
 
-	if path, err = r.call(ctx, conf, path, "link"); err != nil {
+	l, err := r.prepareLink(ctx, conf, path)
+	if err != nil {
+		return conf, err
+	}
+
+	// the paths imported from the configs of other relayers have their channel on one end only.
+	if path.Src.ChannelID != "" {
+		if err := l.resolveChannel(ctx, &path); err != nil {
+			return conf, err
+		}
+		return conf, conf.UpdatePath(path)
+	}
+
+	// the paths imported with a connection reuse it.
+	if path.Src.ConnectionID == "" {
+		if err := l.connect(ctx); err != nil {
+			return conf, err
+		}
+	}
+
+	if err := l.openChannel(ctx, &path); err != nil {
 		return conf, err
 	}
 
@@ -115,8 +134,7 @@ func (r Relayer) Start(
 		if err != nil {
 			return err
 		}
-		path, err = r.call(ctx, conf, path, "start")
-		if err != nil {
+		if err := r.relay(ctx, conf, &path); err != nil {
 			return err
 		}
 		if err := conf.UpdatePath(path); err != nil {
@@ -129,55 +147,82 @@ func (r Relayer) Start(
 	})
 }
 
-func (r Relayer) call(
-	ctx context.Context,
-	conf relayerconf.Config,
-	path relayerconf.Path,
-	action string,
-) (
-	reply relayerconf.Path, err error,
-) {
-	srcChain, srcKey, err := r.prepare(ctx, conf, path.Src.ChainID)
+// relay relays the pending packets and acknowledgements of the linked path.
+func (r Relayer) relay(ctx context.Context, conf relayerconf.Config, path *relayerconf.Path) error {
+	l, err := r.prepareLink(ctx, conf, *path)
 	if err != nil {
-		return relayerconf.Path{}, err
+		return err
 	}
 
-	dstChain, dstKey, err := r.prepare(ctx, conf, path.Dst.ChainID)
+	return l.relay(ctx, path)
+}
+
+// prepareLink checks that the accounts of the relayer can pay for the txs on the chains of path
+// and creates the link between them.
+func (r Relayer) prepareLink(ctx context.Context, conf relayerconf.Config, path relayerconf.Path) (link, error) {
+	for _, chainID := range []string{path.Src.ChainID, path.Dst.ChainID} {
+		if err := r.prepare(ctx, conf, chainID); err != nil {
+			return link{}, err
+		}
+	}
+
+	return r.newLink(ctx, conf, path)
+}
+
+// newLink creates the link between the chains of path, the clients of a linked path are resolved
+// from its connections.
+func (r Relayer) newLink(ctx context.Context, conf relayerconf.Config, path relayerconf.Path) (link, error) {
+	srcChain, err := conf.ChainByID(path.Src.ChainID)
 	if err != nil {
-		return relayerconf.Path{}, err
+		return link{}, err
+	}
+
+	dstChain, err := conf.ChainByID(path.Dst.ChainID)
+	if err != nil {
+		return link{}, err
+	}
+
+	src, err := r.newEndpoint(ctx, srcChain)
+	if err != nil {
+		return link{}, err
+	}
+
+	dst, err := r.newEndpoint(ctx, dstChain)
+	if err != nil {
+		return link{}, err
 	}
 
-	args := []interface{}{
-		path,
-		srcChain,
-		dstChain,
-		srcKey,
-		dstKey,
+	l := link{src: src, dst: dst}
+
+	if path.Src.ConnectionID == "" {
+		return l, nil
 	}
-	return reply, tsrelayer.Call(ctx, action, args, &reply)
+
+	src.connectionID = path.Src.ConnectionID
+	dst.connectionID = path.Dst.ConnectionID
+
+	return l, l.resolveClients(ctx)
 }
 
-func (r Relayer) prepare(ctx context.Context, conf relayerconf.Config, chainID string) (
-	chain relayerconf.Chain, privKey string, err error,
-) {
-	chain, err = conf.ChainByID(chainID)
+func (r Relayer) prepare(ctx context.Context, conf relayerconf.Config, chainID string) error {
+	chain, err := conf.ChainByID(chainID)
 	if err != nil {
-		return relayerconf.Chain{}, "", err
+		return err
 	}
 
 	coins, err := r.balance(ctx, chain.RPCAddress, chain.Account, chain.AddressPrefix)
 	if err != nil {
-		return relayerconf.Chain{}, "", err
+		return err
 	}
 
 	gasPrice, err := sdk.ParseCoinNormalized(chain.GasPrice)
 	if err != nil {
-		return relayerconf.Chain{}, "", err
+		return err
 	}
 
 	account, err := r.ca.GetByName(chain.Account)
 	if err != nil {
-		return relayerconf.Chain{}, "", err
+		return err
 	}
 
 	errMissingBalance := fmt.Errorf(`account "%s(%s)" on %q chain does not have enough balances`,
@@ -187,7 +232,7 @@ func (r Relayer) prepare(ctx context.Context, conf relayerconf.Config, chainID s
 	)
 
 	if len(coins) == 0 {
-		return relayerconf.Chain{}, "", errMissingBalance
+		return errMissingBalance
 	}
 
 	for _, coin := range coins {
@@ -196,16 +241,11 @@ func (r Relayer) prepare(ctx context.Context, conf relayerconf.Config, chainID s
 		}
 
 		if gasPrice.Amount.Int64()*ibcSetupGas > coin.Amount.Int64() {
-			return relayerconf.Chain{}, "", errMissingBalance
+			return errMissingBalance
 		}
 	}
 
-	key, err := r.ca.ExportHex(chain.Account, "")
-	if err != nil {
-		return relayerconf.Chain{}, "", err
-	}
-
-	return chain, key, nil
+	return nil
 }
 
 func (r Relayer) balance(ctx context.Context, rpcAddress, account, addressPrefix string) (sdk.Coins, error) {
@@ -0,0 +1,327 @@
+package relayer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/types/query"
+	clienttypes "github.com/cosmos/ibc-go/v3/modules/core/02-client/types"
+	connectiontypes "github.com/cosmos/ibc-go/v3/modules/core/03-connection/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+
+	relayerconf "github.com/ignite/cli/ignite/pkg/relayer/config"
+)
+
+// PathStatus is the status of a path.
+type PathStatus struct {
+	ID string
+
+	// Linked is true when a channel is open between the ends of the path.
+	Linked bool
+
+	Src, Dst PathEndStatus
+}
+
+// PathEndStatus is the status of an end of a path.
+type PathEndStatus struct {
+	ChainID string
+
+	// ClientID is the id of the light client on the chain that tracks the counterparty chain.
+	ClientID     string
+	ClientStatus string
+	ClientHeight clienttypes.Height
+
+	// TrustingPeriod is the period the light client must be updated within to stay active.
+	TrustingPeriod time.Duration
+
+	// ClientExpiry is the time the light client expires at without any update.
+	ClientExpiry time.Time
+
+	ConnectionID    string
+	ConnectionState string
+	PortID          string
+	ChannelID       string
+	ChannelState    string
+
+	// PacketHeight and AckHeight are the heights the relayer searches the next packets and
+	// acknowledgements written on the chain from.
+	PacketHeight int64
+	AckHeight    int64
+
+	// UnrelayedPackets are the sequences of the packets sent by the chain and not received by the
+	// counterparty chain yet.
+	UnrelayedPackets []uint64
+
+	// UnrelayedAcks are the sequences of the acknowledgements written by the chain and not relayed
+	// to the counterparty chain yet.
+	UnrelayedAcks []uint64
+}
+
+// Status returns the status of the path from config file with pathID.
+func (r Relayer) Status(ctx context.Context, pathID string) (PathStatus, error) {
+	conf, err := relayerconf.Get()
+	if err != nil {
+		return PathStatus{}, err
+	}
+
+	path, err := conf.PathByID(pathID)
+	if err != nil {
+		return PathStatus{}, err
+	}
+
+	status := PathStatus{
+		ID:     path.ID,
+		Linked: path.Src.ChannelID != "" && path.Dst.ChannelID != "",
+		Src:    newPathEndStatus(path.Src),
+		Dst:    newPathEndStatus(path.Dst),
+	}
+
+	if !status.Linked {
+		return status, nil
+	}
+
+	l, err := r.newLink(ctx, conf, path)
+	if err != nil {
+		return PathStatus{}, err
+	}
+
+	if err := endStatus(ctx, l.src, l.dst, path.Src, path.Dst, &status.Src); err != nil {
+		return PathStatus{}, fmt.Errorf("cannot query status of %s: %w", l.src.chain.ID, err)
+	}
+	if err := endStatus(ctx, l.dst, l.src, path.Dst, path.Src, &status.Dst); err != nil {
+		return PathStatus{}, fmt.Errorf("cannot query status of %s: %w", l.dst.chain.ID, err)
+	}
+
+	return status, nil
+}
+
+// Clear relays once the pending packets and acknowledgements of the linked path from config file
+// with pathID, whatever the heights they were sent at.
+func (r Relayer) Clear(ctx context.Context, pathID string) error {
+	conf, err := relayerconf.Get()
+	if err != nil {
+		return err
+	}
+
+	path, err := conf.PathByID(pathID)
+	if err != nil {
+		return err
+	}
+
+	if path.Src.ChannelID == "" || path.Dst.ChannelID == "" {
+		return fmt.Errorf("path %s is not linked", path.ID)
+	}
+
+	l, err := r.prepareLink(ctx, conf, path)
+	if err != nil {
+		return err
+	}
+
+	ordered := path.Ordering == OrderingOrdered
+
+	if err := clearPackets(ctx, l.src, l.dst, path.Src, path.Dst, ordered); err != nil {
+		return fmt.Errorf("cannot relay packets from %s: %w", l.src.chain.ID, err)
+	}
+	if err := clearPackets(ctx, l.dst, l.src, path.Dst, path.Src, ordered); err != nil {
+		return fmt.Errorf("cannot relay packets from %s: %w", l.dst.chain.ID, err)
+	}
+	if err := clearAcks(ctx, l.src, l.dst, path.Src, path.Dst); err != nil {
+		return fmt.Errorf("cannot relay acknowledgements to %s: %w", l.src.chain.ID, err)
+	}
+	if err := clearAcks(ctx, l.dst, l.src, path.Dst, path.Src); err != nil {
+		return fmt.Errorf("cannot relay acknowledgements to %s: %w", l.dst.chain.ID, err)
+	}
+
+	return nil
+}
+
+// clearPackets relays the packets sent by the end of from that are not received by to yet, or
+// times them out on from when they expired.
+func clearPackets(ctx context.Context, from, to *endpoint, fromEnd, toEnd relayerconf.PathEnd, ordered bool) error {
+	sequences, err := unrelayedPackets(ctx, from, to, fromEnd, toEnd)
+	if err != nil || len(sequences) == 0 {
+		return err
+	}
+
+	events, err := queryPacketEventsBySequence(ctx, from, channeltypes.EventTypeSendPacket, fromEnd.PortID, fromEnd.ChannelID, sequences)
+	if err != nil {
+		return err
+	}
+
+	// the skipped packets are still unrelayed, they are relayed by the next clear.
+	_, err = relayPacketEvents(ctx, from, to, events, ordered)
+	return err
+}
+
+// clearAcks relays to from the acknowledgements written by the end of to for the packets sent by
+// from that still have commitments on from.
+func clearAcks(ctx context.Context, from, to *endpoint, fromEnd, toEnd relayerconf.PathEnd) error {
+	sequences, err := unrelayedAcks(ctx, to, from, toEnd, fromEnd)
+	if err != nil || len(sequences) == 0 {
+		return err
+	}
+
+	events, err := queryPacketEventsBySequence(ctx, to, channeltypes.EventTypeWriteAck, toEnd.PortID, toEnd.ChannelID, sequences)
+	if err != nil {
+		return err
+	}
+
+	return relayAckEvents(ctx, from, to, events)
+}
+
+// newPathEndStatus returns the status of end from the config file only.
+func newPathEndStatus(end relayerconf.PathEnd) PathEndStatus {
+	return PathEndStatus{
+		ChainID:      end.ChainID,
+		ConnectionID: end.ConnectionID,
+		PortID:       end.PortID,
+		ChannelID:    end.ChannelID,
+		PacketHeight: end.PacketHeight,
+		AckHeight:    end.AckHeight,
+	}
+}
+
+// endStatus completes status with the state of the light client, the connection and the channel of
+// e, and the sequences of the packets and acknowledgements of e not relayed to counterparty yet.
+func endStatus(
+	ctx context.Context,
+	e, counterparty *endpoint,
+	end, counterpartyEnd relayerconf.PathEnd,
+	status *PathEndStatus,
+) error {
+	status.ClientID = e.clientID
+
+	clientStatus, err := clienttypes.NewQueryClient(e.client.Context()).ClientStatus(ctx, &clienttypes.QueryClientStatusRequest{
+		ClientId: e.clientID,
+	})
+	if err != nil {
+		return err
+	}
+	status.ClientStatus = clientStatus.Status
+
+	state, err := clientState(ctx, e)
+	if err != nil {
+		return err
+	}
+	status.ClientHeight = state.LatestHeight
+	status.TrustingPeriod = state.TrustingPeriod
+
+	consensus, err := consensusState(ctx, e, state.LatestHeight)
+	if err != nil {
+		return err
+	}
+	status.ClientExpiry = consensus.Timestamp.Add(state.TrustingPeriod)
+
+	connection, err := connectiontypes.NewQueryClient(e.client.Context()).Connection(ctx, &connectiontypes.QueryConnectionRequest{
+		ConnectionId: e.connectionID,
+	})
+	if err != nil {
+		return err
+	}
+	status.ConnectionState = connection.Connection.State.String()
+
+	channel, err := channeltypes.NewQueryClient(e.client.Context()).Channel(ctx, &channeltypes.QueryChannelRequest{
+		PortId:    end.PortID,
+		ChannelId: end.ChannelID,
+	})
+	if err != nil {
+		return err
+	}
+	status.ChannelState = channel.Channel.State.String()
+
+	if status.UnrelayedPackets, err = unrelayedPackets(ctx, e, counterparty, end, counterpartyEnd); err != nil {
+		return err
+	}
+
+	status.UnrelayedAcks, err = unrelayedAcks(ctx, e, counterparty, end, counterpartyEnd)
+	return err
+}
+
+// unrelayedPackets returns the sequences of the packets sent by the end of from that are not
+// received by to yet.
+func unrelayedPackets(ctx context.Context, from, to *endpoint, fromEnd, toEnd relayerconf.PathEnd) ([]uint64, error) {
+	var (
+		sequences []uint64
+		key       []byte
+	)
+
+	for {
+		res, err := channeltypes.NewQueryClient(from.client.Context()).PacketCommitments(ctx, &channeltypes.QueryPacketCommitmentsRequest{
+			PortId:     fromEnd.PortID,
+			ChannelId:  fromEnd.ChannelID,
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, commitment := range res.Commitments {
+			sequences = append(sequences, commitment.Sequence)
+		}
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		key = res.Pagination.NextKey
+	}
+
+	if len(sequences) == 0 {
+		return nil, nil
+	}
+
+	res, err := channeltypes.NewQueryClient(to.client.Context()).UnreceivedPackets(ctx, &channeltypes.QueryUnreceivedPacketsRequest{
+		PortId:                    toEnd.PortID,
+		ChannelId:                 toEnd.ChannelID,
+		PacketCommitmentSequences: sequences,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Sequences, nil
+}
+
+// unrelayedAcks returns the sequences of the acknowledgements written by the end of from for the
+// packets whose commitments still exist on to.
+func unrelayedAcks(ctx context.Context, from, to *endpoint, fromEnd, toEnd relayerconf.PathEnd) ([]uint64, error) {
+	var (
+		sequences []uint64
+		key       []byte
+	)
+
+	for {
+		res, err := channeltypes.NewQueryClient(from.client.Context()).PacketAcknowledgements(ctx, &channeltypes.QueryPacketAcknowledgementsRequest{
+			PortId:     fromEnd.PortID,
+			ChannelId:  fromEnd.ChannelID,
+			Pagination: &query.PageRequest{Key: key},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, ack := range res.Acknowledgements {
+			sequences = append(sequences, ack.Sequence)
+		}
+
+		if res.Pagination == nil || len(res.Pagination.NextKey) == 0 {
+			break
+		}
+		key = res.Pagination.NextKey
+	}
+
+	if len(sequences) == 0 {
+		return nil, nil
+	}
+
+	res, err := channeltypes.NewQueryClient(to.client.Context()).UnreceivedAcks(ctx, &channeltypes.QueryUnreceivedAcksRequest{
+		PortId:             toEnd.PortID,
+		ChannelId:          toEnd.ChannelID,
+		PacketAckSequences: sequences,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res.Sequences, nil
+}
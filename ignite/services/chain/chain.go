@@ -15,6 +15,7 @@ import (
 	"github.com/ignite/cli/ignite/pkg/chaincmd"
 	chaincmdrunner "github.com/ignite/cli/ignite/pkg/chaincmd/runner"
 	"github.com/ignite/cli/ignite/pkg/confile"
+	"github.com/ignite/cli/ignite/pkg/cosmosanalysis/module"
 	"github.com/ignite/cli/ignite/pkg/cosmosver"
 	"github.com/ignite/cli/ignite/pkg/repoversion"
 	"github.com/ignite/cli/ignite/pkg/xurl"
@@ -89,6 +90,9 @@ type chainOptions struct {
 
 	// path of a custom config file
 	ConfigFile string
+
+	// portOffset is added to the ports of the servers of the chain.
+	portOffset int
 }
 
 // Option configures Chain.
@@ -232,11 +236,18 @@ func (c *Chain) ConfigPath() string {
 
 // Config returns the config of the chain
 func (c *Chain) Config() (chainconfig.Config, error) {
-	configPath := c.ConfigPath()
-	if configPath == "" {
-		return chainconfig.DefaultConf, nil
+	conf := chainconfig.DefaultConf
+	if configPath := c.ConfigPath(); configPath != "" {
+		var err error
+		if conf, err = chainconfig.ParseFile(configPath); err != nil {
+			return chainconfig.Config{}, err
+		}
+	}
+
+	if c.options.portOffset != 0 {
+		return chainconfig.OffsetPorts(conf, c.options.portOffset)
 	}
-	return chainconfig.ParseFile(configPath)
+	return conf, nil
 }
 
 // ID returns the chain's id.
@@ -274,6 +285,11 @@ func (c *Chain) Name() string {
 	return c.app.N()
 }
 
+// IBCPorts returns the ports of the IBC modules scaffolded in the app.
+func (c *Chain) IBCPorts() ([]module.IBCPort, error) {
+	return module.DiscoverIBCPorts(c.app.Path)
+}
+
 // Binary returns the name of app's default (appd) binary.
 func (c *Chain) Binary() (string, error) {
 	conf, err := c.Config()
@@ -293,6 +309,12 @@ func (c *Chain) SetHome(home string) {
 	c.options.homePath = home
 }
 
+// SetPortOffset sets the offset added to the ports of the servers of the chain, so the chain can
+// be served next to another one.
+func (c *Chain) SetPortOffset(offset int) {
+	c.options.portOffset = offset
+}
+
 // Home returns the blockchain node's home dir.
 func (c *Chain) Home() (string, error) {
 	// check if home is explicitly defined for the app
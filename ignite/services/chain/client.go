@@ -0,0 +1,64 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/cosmosutil"
+	"github.com/ignite/cli/ignite/pkg/xurl"
+)
+
+// cosmosClient returns a client to the chain's node that uses the chain's keyring.
+func (c *Chain) cosmosClient(ctx context.Context, addressPrefix string, options ...cosmosclient.Option) (cosmosclient.Client, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	home, err := c.Home()
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	keyringBackend, err := c.KeyringBackend()
+	if err != nil {
+		return cosmosclient.Client{}, err
+	}
+
+	nodeAddress, err := xurl.HTTP(conf.Host.RPC)
+	if err != nil {
+		return cosmosclient.Client{}, fmt.Errorf("invalid host rpc address format: %w", err)
+	}
+
+	options = append([]cosmosclient.Option{
+		cosmosclient.WithNodeAddress(nodeAddress),
+		cosmosclient.WithHome(home),
+		cosmosclient.WithKeyringBackend(cosmosaccount.KeyringBackend(keyringBackend)),
+		cosmosclient.WithAddressPrefix(addressPrefix),
+	}, options...)
+
+	return cosmosclient.New(ctx, options...)
+}
+
+// AddressPrefix returns the bech32 prefix of the account addresses of the chain, read from the
+// address of its validator account.
+func (c *Chain) AddressPrefix(ctx context.Context) (string, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return "", err
+	}
+
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	account, err := commands.ShowAccount(ctx, conf.Validator.Name)
+	if err != nil {
+		return "", err
+	}
+
+	return cosmosutil.GetAddressPrefix(account.Address)
+}
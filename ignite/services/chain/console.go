@@ -0,0 +1,65 @@
+package chain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/ignite/cli/ignite/pkg/cosmosconsole"
+	"github.com/ignite/cli/ignite/pkg/cosmosutil"
+)
+
+// ErrConsoleIsDisabled is returned when the console is disabled in the config.yml.
+var ErrConsoleIsDisabled = errors.New("console is disabled in the config.yml")
+
+// Console returns the developer console of the chain. Accounts defined in the config.yml
+// that are stored in the chain's keyring can sign messages through the console.
+// The chain's node must be running to create the console.
+func (c *Chain) Console(ctx context.Context) (cosmosconsole.Console, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return cosmosconsole.Console{}, err
+	}
+
+	if conf.Console.Disabled {
+		return cosmosconsole.Console{}, ErrConsoleIsDisabled
+	}
+
+	commands, err := c.Commands(ctx)
+	if err != nil {
+		return cosmosconsole.Console{}, err
+	}
+
+	// accounts that are only defined by their address are not in the keyring.
+	var accounts []string
+	for _, account := range conf.Accounts {
+		if account.Address == "" {
+			accounts = append(accounts, account.Name)
+		}
+	}
+
+	// the address prefix of the chain is discovered from one of its accounts.
+	prefix := "cosmos"
+	if len(accounts) > 0 {
+		account, err := commands.ShowAccount(ctx, accounts[0])
+		if err != nil {
+			return cosmosconsole.Console{}, err
+		}
+		if prefix, err = cosmosutil.GetAddressPrefix(account.Address); err != nil {
+			return cosmosconsole.Console{}, err
+		}
+	}
+
+	client, err := c.cosmosClient(ctx, prefix)
+	if err != nil {
+		return cosmosconsole.Console{}, err
+	}
+
+	return cosmosconsole.New(
+		client,
+		cosmosconsole.Accounts(accounts...),
+		cosmosconsole.AddressPrefix(prefix),
+		cosmosconsole.Title(fmt.Sprintf("%s console", c.app.Name)),
+	), nil
+}
@@ -4,13 +4,18 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/pkg/errors"
 
+	"github.com/ignite/cli/ignite/chainconfig"
 	chaincmdrunner "github.com/ignite/cli/ignite/pkg/chaincmd/runner"
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
 	"github.com/ignite/cli/ignite/pkg/cosmosfaucet"
+	"github.com/ignite/cli/ignite/pkg/cosmosutil"
 	"github.com/ignite/cli/ignite/pkg/xurl"
 )
 
@@ -47,13 +52,26 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		return cosmosfaucet.Faucet{}, ErrFaucetIsNotEnabled
 	}
 
-	if _, err := commands.ShowAccount(ctx, *conf.Faucet.Name); err != nil {
+	account, err := commands.ShowAccount(ctx, *conf.Faucet.Name)
+	if err != nil {
 		if err == chaincmdrunner.ErrAccountDoesNotExist {
 			return cosmosfaucet.Faucet{}, ErrFaucetAccountDoesNotExist
 		}
 		return cosmosfaucet.Faucet{}, err
 	}
 
+	// the faucet signs its transfers with the chain's keyring.
+	prefix, err := cosmosutil.GetAddressPrefix(account.Address)
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+
+	// the transfers pay the min. gas prices of the node.
+	client, err := c.cosmosClient(ctx, prefix, cosmosclient.WithAutoGasPrices())
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+
 	// construct faucet options.
 	apiAddress := conf.Host.API
 	if envAPIAddress != "" {
@@ -65,26 +83,90 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 		return cosmosfaucet.Faucet{}, fmt.Errorf("invalid host api address format: %w", err)
 	}
 
+	home, err := c.Home()
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+
 	faucetOptions := []cosmosfaucet.Option{
-		cosmosfaucet.Account(*conf.Faucet.Name, "", ""),
+		cosmosfaucet.Account(*conf.Faucet.Name, "", faucetCoinType(conf)),
 		cosmosfaucet.ChainID(id),
 		cosmosfaucet.OpenAPI(apiAddress),
+		// the ledger is kept in the chain's home to be reset together with the chain.
+		cosmosfaucet.LedgerPath(filepath.Join(home, "faucet", "ledger.db")),
+	}
+
+	coinOptions, err := faucetCoinOptions(conf.Faucet.Coins, conf.Faucet.CoinsMax, conf.Faucet.DenomAliases)
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+
+	faucetOptions = append(faucetOptions, coinOptions...)
+
+	if conf.Faucet.RateLimitWindow != "" {
+		rateLimitWindow, err := time.ParseDuration(conf.Faucet.RateLimitWindow)
+		if err != nil {
+			return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, conf.Faucet.RateLimitWindow)
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.RefreshWindow(rateLimitWindow))
+	}
+
+	if requestLog := conf.Faucet.RequestLog; requestLog != "" {
+		if !filepath.IsAbs(requestLog) {
+			requestLog = filepath.Join(c.app.Path, requestLog)
+		}
+
+		faucetOptions = append(faucetOptions, cosmosfaucet.RequestLogPath(requestLog))
+	}
+
+	accessOptions, err := faucetAccessOptions(conf.Faucet)
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+
+	faucetOptions = append(faucetOptions, accessOptions...)
+
+	poolOptions, err := faucetPoolOptions(conf)
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+
+	faucetOptions = append(faucetOptions, poolOptions...)
+
+	chainOptions, err := c.faucetChainOptions(ctx, conf.Faucet.Chains)
+	if err != nil {
+		return cosmosfaucet.Faucet{}, err
+	}
+
+	faucetOptions = append(faucetOptions, chainOptions...)
+
+	// init the faucet with options and return.
+	return cosmosfaucet.New(ctx, commands, client.FaucetClient(), faucetOptions...)
+}
+
+// faucetCoinOptions returns the faucet options to distribute coins with their max. amounts in coinsMax.
+func faucetCoinOptions(coins, coinsMax []string, denomAliases map[string]string) ([]cosmosfaucet.Option, error) {
+	var options []cosmosfaucet.Option
+
+	for alias, denom := range denomAliases {
+		options = append(options, cosmosfaucet.DenomAlias(alias, denom))
 	}
 
 	// parse coins to pass to the faucet as coins.
-	for _, coin := range conf.Faucet.Coins {
+	for _, coin := range coins {
 		parsedCoin, err := sdk.ParseCoinNormalized(coin)
 		if err != nil {
-			return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, coin)
+			return nil, fmt.Errorf("%s: %s", err, coin)
 		}
 
 		var amountMax uint64
 
 		// find out the max amount for this coin.
-		for _, coinMax := range conf.Faucet.CoinsMax {
+		for _, coinMax := range coinsMax {
 			parsedMax, err := sdk.ParseCoinNormalized(coinMax)
 			if err != nil {
-				return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, coin)
+				return nil, fmt.Errorf("%s: %s", err, coin)
 			}
 			if parsedMax.Denom == parsedCoin.Denom {
 				amountMax = parsedMax.Amount.Uint64()
@@ -92,18 +174,225 @@ func (c *Chain) Faucet(ctx context.Context) (cosmosfaucet.Faucet, error) {
 			}
 		}
 
-		faucetOptions = append(faucetOptions, cosmosfaucet.Coin(parsedCoin.Amount.Uint64(), amountMax, parsedCoin.Denom))
+		options = append(options, cosmosfaucet.Coin(parsedCoin.Amount.Uint64(), amountMax, parsedCoin.Denom))
 	}
 
-	if conf.Faucet.RateLimitWindow != "" {
-		rateLimitWindow, err := time.ParseDuration(conf.Faucet.RateLimitWindow)
+	return options, nil
+}
+
+// faucetChainOptions returns the faucet options to serve the other chains in chains.
+// the accounts of the chains are kept in the keyring of the chain.
+func (c *Chain) faucetChainOptions(ctx context.Context, chains []chainconfig.FaucetChain) ([]cosmosfaucet.Option, error) {
+	if len(chains) == 0 {
+		return nil, nil
+	}
+
+	home, err := c.Home()
+	if err != nil {
+		return nil, err
+	}
+
+	keyringBackend, err := c.KeyringBackend()
+	if err != nil {
+		return nil, err
+	}
+
+	var options []cosmosfaucet.Option
+
+	for _, chain := range chains {
+		if chain.RPC == "" || chain.AddressPrefix == "" || chain.Name == "" {
+			return nil, fmt.Errorf("faucet chain %q: rpc, address_prefix and name are required", chain.ChainID)
+		}
+
+		nodeAddress, err := xurl.HTTP(chain.RPC)
 		if err != nil {
-			return cosmosfaucet.Faucet{}, fmt.Errorf("%s: %s", err, conf.Faucet.RateLimitWindow)
+			return nil, fmt.Errorf("faucet chain %q: invalid rpc address format: %w", chain.ChainID, err)
 		}
 
-		faucetOptions = append(faucetOptions, cosmosfaucet.RefreshWindow(rateLimitWindow))
+		clientOptions := []cosmosclient.Option{
+			cosmosclient.WithNodeAddress(nodeAddress),
+			cosmosclient.WithHome(home),
+			cosmosclient.WithKeyringBackend(cosmosaccount.KeyringBackend(keyringBackend)),
+			cosmosclient.WithAddressPrefix(chain.AddressPrefix),
+			cosmosclient.WithAutoGasPrices(),
+		}
+		if chain.ChainID != "" {
+			clientOptions = append(clientOptions, cosmosclient.WithChainID(chain.ChainID))
+		}
+
+		client, err := cosmosclient.New(ctx, clientOptions...)
+		if err != nil {
+			return nil, err
+		}
+
+		chainID := chain.ChainID
+		if chainID == "" {
+			chainID = client.Context().ChainID
+		}
+
+		chainOptions := []cosmosfaucet.Option{
+			cosmosfaucet.ChainID(chainID),
+			cosmosfaucet.AddressPrefix(chain.AddressPrefix),
+			cosmosfaucet.Account(chain.Name, chain.Mnemonic, chain.CoinType),
+			cosmosfaucet.LedgerPath(filepath.Join(home, "faucet", fmt.Sprintf("ledger-%s.db", chainID))),
+		}
+
+		coinOptions, err := faucetCoinOptions(chain.Coins, chain.CoinsMax, chain.DenomAliases)
+		if err != nil {
+			return nil, fmt.Errorf("faucet chain %s: %w", chainID, err)
+		}
+
+		options = append(options, cosmosfaucet.AdditionalChain(client.FaucetClient(), append(chainOptions, coinOptions...)...))
 	}
 
-	// init the faucet with options and return.
-	return cosmosfaucet.New(ctx, commands, faucetOptions...)
+	return options, nil
+}
+
+// faucetAccessOptions returns the faucet options to control the access to the faucet.
+func faucetAccessOptions(conf chainconfig.Faucet) ([]cosmosfaucet.Option, error) {
+	var options []cosmosfaucet.Option
+
+	window := cosmosfaucet.DefaultRateLimitWindow
+	if conf.RateLimit.Window != "" {
+		var err error
+		if window, err = time.ParseDuration(conf.RateLimit.Window); err != nil {
+			return nil, fmt.Errorf("faucet rate limit window: %w", err)
+		}
+	}
+
+	options = append(
+		options,
+		cosmosfaucet.RateLimit(conf.RateLimit.PerIP, conf.RateLimit.Global, window),
+		cosmosfaucet.ClientIPHeader(conf.RateLimit.IPHeader),
+		cosmosfaucet.TrustedProxies(conf.RateLimit.TrustedProxies),
+		cosmosfaucet.AllowAddresses(conf.AllowedAddresses...),
+		cosmosfaucet.DenyAddresses(conf.DeniedAddresses...),
+	)
+
+	switch conf.Verifier.Type {
+	case "":
+	case cosmosfaucet.VerifierPoW:
+		if conf.Verifier.Difficulty == 0 {
+			return nil, errors.New("faucet verifier: difficulty is required for pow")
+		}
+
+		verifier, err := cosmosfaucet.NewPoWVerifier(conf.Verifier.Difficulty)
+		if err != nil {
+			return nil, err
+		}
+
+		options = append(options, cosmosfaucet.RequestVerifier(verifier))
+
+	case cosmosfaucet.VerifierCaptcha:
+		if conf.Verifier.URL == "" || conf.Verifier.Secret == "" {
+			return nil, errors.New("faucet verifier: url and secret are required for captcha")
+		}
+
+		options = append(options, cosmosfaucet.RequestVerifier(
+			cosmosfaucet.NewCaptchaVerifier(conf.Verifier.URL, conf.Verifier.Secret),
+		))
+
+	case cosmosfaucet.VerifierTest:
+		if conf.Verifier.Token == "" {
+			return nil, errors.New("faucet verifier: token is required for test")
+		}
+
+		options = append(options, cosmosfaucet.RequestVerifier(cosmosfaucet.NewTestVerifier(conf.Verifier.Token)))
+
+	default:
+		return nil, fmt.Errorf("faucet verifier: unknown type %q", conf.Verifier.Type)
+	}
+
+	for _, apiKey := range conf.APIKeys {
+		if apiKey.Key == "" {
+			return nil, fmt.Errorf("faucet api key %q: key is required", apiKey.Name)
+		}
+
+		tier := cosmosfaucet.Tier{
+			Name:      apiKey.Name,
+			RateLimit: apiKey.RateLimit,
+			CoinsMax:  make(map[string]uint64),
+		}
+
+		for _, coinMax := range apiKey.CoinsMax {
+			parsedMax, err := sdk.ParseCoinNormalized(coinMax)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", err, coinMax)
+			}
+			tier.CoinsMax[parsedMax.Denom] = parsedMax.Amount.Uint64()
+		}
+
+		options = append(options, cosmosfaucet.APIKey(apiKey.Key, tier))
+	}
+
+	return options, nil
+}
+
+// faucetPoolOptions returns the faucet options to send the transfers from a pool of accounts.
+func faucetPoolOptions(conf chainconfig.Config) ([]cosmosfaucet.Option, error) {
+	pool := conf.Faucet.Pool
+	if pool.Size == 0 {
+		return nil, nil
+	}
+
+	// the accounts are derived from the mnemonic of the faucet account by default.
+	mnemonic := pool.Mnemonic
+	if mnemonic == "" {
+		if account, ok := faucetAccount(conf); ok {
+			mnemonic = account.Mnemonic
+		}
+	}
+	if mnemonic == "" {
+		return nil, errors.New("faucet pool: mnemonic is required when the faucet account has no mnemonic")
+	}
+
+	options := []cosmosfaucet.Option{cosmosfaucet.AccountPool(mnemonic, pool.Size)}
+
+	if len(pool.RefillThreshold) == 0 && len(pool.RefillAmount) == 0 {
+		return options, nil
+	}
+
+	threshold, err := parseCoins(pool.RefillThreshold)
+	if err != nil {
+		return nil, fmt.Errorf("faucet pool refill threshold: %w", err)
+	}
+
+	amount, err := parseCoins(pool.RefillAmount)
+	if err != nil {
+		return nil, fmt.Errorf("faucet pool refill amount: %w", err)
+	}
+
+	if threshold.Empty() || amount.Empty() {
+		return nil, errors.New("faucet pool: refill threshold and amount are required to refill the accounts")
+	}
+
+	return append(options, cosmosfaucet.Refill(threshold, amount)), nil
+}
+
+// faucetAccount returns the faucet account from the accounts of the config.
+func faucetAccount(conf chainconfig.Config) (chainconfig.Account, bool) {
+	for _, account := range conf.Accounts {
+		if account.Name == *conf.Faucet.Name {
+			return account, true
+		}
+	}
+	return chainconfig.Account{}, false
+}
+
+// faucetCoinType returns the coin type of the faucet account.
+func faucetCoinType(conf chainconfig.Config) string {
+	account, _ := faucetAccount(conf)
+	return account.CoinType
+}
+
+func parseCoins(coins []string) (sdk.Coins, error) {
+	var parsed sdk.Coins
+	for _, coin := range coins {
+		c, err := sdk.ParseCoinNormalized(coin)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", err, coin)
+		}
+		parsed = parsed.Add(c)
+	}
+	return parsed, nil
 }
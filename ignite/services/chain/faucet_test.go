@@ -0,0 +1,155 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/chainconfig"
+)
+
+func TestFaucetAccessOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		conf    chainconfig.Faucet
+		wantErr bool
+	}{
+		{
+			name: "no access control",
+		},
+		{
+			name: "all access controls",
+			conf: chainconfig.Faucet{
+				RateLimit:        chainconfig.FaucetRateLimit{PerIP: 5, Global: 100, Window: "1h", IPHeader: "X-Forwarded-For"},
+				Verifier:         chainconfig.FaucetVerifier{Type: "pow", Difficulty: 20},
+				APIKeys:          []chainconfig.FaucetAPIKey{{Name: "ci", Key: "key", RateLimit: 1000, CoinsMax: []string{"1000000token"}}},
+				AllowedAddresses: []string{"cosmos1dd246yq6z5vzjz9gh8cff46pll75yyl8ygndsj"},
+			},
+		},
+		{
+			name:    "invalid window",
+			conf:    chainconfig.Faucet{RateLimit: chainconfig.FaucetRateLimit{Window: "1"}},
+			wantErr: true,
+		},
+		{
+			name:    "unknown verifier",
+			conf:    chainconfig.Faucet{Verifier: chainconfig.FaucetVerifier{Type: "unknown"}},
+			wantErr: true,
+		},
+		{
+			name:    "captcha without secret",
+			conf:    chainconfig.Faucet{Verifier: chainconfig.FaucetVerifier{Type: "captcha", URL: "https://hcaptcha.com/siteverify"}},
+			wantErr: true,
+		},
+		{
+			name:    "api key without key",
+			conf:    chainconfig.Faucet{APIKeys: []chainconfig.FaucetAPIKey{{Name: "ci"}}},
+			wantErr: true,
+		},
+		{
+			name:    "api key with invalid coins max",
+			conf:    chainconfig.Faucet{APIKeys: []chainconfig.FaucetAPIKey{{Name: "ci", Key: "key", CoinsMax: []string{"token"}}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := faucetAccessOptions(tt.conf)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestFaucetPoolOptions(t *testing.T) {
+	name := "faucet"
+	newConf := func(account chainconfig.Account, pool chainconfig.FaucetPool) chainconfig.Config {
+		return chainconfig.Config{
+			Accounts: []chainconfig.Account{account},
+			Faucet:   chainconfig.Faucet{Name: &name, Pool: pool},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		conf        chainconfig.Config
+		wantOptions int
+		wantErr     bool
+	}{
+		{
+			name: "no pool",
+			conf: newConf(chainconfig.Account{Name: name}, chainconfig.FaucetPool{}),
+		},
+		{
+			name:        "pool from the faucet account",
+			conf:        newConf(chainconfig.Account{Name: name, Mnemonic: "mnemonic"}, chainconfig.FaucetPool{Size: 3}),
+			wantOptions: 1,
+		},
+		{
+			name: "pool with refills",
+			conf: newConf(chainconfig.Account{Name: name}, chainconfig.FaucetPool{
+				Size:            3,
+				Mnemonic:        "mnemonic",
+				RefillThreshold: []string{"100token"},
+				RefillAmount:    []string{"1000token"},
+			}),
+			wantOptions: 2,
+		},
+		{
+			name:    "pool without mnemonic",
+			conf:    newConf(chainconfig.Account{Name: name}, chainconfig.FaucetPool{Size: 3}),
+			wantErr: true,
+		},
+		{
+			name: "refill without amount",
+			conf: newConf(chainconfig.Account{Name: name}, chainconfig.FaucetPool{
+				Size:            3,
+				Mnemonic:        "mnemonic",
+				RefillThreshold: []string{"100token"},
+			}),
+			wantErr: true,
+		},
+		{
+			name: "invalid refill amount",
+			conf: newConf(chainconfig.Account{Name: name}, chainconfig.FaucetPool{
+				Size:            3,
+				Mnemonic:        "mnemonic",
+				RefillThreshold: []string{"100token"},
+				RefillAmount:    []string{"token"},
+			}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options, err := faucetPoolOptions(tt.conf)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, options, tt.wantOptions)
+		})
+	}
+}
+
+func TestFaucetCoinOptions(t *testing.T) {
+	options, err := faucetCoinOptions(
+		[]string{"10token", "5transfer/channel-0/uatom"},
+		[]string{"100token"},
+		map[string]string{"atom": "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"},
+	)
+	require.NoError(t, err)
+	require.Len(t, options, 3)
+
+	_, err = faucetCoinOptions([]string{"token"}, nil, nil)
+	require.Error(t, err)
+
+	_, err = faucetCoinOptions([]string{"10token"}, []string{"token"}, nil)
+	require.Error(t, err)
+}
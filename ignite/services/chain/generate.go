@@ -6,9 +6,12 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/ignite/cli/ignite/chainconfig"
 	"github.com/ignite/cli/ignite/pkg/cache"
 	"github.com/ignite/cli/ignite/pkg/cosmosanalysis/module"
 	"github.com/ignite/cli/ignite/pkg/cosmosgen"
+	"github.com/ignite/cli/ignite/pkg/protodep"
+	"github.com/ignite/cli/ignite/pkg/xfilepath"
 )
 
 const (
@@ -17,11 +20,18 @@ const (
 	defaultOpenAPIPath = "docs/static/openapi.yml"
 )
 
+// protoCachePath is the place where third party proto dependencies are fetched into.
+var protoCachePath = xfilepath.Join(
+	chainconfig.ConfigDirPath,
+	xfilepath.Path("proto-cache"),
+)
+
 type generateOptions struct {
 	isGoEnabled      bool
 	isVuexEnabled    bool
 	isDartEnabled    bool
 	isOpenAPIEnabled bool
+	isPluginsEnabled bool
 }
 
 // GenerateTarget is a target to generate code for from proto files.
@@ -55,6 +65,13 @@ func GenerateOpenAPI() GenerateTarget {
 	}
 }
 
+// GeneratePlugins enables generating code with the custom protoc plugins defined in the config.yml.
+func GeneratePlugins() GenerateTarget {
+	return func(o *generateOptions) {
+		o.isPluginsEnabled = true
+	}
+}
+
 func (c *Chain) generateAll(ctx context.Context, cacheStorage cache.Storage) error {
 	conf, err := c.Config()
 	if err != nil {
@@ -75,6 +92,10 @@ func (c *Chain) generateAll(ctx context.Context, cacheStorage cache.Storage) err
 		additionalTargets = append(additionalTargets, GenerateOpenAPI())
 	}
 
+	if len(conf.Client.Plugins) > 0 {
+		additionalTargets = append(additionalTargets, GeneratePlugins())
+	}
+
 	return c.Generate(ctx, cacheStorage, GenerateGo(), additionalTargets...)
 }
 
@@ -102,8 +123,14 @@ func (c *Chain) Generate(
 
 	fmt.Fprintln(c.stdLog().out, "🛠️  Building proto...")
 
+	dependencyPaths, err := c.resolveProtoDependencies(ctx, conf)
+	if err != nil {
+		return err
+	}
+
 	options := []cosmosgen.Option{
 		cosmosgen.IncludeDirs(conf.Build.Proto.ThirdPartyPaths),
+		cosmosgen.DependencyPaths(dependencyPaths),
 	}
 
 	if targetOptions.isGoEnabled {
@@ -166,6 +193,18 @@ func (c *Chain) Generate(
 		options = append(options, cosmosgen.WithOpenAPIGeneration(openAPIPath))
 	}
 
+	if targetOptions.isPluginsEnabled {
+		for _, p := range conf.Client.Plugins {
+			options = append(options, cosmosgen.WithPluginGeneration(cosmosgen.Plugin{
+				Name:                     p.Name,
+				Path:                     p.Binary,
+				Options:                  p.Options,
+				Out:                      p.Out,
+				IncludeThirdPartyModules: p.IncludeThirdParty,
+			}))
+		}
+	}
+
 	if err := cosmosgen.Generate(ctx, cacheStorage, c.app.Path, conf.Build.Proto.Path, options...); err != nil {
 		return &CannotBuildAppError{err}
 	}
@@ -174,3 +213,42 @@ func (c *Chain) Generate(
 
 	return nil
 }
+
+// UpdateProtoDependencies resolves the revisions of the proto dependencies defined in
+// the config.yml again and pins the new versions in the lock file.
+func (c *Chain) UpdateProtoDependencies(ctx context.Context) error {
+	conf, err := c.Config()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.resolveProtoDependencies(ctx, conf, protodep.Update())
+	return err
+}
+
+// resolveProtoDependencies fetches the proto dependencies defined in the config.yml
+// into the cache and returns their include paths.
+func (c *Chain) resolveProtoDependencies(
+	ctx context.Context,
+	conf chainconfig.Config,
+	options ...protodep.Option,
+) ([]string, error) {
+	cacheDir, err := protoCachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	var deps []protodep.Dependency
+	for _, d := range conf.Build.Proto.Dependencies {
+		deps = append(deps, protodep.Dependency{
+			Module:   d.Module,
+			Git:      d.Git,
+			Revision: d.Revision,
+			Path:     d.Path,
+		})
+	}
+
+	lockPath := filepath.Join(c.app.Path, protodep.LockFileName)
+
+	return protodep.Resolve(ctx, cacheDir, lockPath, deps, options...)
+}
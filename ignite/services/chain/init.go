@@ -10,6 +10,7 @@ import (
 	"github.com/imdario/mergo"
 
 	"github.com/ignite/cli/ignite/chainconfig"
+	"github.com/ignite/cli/ignite/pkg/chaincmd"
 	chaincmdrunner "github.com/ignite/cli/ignite/pkg/chaincmd/runner"
 	"github.com/ignite/cli/ignite/pkg/confile"
 )
@@ -138,7 +139,15 @@ func (c *Chain) InitAccounts(ctx context.Context, conf chainconfig.Config) error
 
 		// If the account doesn't provide an address, we create one
 		if accountAddress == "" {
-			generatedAccount, err = commands.AddAccount(ctx, account.Name, account.Mnemonic, account.CoinType)
+			generatedAccount, err = commands.AddAccount(
+				ctx,
+				account.Name,
+				account.Mnemonic,
+				account.CoinType,
+				chaincmd.KeyWithAccountIndex(account.AccountIndex),
+				chaincmd.KeyWithAddressIndex(account.AddressIndex),
+				chaincmd.KeyWithHDPath(account.HDPath),
+			)
 			if err != nil {
 				return err
 			}
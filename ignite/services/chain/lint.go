@@ -0,0 +1,32 @@
+package chain
+
+import (
+	"context"
+	"path/filepath"
+
+	"github.com/ignite/cli/ignite/pkg/protolint"
+)
+
+// LintProto lints the app's proto files against Cosmos SDK module conventions.
+// Paths of the returned issues are relative to the app's root.
+func (c *Chain) LintProto(ctx context.Context, options ...protolint.Option) ([]protolint.Issue, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	protoPath := filepath.Join(c.app.Path, conf.Build.Proto.Path)
+
+	issues, err := protolint.Lint(ctx, protoPath, c.app.ImportPath, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, issue := range issues {
+		if path, err := filepath.Rel(c.app.Path, issue.Path); err == nil {
+			issues[i].Path = path
+		}
+	}
+
+	return issues, nil
+}
@@ -8,16 +8,19 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/cenkalti/backoff"
 	"github.com/otiai10/copy"
 	"github.com/pkg/errors"
+	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
 	"golang.org/x/sync/errgroup"
 
 	"github.com/ignite/cli/ignite/chainconfig"
 	"github.com/ignite/cli/ignite/pkg/cache"
 	chaincmdrunner "github.com/ignite/cli/ignite/pkg/chaincmd/runner"
-	"github.com/ignite/cli/ignite/pkg/cosmosfaucet"
 	"github.com/ignite/cli/ignite/pkg/dirchange"
 	"github.com/ignite/cli/ignite/pkg/localfs"
 	"github.com/ignite/cli/ignite/pkg/xexec"
@@ -39,6 +42,9 @@ const (
 	// configChecksumKey is the cache key for containing the checksum to detect config modification
 	configChecksumKey = "config_checksum"
 
+	// portOffsetKey is the cache key for the port offset of the servers to detect its modification
+	portOffsetKey = "port_offset"
+
 	// serveDirchangeCacheNamespace is the name of the cache namespace for detecting changes in directories
 	serveDirchangeCacheNamespace = "serve.dirchange"
 )
@@ -272,13 +278,19 @@ func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceRese
 	if isInit {
 		configModified := false
 		if c.ConfigPath() != "" {
-			configModified, err = dirchange.HasDirChecksumChanged(dirCache, configChecksumKey, c.app.Path, c.ConfigPath())
+			configModified, err = c.hasDirChecksumChanged(dirCache, configChecksumKey, c.app.Path, c.ConfigPath())
 			if err != nil {
 				return err
 			}
 		}
 
-		if forceReset || configModified {
+		// the ports are set to the node config on init only.
+		portOffsetModified, err := c.hasPortOffsetChanged(dirCache)
+		if err != nil {
+			return err
+		}
+
+		if forceReset || configModified || portOffsetModified {
 			// if forceReset is set, we consider the app as being not initialized
 			fmt.Fprintln(c.stdLog().out, "🔄 Resetting the app state...")
 			isInit = false
@@ -287,7 +299,7 @@ func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceRese
 
 	// check if source has been modified since last serve
 	// if the state must not be reset but the source has changed, we rebuild the chain and import the exported state
-	sourceModified, err := dirchange.HasDirChecksumChanged(dirCache, sourceChecksumKey, c.app.Path, appBackendSourceWatchPaths...)
+	sourceModified, err := c.hasDirChecksumChanged(dirCache, sourceChecksumKey, c.app.Path, appBackendSourceWatchPaths...)
 	if err != nil {
 		return err
 	}
@@ -305,7 +317,7 @@ func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceRese
 		}
 		binaryModified = true
 	} else {
-		binaryModified, err = dirchange.HasDirChecksumChanged(dirCache, binaryChecksumKey, "", binaryPath)
+		binaryModified, err = c.hasDirChecksumChanged(dirCache, binaryChecksumKey, "", binaryPath)
 		if err != nil {
 			return err
 		}
@@ -359,18 +371,21 @@ func (c *Chain) serve(ctx context.Context, cacheStorage cache.Storage, forceRese
 
 	// save checksums
 	if c.ConfigPath() != "" {
-		if err := dirchange.SaveDirChecksum(dirCache, configChecksumKey, c.app.Path, c.ConfigPath()); err != nil {
+		if err := c.saveDirChecksum(dirCache, configChecksumKey, c.app.Path, c.ConfigPath()); err != nil {
 			return err
 		}
 	}
-	if err := dirchange.SaveDirChecksum(dirCache, sourceChecksumKey, c.app.Path, appBackendSourceWatchPaths...); err != nil {
+	if err := c.saveDirChecksum(dirCache, sourceChecksumKey, c.app.Path, appBackendSourceWatchPaths...); err != nil {
 		return err
 	}
 	binaryPath, err = exec.LookPath(binaryName)
 	if err != nil {
 		return err
 	}
-	if err := dirchange.SaveDirChecksum(dirCache, binaryChecksumKey, "", binaryPath); err != nil {
+	if err := c.saveDirChecksum(dirCache, binaryChecksumKey, "", binaryPath); err != nil {
+		return err
+	}
+	if err := c.savePortOffset(dirCache); err != nil {
 		return err
 	}
 
@@ -390,19 +405,23 @@ func (c *Chain) start(ctx context.Context, config chainconfig.Config) error {
 	g.Go(func() error { return c.plugin.Start(ctx, commands, config) })
 
 	// start the faucet if enabled.
-	faucet, err := c.Faucet(ctx)
-	isFaucetEnabled := err != ErrFaucetIsNotEnabled
+	isFaucetEnabled := config.Faucet.Name != nil
 
 	if isFaucetEnabled {
-		if err == ErrFaucetAccountDoesNotExist {
-			return &CannotBuildAppError{errors.Wrap(err, "faucet account doesn't exist")}
-		}
-		if err != nil {
-			return err
-		}
-
 		g.Go(func() (err error) {
-			if err := c.runFaucetServer(ctx, faucet); err != nil {
+			if err := c.runFaucetServer(ctx); err != nil {
+				return &CannotBuildAppError{err}
+			}
+			return nil
+		})
+	}
+
+	// start the console if enabled.
+	isConsoleEnabled := !config.Console.Disabled
+
+	if isConsoleEnabled {
+		g.Go(func() error {
+			if err := c.runConsoleServer(ctx); err != nil {
 				return &CannotBuildAppError{err}
 			}
 			return nil
@@ -426,21 +445,155 @@ func (c *Chain) start(ctx context.Context, config chainconfig.Config) error {
 		fmt.Fprintf(c.stdLog().out, "🌍 Token faucet: %s\n", faucetAddr)
 	}
 
+	if isConsoleEnabled {
+		consoleAddr, _ := xurl.HTTP(config.Console.Host)
+		fmt.Fprintf(c.stdLog().out, "🌍 Developer console: %s\n", consoleAddr)
+	}
+
 	return g.Wait()
 }
 
-func (c *Chain) runFaucetServer(ctx context.Context, faucet cosmosfaucet.Faucet) error {
+// runFaucetServer serves the faucet once the chain's node is ready to accept connections.
+func (c *Chain) runFaucetServer(ctx context.Context) error {
 	config, err := c.Config()
 	if err != nil {
 		return err
 	}
 
+	if err := c.waitUntilNodeIsReady(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+
+	faucet, err := c.Faucet(ctx)
+	if err == ErrFaucetAccountDoesNotExist {
+		return errors.Wrap(err, "faucet account doesn't exist")
+	}
+	if err != nil {
+		return err
+	}
+	defer faucet.Close()
+
 	return xhttp.Serve(ctx, &http.Server{
 		Addr:    chainconfig.FaucetHost(config),
 		Handler: faucet,
 	})
 }
 
+// runConsoleServer serves the developer console once the chain's node is ready to accept connections.
+func (c *Chain) runConsoleServer(ctx context.Context) error {
+	config, err := c.Config()
+	if err != nil {
+		return err
+	}
+
+	if err := c.waitUntilNodeIsReady(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil
+		}
+		return err
+	}
+
+	console, err := c.Console(ctx)
+	if err != nil {
+		return err
+	}
+
+	return xhttp.Serve(ctx, &http.Server{
+		Addr:    config.Console.Host,
+		Handler: console,
+	})
+}
+
+// waitUntilNodeIsReady waits until the RPC server of the chain's node accepts connections.
+func (c *Chain) waitUntilNodeIsReady(ctx context.Context) error {
+	config, err := c.Config()
+	if err != nil {
+		return err
+	}
+
+	nodeAddress, err := xurl.HTTP(config.Host.RPC)
+	if err != nil {
+		return fmt.Errorf("invalid host rpc address format: %w", err)
+	}
+
+	client, err := rpchttp.New(nodeAddress, "/websocket")
+	if err != nil {
+		return err
+	}
+
+	return backoff.Retry(func() error {
+		_, err := client.Status(ctx)
+		return err
+	}, backoff.WithContext(backoff.NewConstantBackOff(time.Second), ctx))
+}
+
+// cacheKey returns the cache key of name for the chain, the keys are set by chain so several chains
+// can be served at once.
+func (c *Chain) cacheKey(name string) (string, error) {
+	id, err := c.ID()
+	if err != nil {
+		return "", err
+	}
+	return cache.Key(name, "_", id), nil
+}
+
+// hasDirChecksumChanged checks if the checksum of paths saved for the chain with name changed.
+// The checksums saved before they were set by chain are used when the chain has none.
+func (c *Chain) hasDirChecksumChanged(dirCache cache.Cache[[]byte], name, workdir string, paths ...string) (bool, error) {
+	key, err := c.cacheKey(name)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := dirCache.Get(key); errors.Is(err, cache.ErrorNotFound) {
+		key = name
+	} else if err != nil {
+		return false, err
+	}
+
+	return dirchange.HasDirChecksumChanged(dirCache, key, workdir, paths...)
+}
+
+// saveDirChecksum saves the checksum of paths for the chain with name.
+func (c *Chain) saveDirChecksum(dirCache cache.Cache[[]byte], name, workdir string, paths ...string) error {
+	key, err := c.cacheKey(name)
+	if err != nil {
+		return err
+	}
+	return dirchange.SaveDirChecksum(dirCache, key, workdir, paths...)
+}
+
+// hasPortOffsetChanged checks if the port offset of the chain changed since it was last served.
+func (c *Chain) hasPortOffsetChanged(dirCache cache.Cache[[]byte]) (bool, error) {
+	key, err := c.cacheKey(portOffsetKey)
+	if err != nil {
+		return false, err
+	}
+
+	saved, err := dirCache.Get(key)
+	if errors.Is(err, cache.ErrorNotFound) {
+		// the chains served before the port offsets were saved have no offset.
+		return c.options.portOffset != 0, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return string(saved) != strconv.Itoa(c.options.portOffset), nil
+}
+
+// savePortOffset saves the port offset of the chain.
+func (c *Chain) savePortOffset(dirCache cache.Cache[[]byte]) error {
+	key, err := c.cacheKey(portOffsetKey)
+	if err != nil {
+		return err
+	}
+	return dirCache.Put(key, []byte(strconv.Itoa(c.options.portOffset)))
+}
+
 // saveChainState runs the export command of the chain and store the exported genesis in the chain saved config
 func (c *Chain) saveChainState(ctx context.Context, commands chaincmdrunner.Runner) error {
 	genesisPath, err := c.exportedGenesisPath()
@@ -0,0 +1,56 @@
+package chain
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/pkg/cache"
+)
+
+func TestServeCacheByChain(t *testing.T) {
+	storage, err := cache.NewStorage(filepath.Join(t.TempDir(), "cache.db"))
+	require.NoError(t, err)
+	dirCache := cache.New[[]byte](storage, serveDirchangeCacheNamespace)
+
+	var (
+		workdir = t.TempDir()
+		mars    = &Chain{options: chainOptions{chainID: "mars"}}
+		venus   = &Chain{options: chainOptions{chainID: "venus"}}
+	)
+
+	write := func(content string) {
+		require.NoError(t, os.WriteFile(filepath.Join(workdir, "config.yml"), []byte(content), 0o644))
+	}
+
+	// the checksums of a chain are not overwritten by the ones of another chain.
+	write("mars")
+	require.NoError(t, mars.saveDirChecksum(dirCache, configChecksumKey, workdir, "config.yml"))
+	write("venus")
+	require.NoError(t, venus.saveDirChecksum(dirCache, configChecksumKey, workdir, "config.yml"))
+
+	changed, err := venus.hasDirChecksumChanged(dirCache, configChecksumKey, workdir, "config.yml")
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	changed, err = mars.hasDirChecksumChanged(dirCache, configChecksumKey, workdir, "config.yml")
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	// the port offsets default to zero.
+	changed, err = mars.hasPortOffsetChanged(dirCache)
+	require.NoError(t, err)
+	require.False(t, changed)
+
+	venus.SetPortOffset(10)
+	changed, err = venus.hasPortOffsetChanged(dirCache)
+	require.NoError(t, err)
+	require.True(t, changed)
+
+	require.NoError(t, venus.savePortOffset(dirCache))
+	changed, err = venus.hasPortOffsetChanged(dirCache)
+	require.NoError(t, err)
+	require.False(t, changed)
+}
@@ -0,0 +1,512 @@
+// Package ibcdevnet serves two chains in development next to each other and relays the IBC
+// packets in between while both chains reload on source changes.
+package ibcdevnet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	channeltypes "github.com/cosmos/ibc-go/v3/modules/core/04-channel/types"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/ignite/cli/ignite/chainconfig"
+	"github.com/ignite/cli/ignite/pkg/cache"
+	"github.com/ignite/cli/ignite/pkg/cosmosaccount"
+	"github.com/ignite/cli/ignite/pkg/cosmosanalysis/module"
+	"github.com/ignite/cli/ignite/pkg/cosmosclient"
+	"github.com/ignite/cli/ignite/pkg/ctxticker"
+	"github.com/ignite/cli/ignite/pkg/relayer"
+	relayerconf "github.com/ignite/cli/ignite/pkg/relayer/config"
+	"github.com/ignite/cli/ignite/pkg/xurl"
+	"github.com/ignite/cli/ignite/services/chain"
+)
+
+const (
+	// portOffsetStep is the step the ports of the peer chain are moved by until they don't overlap
+	// the ports of the first chain.
+	portOffsetStep = 10
+
+	// maxPortOffsetTries is the number of port offsets tried for the peer chain.
+	maxPortOffsetTries = 10
+
+	// relayInterval is the interval to relay the pending packets between the chains.
+	relayInterval = time.Second * 2
+)
+
+// Devnet is a development network of two chains connected by a relayer.
+type Devnet struct {
+	chains [2]*chain.Chain
+	ca     cosmosaccount.Registry
+	r      relayer.Relayer
+	stdout io.Writer
+
+	// pathIDs are the ids of the relayer paths between the chains, one for each IBC port.
+	pathIDs []string
+
+	// linked is true when the paths are linked to the current state of the chains.
+	linked bool
+
+	// lastErr is the last relayer error printed, the same error isn't printed on every retry.
+	lastErr string
+}
+
+// Option configures Devnet.
+type Option func(*Devnet)
+
+// Stdout sets the writer the relayer logs are printed to.
+func Stdout(w io.Writer) Option {
+	return func(d *Devnet) {
+		d.stdout = w
+	}
+}
+
+// New creates a devnet for c and peer, the ports of the servers of peer are moved to not overlap
+// the ones of c. The faucets of both chains must be enabled to fund the relayer accounts from ca.
+func New(c, peer *chain.Chain, ca cosmosaccount.Registry, options ...Option) (*Devnet, error) {
+	d := &Devnet{
+		chains: [2]*chain.Chain{c, peer},
+		ca:     ca,
+		r:      relayer.New(ca),
+		stdout: os.Stdout,
+	}
+
+	for _, apply := range options {
+		apply(d)
+	}
+
+	id, err := c.ID()
+	if err != nil {
+		return nil, err
+	}
+	peerID, err := peer.ID()
+	if err != nil {
+		return nil, err
+	}
+	if id == peerID {
+		return nil, fmt.Errorf("chains must have different ids to be connected, both are %q", id)
+	}
+
+	home, err := c.Home()
+	if err != nil {
+		return nil, err
+	}
+	peerHome, err := peer.Home()
+	if err != nil {
+		return nil, err
+	}
+	if home == peerHome {
+		return nil, fmt.Errorf("chains must have different homes, both are %s", home)
+	}
+
+	conf, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+	peerConf, err := peer.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cc := range []struct {
+		id   string
+		conf chainconfig.Config
+	}{{id, conf}, {peerID, peerConf}} {
+		if cc.conf.Faucet.Name == nil {
+			return nil, fmt.Errorf("the faucet of %s must be enabled to fund the relayer", cc.id)
+		}
+	}
+
+	offset, err := portOffset(conf, peerConf)
+	if err != nil {
+		return nil, err
+	}
+	peer.SetPortOffset(offset)
+
+	return d, nil
+}
+
+// Serve serves both chains and relays the packets between them until ctx is canceled.
+func (d *Devnet) Serve(ctx context.Context, cacheStorage cache.Storage, options ...chain.ServeOption) error {
+	g, ctx := errgroup.WithContext(ctx)
+
+	for _, c := range d.chains {
+		c := c
+		g.Go(func() error {
+			return c.Serve(ctx, cacheStorage, options...)
+		})
+	}
+
+	g.Go(func() error {
+		return d.relay(ctx)
+	})
+
+	return g.Wait()
+}
+
+// relay links the chains once both are up and relays their packets. The paths are linked again
+// when the state of a chain is reset. Relayer errors are printed and never stop the chains.
+func (d *Devnet) relay(ctx context.Context) error {
+	return ctxticker.DoNow(ctx, relayInterval, func() error {
+		err := d.relayOnce(ctx)
+		if err == nil || ctx.Err() != nil {
+			d.lastErr = ""
+			return nil
+		}
+
+		d.linked = false
+		if err.Error() != d.lastErr {
+			d.lastErr = err.Error()
+			fmt.Fprintf(d.stdout, "🔌 relayer: %s\n", err)
+		}
+		return nil
+	})
+}
+
+func (d *Devnet) relayOnce(ctx context.Context) error {
+	for _, c := range d.chains {
+		if !d.isNodeUp(ctx, c) {
+			d.linked = false
+			return nil
+		}
+	}
+
+	if d.pathIDs == nil {
+		if err := d.setup(ctx); err != nil {
+			return err
+		}
+	}
+
+	if !d.linked {
+		if err := d.link(ctx); err != nil {
+			return err
+		}
+	}
+
+	for _, id := range d.pathIDs {
+		if err := d.r.Clear(ctx, id); err != nil {
+			return fmt.Errorf("cannot relay packets of %s: %w", id, err)
+		}
+	}
+
+	return nil
+}
+
+// isNodeUp checks if the node of c is serving blocks.
+func (d *Devnet) isNodeUp(ctx context.Context, c *chain.Chain) bool {
+	conf, err := c.Config()
+	if err != nil {
+		return false
+	}
+
+	rpcAddress, err := localAddress(conf.Host.RPC)
+	if err != nil {
+		return false
+	}
+
+	client, err := cosmosclient.New(ctx, cosmosclient.WithNodeAddress(rpcAddress))
+	if err != nil {
+		return false
+	}
+
+	status, err := client.RPC.Status(ctx)
+	if err != nil {
+		return false
+	}
+
+	return status.SyncInfo.LatestBlockHeight > 0
+}
+
+// setup adds the chains to the relayer config with funded accounts and finds or creates the paths
+// between them, one for each IBC port of the apps.
+func (d *Devnet) setup(ctx context.Context) error {
+	if err := d.ca.EnsureDefaultAccount(); err != nil {
+		return err
+	}
+
+	var (
+		chains [2]*relayer.Chain
+		ports  [2][]module.IBCPort
+	)
+
+	for i, c := range d.chains {
+		rc, err := d.relayerChain(ctx, c)
+		if err != nil {
+			return err
+		}
+		chains[i] = rc
+
+		if ports[i], err = c.IBCPorts(); err != nil {
+			return err
+		}
+	}
+
+	conf, err := relayerconf.Get()
+	if err != nil {
+		return err
+	}
+
+	var pathIDs []string
+	for _, port := range commonPorts(ports[0], ports[1]) {
+		id, ok := findPath(conf, chains[0].ID, chains[1].ID, port.PortID)
+		if !ok {
+			options := []relayer.ChannelOption{
+				relayer.SourcePort(port.PortID),
+				relayer.TargetPort(port.PortID),
+				relayer.SourceVersion(port.Version),
+				relayer.TargetVersion(port.Version),
+			}
+			if port.Ordering == relayer.OrderingOrdered {
+				options = append(options, relayer.Ordered())
+			}
+
+			if id, err = chains[0].Connect(chains[1], options...); err != nil {
+				return err
+			}
+		}
+		pathIDs = append(pathIDs, id)
+	}
+
+	d.pathIDs = pathIDs
+	return nil
+}
+
+// relayerChain adds c to the relayer config and funds the relayer account from the faucet of c.
+func (d *Devnet) relayerChain(ctx context.Context, c *chain.Chain) (*relayer.Chain, error) {
+	conf, err := c.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	rpcAddress, err := localAddress(conf.Host.RPC)
+	if err != nil {
+		return nil, err
+	}
+
+	faucetAddress, err := localAddress(chainconfig.FaucetHost(conf))
+	if err != nil {
+		return nil, err
+	}
+
+	addressPrefix, err := c.AddressPrefix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	staked, err := sdk.ParseCoinNormalized(conf.Validator.Staked)
+	if err != nil {
+		return nil, fmt.Errorf("invalid staked coins of the validator: %w", err)
+	}
+
+	rc, _, err := d.r.NewChain(
+		cosmosaccount.DefaultAccount,
+		rpcAddress,
+		relayer.WithFaucet(faucetAddress),
+		relayer.WithGasPrice("0"+staked.Denom),
+		relayer.WithAddressPrefix(addressPrefix),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rc.EnsureChainSetup(ctx); err != nil {
+		return nil, err
+	}
+
+	// the faucet may refuse to fund an account that already received coins.
+	if _, err := rc.TryRetrieve(ctx); err != nil {
+		fmt.Fprintf(d.stdout, "🔌 relayer: cannot retrieve coins on %s: %s\n", rc.ID, err)
+	}
+
+	return rc, nil
+}
+
+// link links the paths between the chains. The paths linked to a previous state of the chains are
+// reset before being linked again.
+func (d *Devnet) link(ctx context.Context) error {
+	for _, id := range d.pathIDs {
+		status, err := d.r.Status(ctx, id)
+		if err != nil || (status.Linked && !isOpen(status)) {
+			if err := resetPath(id); err != nil {
+				return err
+			}
+		} else if status.Linked {
+			continue
+		}
+
+		if err := d.r.LinkPaths(ctx, id); err != nil {
+			return fmt.Errorf("cannot link %s: %w", id, err)
+		}
+
+		path, err := d.r.GetPath(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(
+			d.stdout,
+			"🔌 relayer: linked %s/%s on %s to %s/%s on %s\n",
+			path.Src.PortID, path.Src.ChannelID, path.Src.ChainID,
+			path.Dst.PortID, path.Dst.ChannelID, path.Dst.ChainID,
+		)
+	}
+
+	d.linked = true
+	return nil
+}
+
+// isOpen checks if the channels of both ends of a linked path are open.
+func isOpen(status relayer.PathStatus) bool {
+	open := channeltypes.OPEN.String()
+	return status.Src.ChannelState == open && status.Dst.ChannelState == open
+}
+
+// resetPath removes the connections and channels of the path with id from the relayer config,
+// together with the light clients of its chains, to link the path from scratch.
+func resetPath(id string) error {
+	conf, err := relayerconf.Get()
+	if err != nil {
+		return err
+	}
+
+	path, err := conf.PathByID(id)
+	if err != nil {
+		return err
+	}
+
+	for i, chain := range conf.Chains {
+		if chain.ID == path.Src.ChainID || chain.ID == path.Dst.ChainID {
+			conf.Chains[i].ClientID = ""
+		}
+	}
+
+	for _, end := range []*relayerconf.PathEnd{&path.Src, &path.Dst} {
+		*end = relayerconf.PathEnd{
+			ChainID: end.ChainID,
+			PortID:  end.PortID,
+			Version: end.Version,
+		}
+	}
+
+	if err := conf.UpdatePath(path); err != nil {
+		return err
+	}
+
+	return relayerconf.Save(conf)
+}
+
+// findPath returns the id of the path from the relayer config between the chains with srcID and
+// dstID on port.
+func findPath(conf relayerconf.Config, srcID, dstID, port string) (id string, ok bool) {
+	for _, path := range conf.Paths {
+		if path.Src.ChainID == srcID &&
+			path.Dst.ChainID == dstID &&
+			path.Src.PortID == port &&
+			path.Dst.PortID == port {
+			return path.ID, true
+		}
+	}
+	return "", false
+}
+
+// commonPorts returns the transfer port and the IBC ports scaffolded in both apps.
+func commonPorts(ports, peerPorts []module.IBCPort) []module.IBCPort {
+	common := []module.IBCPort{{
+		Module:   relayer.TransferPort,
+		PortID:   relayer.TransferPort,
+		Version:  relayer.TransferVersion,
+		Ordering: relayer.OrderingUnordered,
+	}}
+
+	for _, port := range ports {
+		for _, peerPort := range peerPorts {
+			if port.PortID == peerPort.PortID && port.Version == peerPort.Version && port.Ordering == peerPort.Ordering {
+				common = append(common, port)
+				break
+			}
+		}
+	}
+
+	return common
+}
+
+// portOffset returns the smallest offset the ports of peerConf must be moved by to not overlap
+// the ports of conf.
+func portOffset(conf, peerConf chainconfig.Config) (int, error) {
+	used := make(map[int]bool)
+	for _, address := range chainconfig.Addresses(conf) {
+		port, err := addressPort(address)
+		if err != nil {
+			return 0, err
+		}
+		used[port] = true
+	}
+
+	var peerPorts []int
+	for _, address := range chainconfig.Addresses(peerConf) {
+		port, err := addressPort(address)
+		if err != nil {
+			return 0, err
+		}
+		peerPorts = append(peerPorts, port)
+	}
+
+	for i := 0; i < maxPortOffsetTries; i++ {
+		offset := i * portOffsetStep
+		overlap := false
+		for _, port := range peerPorts {
+			if port != 0 && used[port+offset] {
+				overlap = true
+				break
+			}
+		}
+		if !overlap {
+			return offset, nil
+		}
+	}
+
+	return 0, errors.New("cannot find free ports for the peer chain, change its hosts in its config")
+}
+
+// addressPort returns the port of address, 0 when address is empty.
+func addressPort(address string) (int, error) {
+	if address == "" {
+		return 0, nil
+	}
+
+	if i := strings.Index(address, "://"); i != -1 {
+		address = address[i+3:]
+	}
+
+	_, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return 0, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	return strconv.Atoi(port)
+}
+
+// localAddress returns the HTTP address to reach the server listening on address from the local host.
+func localAddress(address string) (string, error) {
+	if i := strings.Index(address, "://"); i != -1 {
+		address = address[i+3:]
+	}
+
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	if host == "" || host == "0.0.0.0" {
+		host = "localhost"
+	}
+
+	return xurl.HTTP(net.JoinHostPort(host, port))
+}
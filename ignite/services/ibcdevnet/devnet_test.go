@@ -0,0 +1,63 @@
+package ibcdevnet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ignite/cli/ignite/chainconfig"
+	"github.com/ignite/cli/ignite/pkg/cosmosanalysis/module"
+	"github.com/ignite/cli/ignite/pkg/relayer"
+)
+
+func TestPortOffset(t *testing.T) {
+	conf := chainconfig.DefaultConf
+
+	offset, err := portOffset(conf, conf)
+	require.NoError(t, err)
+	require.Equal(t, portOffsetStep, offset)
+
+	peerConf, err := chainconfig.OffsetPorts(conf, 100)
+	require.NoError(t, err)
+	offset, err = portOffset(conf, peerConf)
+	require.NoError(t, err)
+	require.Equal(t, 0, offset)
+
+	// the rpc port of the peer overlaps the p2p port with the first offset.
+	peerConf = conf
+	peerConf.Host.RPC = "0.0.0.0:26646"
+	offset, err = portOffset(conf, peerConf)
+	require.NoError(t, err)
+	require.Equal(t, 2*portOffsetStep, offset)
+
+	peerConf.Host.RPC = "0.0.0.0"
+	_, err = portOffset(conf, peerConf)
+	require.Error(t, err)
+}
+
+func TestCommonPorts(t *testing.T) {
+	var (
+		blog  = module.IBCPort{Module: "blog", PortID: "blog", Version: "blog-1", Ordering: relayer.OrderingUnordered}
+		chat  = module.IBCPort{Module: "chat", PortID: "chat", Version: "chat-1", Ordering: relayer.OrderingOrdered}
+		chat2 = module.IBCPort{Module: "chat", PortID: "chat", Version: "chat-2", Ordering: relayer.OrderingOrdered}
+	)
+
+	ports := commonPorts([]module.IBCPort{blog, chat}, []module.IBCPort{chat2, blog})
+
+	require.Len(t, ports, 2)
+	require.Equal(t, relayer.TransferPort, ports[0].PortID)
+	require.Equal(t, blog, ports[1])
+}
+
+func TestLocalAddress(t *testing.T) {
+	for address, want := range map[string]string{
+		"0.0.0.0:26657":           "http://localhost:26657",
+		":4500":                   "http://localhost:4500",
+		"tcp://127.0.0.1:26657":   "http://127.0.0.1:26657",
+		"http://example.com:1317": "http://example.com:1317",
+	} {
+		got, err := localAddress(address)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+	}
+}